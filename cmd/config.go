@@ -40,6 +40,7 @@ import (
 func newConfigCmd() *cobra.Command {
 	var stack string
 	var showSecrets bool
+	var showOrigin bool
 	var jsonOut bool
 
 	cmd := &cobra.Command{
@@ -59,13 +60,16 @@ func newConfigCmd() *cobra.Command {
 				return err
 			}
 
-			return listConfig(stack, showSecrets, jsonOut)
+			return listConfig(stack, showSecrets, showOrigin, jsonOut)
 		}),
 	}
 
 	cmd.Flags().BoolVar(
 		&showSecrets, "show-secrets", false,
 		"Show secret values when listing config instead of displaying blinded values")
+	cmd.Flags().BoolVar(
+		&showOrigin, "show-origin", false,
+		"Show the configuration file each value comes from, including values inherited from a base file")
 	cmd.Flags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
@@ -414,15 +418,26 @@ type configValueJSON struct {
 	Value       *string     `json:"value,omitempty"`
 	ObjectValue interface{} `json:"objectValue,omitempty"`
 	Secret      bool        `json:"secret"`
+	// Origin is the configuration file the value comes from. It is only set when --show-origin is passed.
+	Origin string `json:"origin,omitempty"`
 }
 
-func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
+func listConfig(stack backend.Stack, showSecrets bool, showOrigin bool, jsonOut bool) error {
 	ps, err := loadProjectStack(stack)
 	if err != nil {
 		return err
 	}
 
-	cfg := ps.Config
+	// Secrets inherited from a base file only need to be moved under the stack's secrets manager if we're going to
+	// decrypt them; otherwise they are blinded regardless of who encrypted them.
+	var encrypter func() (config.Encrypter, error)
+	if showSecrets {
+		encrypter = func() (config.Encrypter, error) { return getStackEncrypter(stack) }
+	}
+	cfg, origins, err := mergeStackConfig(stack, ps, encrypter)
+	if err != nil {
+		return err
+	}
 
 	// By default, we will use a blinding decrypter to show "[secret]". If requested, display secrets in plaintext.
 	decrypter := config.NewBlindingDecrypter()
@@ -448,6 +463,9 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 			entry := configValueJSON{
 				Secret: cfg[key].Secure(),
 			}
+			if showOrigin {
+				entry.Origin = origins[key]
+			}
 
 			decrypted, err := cfg[key].Value(decrypter)
 			if err != nil {
@@ -486,11 +504,19 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 				return errors.Wrap(err, "could not decrypt configuration value")
 			}
 
-			rows = append(rows, cmdutil.TableRow{Columns: []string{prettyKey(key), decrypted}})
+			columns := []string{prettyKey(key), decrypted}
+			if showOrigin {
+				columns = append(columns, origins[key])
+			}
+			rows = append(rows, cmdutil.TableRow{Columns: columns})
 		}
 
+		headers := []string{"KEY", "VALUE"}
+		if showOrigin {
+			headers = append(headers, "ORIGIN")
+		}
 		cmdutil.PrintTable(cmdutil.Table{
-			Headers: []string{"KEY", "VALUE"},
+			Headers: headers,
 			Rows:    rows,
 		})
 	}
@@ -504,7 +530,12 @@ func getConfig(stack backend.Stack, key config.Key, path, jsonOut bool) error {
 		return err
 	}
 
-	cfg := ps.Config
+	cfg, _, err := mergeStackConfig(stack, ps, func() (config.Encrypter, error) {
+		return getStackEncrypter(stack)
+	})
+	if err != nil {
+		return err
+	}
 
 	v, ok, err := cfg.Get(key, path)
 	if err != nil {
@@ -597,12 +628,17 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
 	}
 
+	cfg, _, err := mergeStackConfig(stack, workspaceStack, sm.Encrypter)
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
+	}
+
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
-	if !workspaceStack.Config.HasSecureValue() {
+	if !cfg.HasSecureValue() {
 		return backend.StackConfiguration{
-			Config:    workspaceStack.Config,
+			Config:    cfg,
 			Decrypter: config.NewPanicCrypter(),
 		}, nil
 	}
@@ -613,7 +649,61 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 	}
 
 	return backend.StackConfiguration{
-		Config:    workspaceStack.Config,
+		Config:    cfg,
 		Decrypter: crypter,
 	}, nil
 }
+
+// mergeStackConfig returns the stack's configuration layered on top of the configuration of each base file it
+// inherits from, along with the path of the file each key's value came from. Secure values in a base that is protected
+// by a different secrets manager than the stack are re-encrypted using the encrypter returned by encrypter, so that
+// the stack's decrypter can read the entire result. If encrypter is nil, secure values are merged as-is, which is only
+// appropriate when they will never be decrypted.
+func mergeStackConfig(stack backend.Stack, ps *workspace.ProjectStack,
+	encrypter func() (config.Encrypter, error)) (config.Map, map[config.Key]string, error) {
+
+	stackPath, err := getProjectStackPath(stack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged, origins := make(config.Map), make(map[config.Key]string)
+	merge := func(path string, cfg config.Map) error {
+		if err := merged.Merge(cfg); err != nil {
+			return errors.Wrapf(err, "merging configuration from '%s'", path)
+		}
+		for key := range cfg {
+			origins[key] = path
+		}
+		return nil
+	}
+
+	for _, base := range ps.Bases() {
+		cfg := base.Stack.Config
+		if cfg.HasSecureValue() && encrypter != nil && !sameSecretsManager(ps, base.Stack) {
+			sm, err := getBaseSecretsManager(stack, base)
+			if err != nil {
+				return nil, nil, err
+			}
+			dec, err := sm.Decrypter()
+			if err != nil {
+				return nil, nil, err
+			}
+			enc, err := encrypter()
+			if err != nil {
+				return nil, nil, err
+			}
+			if cfg, err = cfg.Copy(dec, enc); err != nil {
+				return nil, nil, errors.Wrapf(err, "re-encrypting secrets from '%s'", base.Path)
+			}
+		}
+		if err := merge(base.Path, cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := merge(stackPath, ps.Config); err != nil {
+		return nil, nil, err
+	}
+
+	return merged, origins, nil
+}
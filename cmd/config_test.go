@@ -15,10 +15,14 @@
 package cmd
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -41,3 +45,76 @@ func TestSecretDetection(t *testing.T) {
 	// The key name does not match the, so even though this "looks like" a secret, we say it is not.
 	assert.False(t, looksLikeSecret(config.MustMakeKey("test", "okay"), "1415fc1f4eaeb5e096ee58c1480016638fff29bf"))
 }
+
+type testStackReference tokens.QName
+
+func (r testStackReference) String() string     { return string(r) }
+func (r testStackReference) Name() tokens.QName { return tokens.QName(r) }
+
+func TestMergeStackConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-config-bases")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldPassphrase, hadPassphrase := os.LookupEnv("PULUMI_CONFIG_PASSPHRASE")
+	assert.NoError(t, os.Setenv("PULUMI_CONFIG_PASSPHRASE", "password"))
+	defer func() {
+		if hadPassphrase {
+			os.Setenv("PULUMI_CONFIG_PASSPHRASE", oldPassphrase)
+		} else {
+			os.Unsetenv("PULUMI_CONFIG_PASSPHRASE")
+		}
+	}()
+
+	basePath, stackPath := filepath.Join(dir, "Pulumi.common.yaml"), filepath.Join(dir, "Pulumi.dev.yaml")
+	oldStackConfigFile := stackConfigFile
+	stackConfigFile = stackPath
+	defer func() { stackConfigFile = oldStackConfigFile }()
+
+	// Both files get their own encryption salt, and so their own key.
+	baseSM, err := newPassphraseSecretsManager("dev", basePath)
+	assert.NoError(t, err)
+	baseEnc, err := baseSM.Encrypter()
+	assert.NoError(t, err)
+	secret, err := baseEnc.EncryptValue("hunter2")
+	assert.NoError(t, err)
+	base, err := workspace.LoadProjectStack(basePath)
+	assert.NoError(t, err)
+	base.Config = config.Map{
+		config.MustMakeKey("proj", "password"): config.NewSecureValue(secret),
+		config.MustMakeKey("proj", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "tags"):     config.NewObjectValue(`{"env":"common","owner":"infra"}`),
+	}
+	assert.NoError(t, base.Save(basePath))
+
+	assert.NoError(t, (&workspace.ProjectStack{Base: "Pulumi.common.yaml"}).Save(stackPath))
+	stackSM, err := newPassphraseSecretsManager("dev", stackPath)
+	assert.NoError(t, err)
+	ps, err := workspace.LoadProjectStack(stackPath)
+	assert.NoError(t, err)
+	assert.NoError(t, ps.Config.Set(config.MustMakeKey("proj", "tags.env"), config.NewValue("dev"), true))
+	assert.NoError(t, ps.Save(stackPath))
+
+	ps, err = workspace.LoadProjectStack(stackPath)
+	assert.NoError(t, err)
+	s := &backend.MockStack{
+		RefF: func() backend.StackReference { return testStackReference("dev") },
+	}
+	cfg, origins, err := mergeStackConfig(s, ps, stackSM.Encrypter)
+	assert.NoError(t, err)
+
+	dec, err := stackSM.Decrypter()
+	assert.NoError(t, err)
+	values, err := cfg.Decrypt(dec)
+	assert.NoError(t, err)
+	assert.Equal(t, map[config.Key]string{
+		config.MustMakeKey("proj", "password"): "hunter2",
+		config.MustMakeKey("proj", "region"):   "us-west-2",
+		config.MustMakeKey("proj", "tags"):     `{"env":"dev","owner":"infra"}`,
+	}, values)
+	assert.Equal(t, map[config.Key]string{
+		config.MustMakeKey("proj", "password"): basePath,
+		config.MustMakeKey("proj", "region"):   basePath,
+		config.MustMakeKey("proj", "tags"):     stackPath,
+	}, origins)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/secrets"
 	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 func getStackEncrypter(s backend.Stack) (config.Encrypter, error) {
@@ -76,6 +77,31 @@ func getStackSecretsManager(s backend.Stack) (secrets.Manager, error) {
 	return stack.NewCachingSecretsManager(sm), nil
 }
 
+// getBaseSecretsManager returns the secrets manager for a base configuration file that the stack inherits from.
+func getBaseSecretsManager(s backend.Stack, base workspace.ProjectStackLayer) (secrets.Manager, error) {
+	ps := base.Stack
+	if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
+		return newCloudSecretsManager(s.Ref().Name(), base.Path, ps.SecretsProvider)
+	}
+	if ps.EncryptionSalt != "" {
+		return newPassphraseSecretsManager(s.Ref().Name(), base.Path)
+	}
+	return nil, errors.Errorf(
+		"base configuration '%s' contains secrets but has no secrets provider of its own; "+
+			"only passphrase and cloud secrets providers are supported for inherited secrets", base.Path)
+}
+
+// sameSecretsManager returns true if secrets in both stack configurations are protected by the same key.
+func sameSecretsManager(a, b *workspace.ProjectStack) bool {
+	if a.EncryptionSalt == "" && a.EncryptedKey == "" {
+		// Service-managed secrets are keyed per stack, so two files never share them.
+		return false
+	}
+	return a.SecretsProvider == b.SecretsProvider &&
+		a.EncryptionSalt == b.EncryptionSalt &&
+		a.EncryptedKey == b.EncryptedKey
+}
+
 func validateSecretsProvider(typ string) error {
 	kind := strings.SplitN(typ, ":", 2)[0]
 	supportedKinds := []string{"default", "passphrase", "awskms", "azurekeyvault", "gcpkms", "hashivault"}
@@ -45,6 +45,11 @@ func newDestroyCmd() *cobra.Command {
 	var showSames bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var rawStringDiffs bool
+	var summary string
+	var showOnly []string
+	var hide []string
+	var subtree string
 	var yes bool
 	var targets *[]string
 	var targetDependents bool
@@ -62,6 +67,11 @@ func newDestroyCmd() *cobra.Command {
 			"Warning: this command is generally irreversible and should be used with great care.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			groupedSummary, err := summaryFlagToGrouped(summary)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			interactive := cmdutil.Interactive()
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
@@ -83,20 +93,29 @@ func newDestroyCmd() *cobra.Command {
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
 				SuppressOutputs:      suppressOutputs,
+				RawStringDiffs:       rawStringDiffs,
+				GroupedSummary:       groupedSummary,
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
+			if err := applyDisplayFilterFlags(&opts.Display, showOnly, hide, subtree); err != nil {
+				return result.FromError(err)
+			}
 
 			s, err := requireStack(stack, false, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return result.FromError(err)
 			}
+			if err := applySubtreeParents(&opts.Display, s); err != nil {
+				return result.FromError(err)
+			}
 			proj, root, err := readProject()
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.Display.ProjectFileSource = projectFileSource(root)
 
 			m, err := getUpdateMetadata(message, root)
 			if err != nil {
@@ -194,6 +213,24 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&rawStringDiffs, "raw-string-diffs", false,
+		"Diff strings that contain JSON or YAML documents as text rather than as structured documents")
+	cmd.PersistentFlags().StringVar(
+		&summary, "summary", "default",
+		"The summary of changes to display: default or grouped, which also counts the changes to each "+
+			"resource type, module and top-level component")
+	cmd.PersistentFlags().StringArrayVar(
+		&showOnly, "show-only", []string{},
+		"Only show the resources that match this filter: type=<glob>, name=<glob> or urn=<glob>, or ~= and a regular "+
+			"expression in place of = and a glob (e.g. type=aws:iam/*). Multiple filters can be specified")
+	cmd.PersistentFlags().StringArrayVar(
+		&hide, "hide", []string{},
+		"Hide the resources that match this filter, which takes the same form as --show-only. "+
+			"Multiple filters can be specified")
+	cmd.PersistentFlags().StringVar(
+		&subtree, "subtree", "",
+		"Only show the resource with this URN, e.g. a component, and its descendants")
 
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
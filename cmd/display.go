@@ -0,0 +1,38 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newDisplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "display",
+		Short: "Render the output of previous Pulumi operations",
+		Long: "Render the output of previous Pulumi operations.\n" +
+			"\n" +
+			"The display family of commands renders data recorded by earlier invocations of the\n" +
+			"Pulumi CLI, such as the event logs written by `--event-log`, using the same displays\n" +
+			"that `pulumi up` and `pulumi preview` use for live operations.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newDisplayReplayCmd())
+
+	return cmd
+}
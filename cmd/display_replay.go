@@ -0,0 +1,316 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// replayEventTypes are the event types that may be selected with `--type`.
+var replayEventTypes = []engine.EventType{
+	engine.StdoutColorEvent,
+	engine.DiagEvent,
+	engine.PreludeEvent,
+	engine.SummaryEvent,
+	engine.ResourcePreEvent,
+	engine.ResourceOutputsEvent,
+	engine.ResourceOperationFailed,
+	engine.PolicyViolationEvent,
+}
+
+// loggedEvent is an engine event read from an event log, along with the time at which it was logged.
+type loggedEvent struct {
+	Event     engine.Event
+	Timestamp time.Time
+}
+
+// eventLogFilter selects the events of an event log to replay. Empty sets select everything.
+type eventLogFilter struct {
+	URNs  map[resource.URN]bool
+	Types map[engine.EventType]bool
+}
+
+// newEventLogFilter creates a filter from the values of the `--urn` and `--type` flags.
+func newEventLogFilter(urns, types []string) (eventLogFilter, error) {
+	filter := eventLogFilter{URNs: map[resource.URN]bool{}, Types: map[engine.EventType]bool{}}
+	for _, urn := range urns {
+		filter.URNs[resource.URN(urn)] = true
+	}
+
+	known := map[engine.EventType]bool{}
+	var names []string
+	for _, t := range replayEventTypes {
+		known[t] = true
+		names = append(names, string(t))
+	}
+	for _, t := range types {
+		if !known[engine.EventType(t)] {
+			return eventLogFilter{}, errors.Errorf("unknown event type %q; expected one of %s",
+				t, strings.Join(names, ", "))
+		}
+		filter.Types[engine.EventType(t)] = true
+	}
+	return filter, nil
+}
+
+// eventURN returns the URN of the resource an event pertains to, if any.
+func eventURN(e engine.Event) resource.URN {
+	switch p := e.Payload.(type) {
+	case engine.DiagEventPayload:
+		return p.URN
+	case engine.PolicyViolationEventPayload:
+		return p.ResourceURN
+	case engine.ResourcePreEventPayload:
+		return p.Metadata.URN
+	case engine.ResourceOutputsEventPayload:
+		return p.Metadata.URN
+	case engine.ResourceOperationFailedPayload:
+		return p.Metadata.URN
+	default:
+		return ""
+	}
+}
+
+// Include returns true if the given event should be replayed. When filtering by URN, the prelude and summary events
+// that frame the operation are kept, but all other events that do not pertain to one of the URNs are dropped.
+func (f eventLogFilter) Include(e engine.Event) bool {
+	if len(f.Types) != 0 && !f.Types[e.Type] {
+		return false
+	}
+	if len(f.URNs) != 0 && e.Type != engine.PreludeEvent && e.Type != engine.SummaryEvent {
+		return f.URNs[eventURN(e)]
+	}
+	return true
+}
+
+// readEventLog reads the events written to an event log by the `--event-log` flag, discarding those that are not
+// selected by the filter. The cancellation event that terminates the log is always discarded.
+func readEventLog(r io.Reader, filter eventLogFilter) ([]loggedEvent, error) {
+	var events []loggedEvent
+	for dec := json.NewDecoder(r); ; {
+		var apiEvent apitype.EngineEvent
+		if err := dec.Decode(&apiEvent); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "reading event log")
+		}
+
+		e, err := display.ConvertJSONEvent(apiEvent)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting event %d", apiEvent.Sequence)
+		}
+		if e.Type == engine.CancelEvent || !filter.Include(e) {
+			continue
+		}
+		events = append(events, loggedEvent{Event: e, Timestamp: time.Unix(int64(apiEvent.Timestamp), 0)})
+	}
+	return events, nil
+}
+
+// describeEventLog determines the stack and project an event log pertains to and whether it was written by a
+// preview. None of these are recorded in the log itself, so they are recovered from the events: the first URN gives
+// the stack and project, and resource events are only marked as planning during previews.
+func describeEventLog(events []loggedEvent) (tokens.QName, tokens.PackageName, bool) {
+	var stack tokens.QName
+	var proj tokens.PackageName
+	isPreview := false
+	for _, e := range events {
+		if urn := eventURN(e.Event); stack == "" && urn.IsValid() {
+			stack, proj = urn.Stack(), urn.Project()
+		}
+		switch p := e.Event.Payload.(type) {
+		case engine.ResourcePreEventPayload:
+			isPreview = isPreview || p.Planning
+		case engine.ResourceOutputsEventPayload:
+			isPreview = isPreview || p.Planning
+		}
+	}
+	return stack, proj, isPreview
+}
+
+// markPreview sets the IsPreview flags of prelude and summary events, which are not recorded in event logs.
+func markPreview(e engine.Event, isPreview bool) engine.Event {
+	switch p := e.Payload.(type) {
+	case engine.PreludeEventPayload:
+		p.IsPreview = isPreview
+		e.Payload = p
+	case engine.SummaryEventPayload:
+		p.IsPreview = isPreview
+		e.Payload = p
+	}
+	return e
+}
+
+func newDisplayReplayCmd() *cobra.Command {
+	var urns []string
+	var types []string
+	var realTime bool
+
+	var debug bool
+	var diffDisplay bool
+	var jsonDisplay bool
+	var showConfig bool
+	var showReplacementSteps bool
+	var showSames bool
+	var showReads bool
+	var suppressOutputs bool
+	var rawStringDiffs bool
+
+	var cmd = &cobra.Command{
+		Use:   "replay <event-log>",
+		Short: "Replay an engine event log through the Pulumi displays",
+		Long: "Replay an engine event log through the Pulumi displays.\n" +
+			"\n" +
+			"This command reads the engine events recorded by a previous operation's `--event-log`\n" +
+			"flag and renders them as that operation would have been rendered, e.g. to inspect the\n" +
+			"result of an update that ran in CI. By default, the events are rendered with the\n" +
+			"progress display; use `--diff` or `--json` to select a different display.\n" +
+			"\n" +
+			"Events are rendered as fast as possible unless `--real-time` is passed, in which case\n" +
+			"the original delays between events are reproduced. The `--urn` and `--type` flags\n" +
+			"restrict the replay to the events that pertain to particular resources or that have\n" +
+			"particular types.\n" +
+			"\n" +
+			"Secret values are never recorded in event logs and are displayed as [secret].",
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			filter, err := newEventLogFilter(urns, types)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(f)
+
+			logged, err := readEventLog(f, filter)
+			if err != nil {
+				return err
+			}
+
+			stack, proj, isPreview := describeEventLog(logged)
+			kind := apitype.UpdateUpdate
+			if isPreview {
+				kind = apitype.PreviewUpdate
+			}
+
+			var displayType = display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+			opts := display.Options{
+				Color:                cmdutil.GetGlobalColorization(),
+				ShowConfig:           showConfig,
+				ShowReplacementSteps: showReplacementSteps,
+				ShowSameResources:    showSames,
+				ShowReads:            showReads,
+				SuppressOutputs:      suppressOutputs,
+				RawStringDiffs:       rawStringDiffs,
+				IsInteractive:        cmdutil.Interactive(),
+				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
+				Debug:                debug,
+			}
+
+			events, done := make(chan engine.Event), make(chan bool)
+			go func() {
+				var last time.Time
+				for _, e := range logged {
+					if realTime && !last.IsZero() && e.Timestamp.After(last) {
+						time.Sleep(e.Timestamp.Sub(last))
+					}
+					last = e.Timestamp
+
+					events <- markPreview(e.Event, isPreview)
+				}
+				events <- engine.Event{Type: engine.CancelEvent}
+			}()
+
+			op := backend.ActionLabel(kind, isPreview)
+			switch {
+			case jsonDisplay:
+				display.ShowJSONEvents(op, kind, events, done, opts)
+			case diffDisplay:
+				display.ShowDiffEvents(op, kind, events, done, opts)
+			default:
+				display.ShowProgressEvents(op, kind, stack, proj, events, done, opts, isPreview)
+			}
+			<-done
+
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringArrayVar(
+		&urns, "urn", []string{},
+		"Only replay the events for the resource with the given URN. Multiple resources can be specified using"+
+			" --urn urn1 --urn urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&types, "type", []string{},
+		"Only replay events of the given type (e.g. diag or resource-pre). Multiple types can be specified using"+
+			" --type type1 --type type2")
+	cmd.PersistentFlags().BoolVar(
+		&realTime, "real-time", false,
+		"Reproduce the delays between events that were observed when the event log was written")
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output recorded in the event log")
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Serialize the diffs, operations, and overall output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&showConfig, "show-config", false,
+		"Show configuration keys and variables")
+	cmd.PersistentFlags().BoolVar(
+		&showReplacementSteps, "show-replacement-steps", false,
+		"Show detailed resource replacement creates and deletes instead of a single step")
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false,
+		"Show resources that needn't be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&showReads, "show-reads", false,
+		"Show resources that are being read in, alongside those being managed directly in the stack")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&rawStringDiffs, "raw-string-diffs", false,
+		"Diff strings that contain JSON or YAML documents as text rather than as structured documents")
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+const testEventLog = `{"sequence":0,"timestamp":100,"preludeEvent":{"config":{}}}
+{"sequence":1,"timestamp":100,"resourcePreEvent":{"metadata":{"op":"create",` +
+	`"urn":"urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev","type":"pulumi:pulumi:Stack","old":null,` +
+	`"new":{"type":"pulumi:pulumi:Stack","urn":"urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",` +
+	`"id":"","parent":"","inputs":{},"outputs":{},"provider":""},"provider":""},"planning":true}}
+{"sequence":2,"timestamp":101,"diagnosticEvent":{"urn":"urn:pulumi:dev::proj::random:index:Id::id",` +
+	`"message":"hello\n","color":"raw","severity":"info"}}
+{"sequence":3,"timestamp":101,"diagnosticEvent":{"message":"global\n","color":"raw","severity":"warning"}}
+{"sequence":4,"timestamp":102,"summaryEvent":{"maybeCorrupt":false,"durationSeconds":2,` +
+	`"resourceChanges":{"create":1},"PolicyPacks":{}}}
+{"sequence":5,"timestamp":102,"cancelEvent":{}}
+`
+
+func TestReadEventLog(t *testing.T) {
+	all, err := newEventLogFilter(nil, nil)
+	assert.NoError(t, err)
+
+	events, err := readEventLog(strings.NewReader(testEventLog), all)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// The cancellation event is dropped.
+	var types []engine.EventType
+	for _, e := range events {
+		types = append(types, e.Event.Type)
+	}
+	assert.Equal(t, []engine.EventType{
+		engine.PreludeEvent, engine.ResourcePreEvent, engine.DiagEvent, engine.DiagEvent, engine.SummaryEvent,
+	}, types)
+	assert.Equal(t, int64(100), events[0].Timestamp.Unix())
+	assert.Equal(t, int64(102), events[4].Timestamp.Unix())
+
+	stack, proj, isPreview := describeEventLog(events)
+	assert.Equal(t, "dev", string(stack))
+	assert.Equal(t, "proj", string(proj))
+	assert.True(t, isPreview)
+
+	prelude := markPreview(events[0].Event, isPreview)
+	assert.True(t, prelude.Payload.(engine.PreludeEventPayload).IsPreview)
+}
+
+func TestEventLogFilter(t *testing.T) {
+	urn := "urn:pulumi:dev::proj::random:index:Id::id"
+
+	byURN, err := newEventLogFilter([]string{urn}, nil)
+	assert.NoError(t, err)
+	events, err := readEventLog(strings.NewReader(testEventLog), byURN)
+	if assert.NoError(t, err) && assert.Len(t, events, 3) {
+		assert.Equal(t, engine.PreludeEvent, events[0].Event.Type)
+		assert.Equal(t, resource.URN(urn), eventURN(events[1].Event))
+		assert.Equal(t, engine.SummaryEvent, events[2].Event.Type)
+	}
+
+	byType, err := newEventLogFilter(nil, []string{"diag"})
+	assert.NoError(t, err)
+	events, err = readEventLog(strings.NewReader(testEventLog), byType)
+	if assert.NoError(t, err) && assert.Len(t, events, 2) {
+		assert.Equal(t, engine.DiagEvent, events[0].Event.Type)
+		assert.Equal(t, engine.DiagEvent, events[1].Event.Type)
+	}
+
+	_, err = newEventLogFilter(nil, []string{"cancel"})
+	assert.Error(t, err)
+}
+
+func TestReadEventLogErrors(t *testing.T) {
+	all, err := newEventLogFilter(nil, nil)
+	assert.NoError(t, err)
+
+	_, err = readEventLog(strings.NewReader(`{"sequence":0,`), all)
+	assert.Error(t, err)
+
+	_, err = readEventLog(strings.NewReader(`{"sequence":0,"timestamp":0}`), all)
+	assert.Error(t, err)
+}
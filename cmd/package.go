@@ -0,0 +1,87 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func newPackageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package",
+		Short: "Work with Pulumi packages",
+		Long: "Work with Pulumi packages.\n" +
+			"\n" +
+			"A package is the set of resources, types and functions served by a resource\n" +
+			"provider plugin, as described by the provider's schema.  The package family of\n" +
+			"commands provides a way of inspecting packages and the schemas that describe them.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newPackageDiffSchemaCmd())
+	cmd.AddCommand(newPackageGenDocsCmd())
+	cmd.AddCommand(newPackageGenSDKCmd())
+	cmd.AddCommand(newPackageGetSchemaCmd())
+
+	return cmd
+}
+
+// getProviderSchema loads the installed resource provider plugin with the given name and version and fetches its
+// schema.  The raw schema is returned along with the validated package spec.
+func getProviderSchema(name string, version *semver.Version) ([]byte, schema.PackageSpec, error) {
+	ctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, "", nil, nil)
+	if err != nil {
+		return nil, schema.PackageSpec{}, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	prov, err := ctx.Host.Provider(tokens.Package(name), version)
+	if err != nil {
+		return nil, schema.PackageSpec{}, errors.Wrapf(err, "loading provider %s", name)
+	}
+
+	bytes, err := prov.GetSchema(0)
+	if err != nil {
+		return nil, schema.PackageSpec{}, errors.Wrapf(err, "fetching schema for provider %s", name)
+	}
+
+	spec, err := parseSchema(bytes)
+	if err != nil {
+		return nil, schema.PackageSpec{}, errors.Wrapf(err, "provider %s returned an invalid schema", name)
+	}
+	return bytes, spec, nil
+}
+
+// parseSchema decodes and validates a JSON-encoded package schema.
+func parseSchema(bytes []byte) (schema.PackageSpec, error) {
+	var spec schema.PackageSpec
+	if err := json.Unmarshal(bytes, &spec); err != nil {
+		return schema.PackageSpec{}, err
+	}
+	if _, err := schema.ImportSpec(spec); err != nil {
+		return schema.PackageSpec{}, err
+	}
+	return spec, nil
+}
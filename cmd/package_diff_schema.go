@@ -0,0 +1,127 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newPackageDiffSchemaCmd() *cobra.Command {
+	var jsonOut bool
+	var cmd = &cobra.Command{
+		Use:   "diff-schema OLD_SCHEMA_SOURCE NEW_SCHEMA_SOURCE",
+		Args:  cmdutil.ExactArgs(2),
+		Short: "Compare two versions of a Pulumi package schema",
+		Long: "Compare two versions of a Pulumi package schema.\n" +
+			"\n" +
+			"Each SCHEMA_SOURCE is either the path to a JSON schema file or the name of an installed\n" +
+			"resource provider plugin, optionally followed by @VERSION, whose schema is fetched\n" +
+			"from the plugin.  Every change from the old schema to the new schema is listed and\n" +
+			"classified as breaking or non-breaking.  Removed resources, functions, types and\n" +
+			"properties, changes to the types of properties and new requirements on inputs are\n" +
+			"breaking; additions are not.\n" +
+			"\n" +
+			"The command exits with a non-zero exit code if any change is breaking, so that it\n" +
+			"can be used to gate releases in CI.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			oldSpec, err := loadSchemaSource(args[0])
+			if err != nil {
+				return err
+			}
+			newSpec, err := loadSchemaSource(args[1])
+			if err != nil {
+				return err
+			}
+
+			changes := schema.ComparePackageSpecs(oldSpec, newSpec)
+			if jsonOut {
+				err = printSchemaChangesJSON(changes)
+			} else {
+				err = printSchemaChanges(os.Stdout, changes)
+			}
+			if err != nil {
+				return err
+			}
+
+			if schema.HasBreakingChanges(changes) {
+				return errors.New("the new schema has breaking changes")
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	return cmd
+}
+
+// schemaChangesJSON is the shape of the --json output of the diff-schema command.  While we can add fields to this
+// structure in the future, we should not change existing fields.
+type schemaChangesJSON struct {
+	Breaking bool            `json:"breaking"`
+	Changes  []schema.Change `json:"changes"`
+}
+
+func printSchemaChangesJSON(changes []schema.Change) error {
+	if changes == nil {
+		changes = []schema.Change{}
+	}
+	return printJSON(schemaChangesJSON{
+		Breaking: schema.HasBreakingChanges(changes),
+		Changes:  changes,
+	})
+}
+
+// printSchemaChanges prints the breaking changes followed by the non-breaking changes.
+func printSchemaChanges(w io.Writer, changes []schema.Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	for _, breaking := range []bool{true, false} {
+		header := "Breaking changes:"
+		if !breaking {
+			header = "Non-breaking changes:"
+		}
+
+		printed := false
+		for _, c := range changes {
+			if c.Breaking != breaking {
+				continue
+			}
+			if !printed {
+				if _, err := fmt.Fprintln(w, header); err != nil {
+					return err
+				}
+				printed = true
+			}
+			if _, err := fmt.Fprintf(w, "    %s\n", c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+func loadDiffTestSchemas(t *testing.T) (schema.PackageSpec, schema.PackageSpec) {
+	path := filepath.Join("testdata", "gen-sdk", "schema.json")
+	old, err := loadSchemaSource(path)
+	assert.NoError(t, err)
+	new, err := loadSchemaSource(path)
+	assert.NoError(t, err)
+	return old, new
+}
+
+func TestDiffSchemaNoChanges(t *testing.T) {
+	old, new := loadDiffTestSchemas(t)
+
+	changes := schema.ComparePackageSpecs(old, new)
+	assert.Empty(t, changes)
+
+	var buf bytes.Buffer
+	assert.NoError(t, printSchemaChanges(&buf, changes))
+	assert.Equal(t, "No changes.\n", buf.String())
+}
+
+func TestDiffSchemaChanges(t *testing.T) {
+	old, new := loadDiffTestSchemas(t)
+
+	// Make the firewall's rules required, drop its URL output, and add an optional input.
+	firewall := new.Resources["example:index:Firewall"]
+	firewall.RequiredInputs = append(firewall.RequiredInputs, "rules")
+	firewall.Properties = map[string]schema.PropertySpec{
+		"name":   firewall.Properties["name"],
+		"rules":  firewall.Properties["rules"],
+		"action": firewall.Properties["action"],
+	}
+	firewall.InputProperties = map[string]schema.PropertySpec{
+		"name":        firewall.InputProperties["name"],
+		"rules":       firewall.InputProperties["rules"],
+		"action":      firewall.InputProperties["action"],
+		"description": {TypeSpec: schema.TypeSpec{Type: "string"}},
+	}
+	new.Resources["example:index:Firewall"] = firewall
+
+	// Change the type of a rule's port and make its protocol required.
+	rule := new.Types["example:index:Rule"]
+	rule.Properties = map[string]schema.PropertySpec{
+		"port":     {TypeSpec: schema.TypeSpec{Type: "string"}},
+		"protocol": rule.Properties["protocol"],
+	}
+	rule.Required = []string{"port", "protocol"}
+	new.Types["example:index:Rule"] = rule
+
+	// Add a protocol and remove the lookup function.
+	protocol := new.Types["example:index:Protocol"]
+	protocol.Enum = append(protocol.Enum[:len(protocol.Enum):len(protocol.Enum)], schema.EnumValueSpec{Value: "sctp"})
+	new.Types["example:index:Protocol"] = protocol
+	new.Functions = nil
+
+	changes := schema.ComparePackageSpecs(old, new)
+	assert.Equal(t, []schema.Change{
+		{
+			Path:     `functions["example:index:getFirewall"]`,
+			Kind:     schema.ChangeRemoved,
+			Breaking: true,
+			Message:  "function was removed",
+		},
+		{
+			Path:     `resources["example:index:Firewall"].inputProperties["description"]`,
+			Kind:     schema.ChangeAdded,
+			Breaking: false,
+			Message:  "property was added",
+		},
+		{
+			Path:     `resources["example:index:Firewall"].inputProperties["rules"]`,
+			Kind:     schema.ChangeMadeRequired,
+			Breaking: true,
+			Message:  "property is now required",
+		},
+		{
+			Path:     `resources["example:index:Firewall"].properties["url"]`,
+			Kind:     schema.ChangeRemoved,
+			Breaking: true,
+			Message:  "property was removed",
+		},
+		{
+			Path:     `types["example:index:Protocol"].enum["sctp"]`,
+			Kind:     schema.ChangeAdded,
+			Breaking: false,
+			Message:  "enum value was added",
+		},
+		{
+			Path:     `types["example:index:Rule"].properties["port"]`,
+			Kind:     schema.ChangeTypeChanged,
+			Breaking: true,
+			Message:  "type changed from integer to string",
+		},
+		{
+			Path:     `types["example:index:Rule"].properties["protocol"]`,
+			Kind:     schema.ChangeMadeRequired,
+			Breaking: true,
+			Message:  "property is now required",
+		},
+	}, changes)
+	assert.True(t, schema.HasBreakingChanges(changes))
+
+	var buf bytes.Buffer
+	assert.NoError(t, printSchemaChanges(&buf, changes))
+	assert.Equal(t, "Breaking changes:\n"+
+		"    functions[\"example:index:getFirewall\"]: function was removed\n"+
+		"    resources[\"example:index:Firewall\"].inputProperties[\"rules\"]: property is now required\n"+
+		"    resources[\"example:index:Firewall\"].properties[\"url\"]: property was removed\n"+
+		"    types[\"example:index:Rule\"].properties[\"port\"]: type changed from integer to string\n"+
+		"    types[\"example:index:Rule\"].properties[\"protocol\"]: property is now required\n"+
+		"Non-breaking changes:\n"+
+		"    resources[\"example:index:Firewall\"].inputProperties[\"description\"]: property was added\n"+
+		"    types[\"example:index:Protocol\"].enum[\"sctp\"]: enum value was added\n", buf.String())
+
+	// Loosening requirements on inputs is not breaking, but loosening guarantees about outputs is.
+	changes = schema.ComparePackageSpecs(new, old)
+	assert.Contains(t, changes, schema.Change{
+		Path:     `resources["example:index:Firewall"].inputProperties["rules"]`,
+		Kind:     schema.ChangeMadeOptional,
+		Breaking: false,
+		Message:  "property is now optional",
+	})
+	assert.Contains(t, changes, schema.Change{
+		Path:     `types["example:index:Protocol"].enum["sctp"]`,
+		Kind:     schema.ChangeRemoved,
+		Breaking: true,
+		Message:  "enum value was removed",
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/codegen/docs"
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// genDocsTool is the name of the tool recorded in the headers of generated pages.
+const genDocsTool = "the Pulumi Docs Generator (pulumi package gen-docs)"
+
+func newPackageGenDocsCmd() *cobra.Command {
+	var format string
+	var outDir string
+	var overwrite bool
+	var cmd = &cobra.Command{
+		Use:   "gen-docs SCHEMA_SOURCE",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Generate API reference documentation for a Pulumi package",
+		Long: "Generate API reference documentation for a Pulumi package.\n" +
+			"\n" +
+			"SCHEMA_SOURCE is either the path to a JSON schema file or the name of an installed\n" +
+			"resource provider plugin, optionally followed by @VERSION, whose schema is fetched\n" +
+			"from the plugin.  A page is generated for each module of the package that describes\n" +
+			"its resources, functions and types in each supported language.  Pages are written\n" +
+			"as Markdown or as static HTML, depending on --format.\n" +
+			"\n" +
+			"An existing output directory is left untouched unless --overwrite is passed, in\n" +
+			"which case it is removed and regenerated from scratch.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			f := docs.Format(format)
+			if f != docs.Markdown && f != docs.HTML {
+				return errors.Errorf("unsupported format '%s'; supported formats are %s, %s",
+					format, docs.Markdown, docs.HTML)
+			}
+
+			spec, err := loadSchemaSource(args[0])
+			if err != nil {
+				return err
+			}
+			pkg, err := schema.ImportSpec(spec)
+			if err != nil {
+				return errors.Wrap(err, "invalid schema")
+			}
+
+			if err = generateDocs(f, pkg, outDir, overwrite); err != nil {
+				return err
+			}
+			fmt.Printf("Generated %s documentation for %s in %s\n", f, pkg.Name, outDir)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(&format, "format", "f", string(docs.Markdown),
+		"The format of the generated pages: "+string(docs.Markdown)+" or "+string(docs.HTML))
+	cmd.PersistentFlags().StringVarP(&outDir, "out", "o", "docs",
+		"The directory in which to write the generated pages")
+	cmd.PersistentFlags().BoolVar(&overwrite, "overwrite", false,
+		"Replace any existing output directory")
+
+	return cmd
+}
+
+// generateDocs generates the documentation for the given package in the given format and writes it to dir.  If dir
+// already exists and is not empty, it is replaced if overwrite is true and an error is returned otherwise.
+func generateDocs(format docs.Format, pkg *schema.Package, dir string, overwrite bool) error {
+	if infos, err := ioutil.ReadDir(dir); err == nil && len(infos) > 0 {
+		if !overwrite {
+			return errors.Errorf("%s already exists and is not empty; pass --overwrite to replace it", dir)
+		}
+		if err = os.RemoveAll(dir); err != nil {
+			return errors.Wrapf(err, "removing %s", dir)
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	files, err := docs.GeneratePackage(genDocsTool, pkg, format)
+	if err != nil {
+		return errors.Wrap(err, "generating documentation")
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(path, contents, 0666); err != nil {
+			return errors.Wrapf(err, "writing %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/codegen/docs"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// TestGenerateDocsGolden checks the documentation generated for the example schema in testdata/gen-sdk against the
+// golden files in testdata/gen-docs.  Set PULUMI_ACCEPT to a truthy value to update the golden files.
+func TestGenerateDocsGolden(t *testing.T) {
+	pkg, done := loadTestSchema(t)
+	defer done()
+
+	accept := cmdutil.IsTruthy(os.Getenv("PULUMI_ACCEPT"))
+	for _, format := range []docs.Format{docs.Markdown, docs.HTML} {
+		t.Run(string(format), func(t *testing.T) {
+			golden := filepath.Join("testdata", "gen-docs", string(format))
+			if accept {
+				assert.NoError(t, generateDocs(format, pkg, golden, true))
+				return
+			}
+
+			dir, err := ioutil.TempDir("", "gen-docs")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			assert.NoError(t, generateDocs(format, pkg, dir, false))
+			assert.Equal(t, readSDKDir(t, golden), readSDKDir(t, dir))
+		})
+	}
+}
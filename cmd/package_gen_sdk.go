@@ -0,0 +1,182 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	dotnetgen "github.com/pulumi/pulumi/pkg/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/codegen/go"
+	nodejsgen "github.com/pulumi/pulumi/pkg/codegen/nodejs"
+	pythongen "github.com/pulumi/pulumi/pkg/codegen/python"
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// genSDKTool is the name of the tool recorded in the headers of generated files.
+const genSDKTool = "the Pulumi SDK Generator (pulumi package gen-sdk)"
+
+// sdkGenerators maps each supported language to the code generator for that language.
+var sdkGenerators = map[string]func(tool string, pkg *schema.Package) (map[string][]byte, error){
+	"dotnet": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return dotnetgen.GeneratePackage(tool, pkg, nil)
+	},
+	"go": gogen.GeneratePackage,
+	"nodejs": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return nodejsgen.GeneratePackage(tool, pkg, nil)
+	},
+	"python": func(tool string, pkg *schema.Package) (map[string][]byte, error) {
+		return pythongen.GeneratePackage(tool, pkg, nil)
+	},
+}
+
+func newPackageGenSDKCmd() *cobra.Command {
+	var languages []string
+	var outDir string
+	var overwrite bool
+	var cmd = &cobra.Command{
+		Use:   "gen-sdk SCHEMA_SOURCE",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Generate SDKs for a Pulumi package",
+		Long: "Generate SDKs for a Pulumi package.\n" +
+			"\n" +
+			"SCHEMA_SOURCE is either the path to a JSON schema file or the name of an installed\n" +
+			"resource provider plugin, optionally followed by @VERSION, whose schema is fetched\n" +
+			"from the plugin.  An SDK is generated for each language passed to --language and\n" +
+			"written to a directory named after the language within the output directory.\n" +
+			"\n" +
+			"Existing SDK directories are left untouched unless --overwrite is passed, in which\n" +
+			"case they are removed and regenerated from scratch.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if len(languages) == 0 {
+				return errors.New("at least one --language must be specified")
+			}
+			for _, lang := range languages {
+				if lang == "all" {
+					languages = sortedSDKLanguages()
+					break
+				}
+				if _, ok := sdkGenerators[lang]; !ok {
+					return errors.Errorf("unsupported language '%s'; supported languages are %s",
+						lang, strings.Join(sortedSDKLanguages(), ", "))
+				}
+			}
+
+			spec, err := loadSchemaSource(args[0])
+			if err != nil {
+				return err
+			}
+			pkg, err := schema.ImportSpec(spec)
+			if err != nil {
+				return errors.Wrap(err, "invalid schema")
+			}
+
+			for _, lang := range languages {
+				dir := filepath.Join(outDir, lang)
+				if err = generateSDK(lang, pkg, dir, overwrite); err != nil {
+					return err
+				}
+				fmt.Printf("Generated %s SDK for %s in %s\n", lang, pkg.Name, dir)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVarP(&languages, "language", "l", nil,
+		"The language(s) to generate SDKs for: "+strings.Join(sortedSDKLanguages(), ", ")+", or all")
+	cmd.PersistentFlags().StringVarP(&outDir, "out", "o", "sdk",
+		"The directory in which to write the generated SDKs")
+	cmd.PersistentFlags().BoolVar(&overwrite, "overwrite", false,
+		"Replace any existing SDK directories")
+
+	return cmd
+}
+
+// sortedSDKLanguages returns the languages for which SDKs can be generated, in sorted order.
+func sortedSDKLanguages() []string {
+	var langs []string
+	for lang := range sdkGenerators {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// loadSchemaSource loads a package schema from either a JSON file or an installed resource provider plugin.
+func loadSchemaSource(source string) (schema.PackageSpec, error) {
+	if strings.HasSuffix(source, ".json") {
+		bytes, err := ioutil.ReadFile(source)
+		if err != nil {
+			return schema.PackageSpec{}, errors.Wrap(err, "reading schema")
+		}
+		spec, err := parseSchema(bytes)
+		if err != nil {
+			return schema.PackageSpec{}, errors.Wrapf(err, "invalid schema %s", source)
+		}
+		return spec, nil
+	}
+
+	name := source
+	var version *semver.Version
+	if at := strings.Index(source, "@"); at != -1 {
+		v, err := semver.ParseTolerant(source[at+1:])
+		if err != nil {
+			return schema.PackageSpec{}, errors.Wrap(err, "invalid plugin semver")
+		}
+		name, version = source[:at], &v
+	}
+	_, spec, err := getProviderSchema(name, version)
+	return spec, err
+}
+
+// generateSDK generates the SDK for the given package in the given language and writes it to dir.  If dir already
+// exists and is not empty, it is replaced if overwrite is true and an error is returned otherwise.
+func generateSDK(lang string, pkg *schema.Package, dir string, overwrite bool) error {
+	if infos, err := ioutil.ReadDir(dir); err == nil && len(infos) > 0 {
+		if !overwrite {
+			return errors.Errorf("%s already exists and is not empty; pass --overwrite to replace it", dir)
+		}
+		if err = os.RemoveAll(dir); err != nil {
+			return errors.Wrapf(err, "removing %s", dir)
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	files, err := sdkGenerators[lang](genSDKTool, pkg)
+	if err != nil {
+		return errors.Wrapf(err, "generating %s SDK", lang)
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(path, contents, 0666); err != nil {
+			return errors.Wrapf(err, "writing %s", path)
+		}
+	}
+	return nil
+}
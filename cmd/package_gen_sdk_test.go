@@ -0,0 +1,116 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// readSDKDir reads all of the files in the given directory into a map from slash-separated relative path to contents.
+func readSDKDir(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(contents)
+		return nil
+	})
+	assert.NoError(t, err)
+	return files
+}
+
+// loadTestSchema loads the example schema in testdata/gen-sdk.  The schema's logo is served by a local HTTP server
+// so that generating the .NET SDK does not require network access.
+func loadTestSchema(t *testing.T) (*schema.Package, func()) {
+	spec, err := loadSchemaSource(filepath.Join("testdata", "gen-sdk", "schema.json"))
+	assert.NoError(t, err)
+	pkg, err := schema.ImportSpec(spec)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("logo"))
+		assert.NoError(t, err)
+	}))
+	pkg.LogoURL = server.URL
+	return pkg, server.Close
+}
+
+// TestGenerateSDKGolden checks the SDKs generated for the example schema against the golden files in
+// testdata/gen-sdk.  Set PULUMI_ACCEPT to a truthy value to update the golden files.
+func TestGenerateSDKGolden(t *testing.T) {
+	pkg, done := loadTestSchema(t)
+	defer done()
+
+	accept := cmdutil.IsTruthy(os.Getenv("PULUMI_ACCEPT"))
+	for _, lang := range sortedSDKLanguages() {
+		t.Run(lang, func(t *testing.T) {
+			golden := filepath.Join("testdata", "gen-sdk", lang)
+			if accept {
+				assert.NoError(t, generateSDK(lang, pkg, golden, true))
+				return
+			}
+
+			dir, err := ioutil.TempDir("", "gen-sdk")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			assert.NoError(t, generateSDK(lang, pkg, dir, false))
+			assert.Equal(t, readSDKDir(t, golden), readSDKDir(t, dir))
+		})
+	}
+}
+
+func TestGenerateSDKOverwrite(t *testing.T) {
+	pkg, done := loadTestSchema(t)
+	defer done()
+
+	dir, err := ioutil.TempDir("", "gen-sdk")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stale := filepath.Join(dir, "stale.txt")
+	assert.NoError(t, ioutil.WriteFile(stale, []byte("stale"), 0600))
+
+	// A non-empty directory is not replaced without overwrite.
+	assert.Error(t, generateSDK("nodejs", pkg, dir, false))
+	_, err = os.Stat(stale)
+	assert.NoError(t, err)
+
+	// With overwrite, the directory is replaced wholesale.
+	assert.NoError(t, generateSDK("nodejs", pkg, dir, true))
+	_, err = os.Stat(stale)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "package.json"))
+	assert.NoError(t, err)
+}
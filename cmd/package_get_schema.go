@@ -0,0 +1,65 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newPackageGetSchemaCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "get-schema NAME [VERSION]",
+		Args:  cmdutil.RangeArgs(1, 2),
+		Short: "Print the schema of a resource provider",
+		Long: "Print the schema of a resource provider.\n" +
+			"\n" +
+			"Loads the installed resource provider plugin NAME, optionally at the exact\n" +
+			"VERSION, asks it for the schema of its package and prints the schema as JSON.\n" +
+			"The schema is validated before it is printed.  If VERSION is omitted, the\n" +
+			"newest installed version of the plugin is used.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			var version *semver.Version
+			if len(args) > 1 {
+				v, err := semver.ParseTolerant(args[1])
+				if err != nil {
+					return errors.Wrap(err, "invalid plugin semver")
+				}
+				version = &v
+			}
+
+			raw, _, err := getProviderSchema(args[0], version)
+			if err != nil {
+				return err
+			}
+
+			var out bytes.Buffer
+			if err = json.Indent(&out, raw, "", "    "); err != nil {
+				return err
+			}
+			fmt.Println(out.String())
+			return nil
+		}),
+	}
+
+	return cmd
+}
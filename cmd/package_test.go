@@ -0,0 +1,50 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchema(t *testing.T) {
+	spec, err := parseSchema([]byte(`{
+		"name": "test",
+		"version": "1.0.0",
+		"resources": {
+			"test:index:Thing": {
+				"properties": {"name": {"type": "string"}},
+				"inputProperties": {"name": {"type": "string"}}
+			}
+		}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", spec.Name)
+	assert.Contains(t, spec.Resources, "test:index:Thing")
+
+	// Malformed JSON is rejected.
+	_, err = parseSchema([]byte(`{"name": `))
+	assert.Error(t, err)
+
+	// So are schemas that fail validation.
+	_, err = parseSchema([]byte(`{"name": "test", "version": "not-a-version"}`))
+	assert.Error(t, err)
+	_, err = parseSchema([]byte(`{
+		"name": "test",
+		"resources": {"test:index:Thing": {"properties": {"name": {"type": "widget"}}}}
+	}`))
+	assert.Error(t, err)
+}
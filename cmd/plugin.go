@@ -15,6 +15,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/engine"
@@ -44,12 +46,24 @@ func newPluginCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newPluginInstallCmd())
+	cmd.AddCommand(newPluginLockCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginPruneCmd())
 	cmd.AddCommand(newPluginRmCmd())
 
 	return cmd
 }
 
+// detectPluginLock loads the plugin lockfile for the current project, if it has one.
+func detectPluginLock() (*workspace.PluginLock, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	lock, _, err := workspace.DetectPluginLockFrom(pwd)
+	return lock, err
+}
+
 // getProjectPlugins fetches a list of plugins used by this project.
 func getProjectPlugins() ([]workspace.PluginInfo, error) {
 	proj, root, err := readProject()
@@ -18,10 +18,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag"
@@ -50,7 +52,19 @@ func newPluginInstallCmd() *cobra.Command {
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"Plugins are downloaded from the Pulumi release server by default. To use a mirror\n" +
+			"instead, such as an internal artifact server or (with a file:// URL) a local\n" +
+			"directory of plugin tarballs, set PULUMI_PLUGIN_MIRROR. Extra HTTP headers to send\n" +
+			"to the server, such as credentials, may be set in PULUMI_PLUGIN_DOWNLOAD_HEADERS as\n" +
+			"semicolon-separated 'Name: Value' pairs. If PULUMI_PLUGIN_CHECKSUMS names a\n" +
+			"manifest in the format written by sha256sum, every downloaded tarball must match it.\n" +
+			"\n" +
+			"A plugin may also be installed from a tarball with --file (or --from-file). If the\n" +
+			"tarball follows the standard pulumi-KIND-NAME-vVERSION[-OS-ARCH].tar.gz naming\n" +
+			"scheme, the KIND, NAME, and VERSION arguments may be omitted. The tarball must match\n" +
+			"the checksum manifest, if one is configured.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -67,11 +81,9 @@ func newPluginInstallCmd() *cobra.Command {
 				serverURL = cloudURL + "/releases/plugins"
 			}
 
-			// Note we don't presently set this as the default value for `--server` so we can play games like the above
-			// where we want to ensure at most one of `--server` or `--cloud-url` is set.
-			if serverURL == "" {
-				serverURL = "https://api.pulumi.com/releases/plugins"
-			}
+			// Note we don't set a default value for `--server`, both so we can play games like the above where we want
+			// to ensure at most one of `--server` or `--cloud-url` is set, and so that plugins without a server fall
+			// back to the plugin mirror, if one is configured.
 
 			// Parse the kind, name, and version, if specified.
 			var installs []workspace.PluginInfo
@@ -93,22 +105,40 @@ func newPluginInstallCmd() *cobra.Command {
 					Version:   &version,
 					ServerURL: serverURL,
 				})
-			} else {
-				if file != "" {
-					return errors.New("--file (-f) is only valid if a specific package is being installed")
+			} else if file != "" {
+				// If we're installing from a file, try to figure out which plugin it is from its name.
+				install, ok := workspace.ParsePluginTarballName(file)
+				if !ok {
+					return errors.Errorf("could not determine the plugin kind, name, and version from the file name "+
+						"%s; pass them as arguments instead", file)
 				}
-
+				installs = append(installs, install)
+			} else {
 				// If a specific plugin wasn't given, compute the set of plugins the current project needs.
 				plugins, err := getProjectPlugins()
 				if err != nil {
 					return err
 				}
+				lock, err := detectPluginLock()
+				if err != nil {
+					return err
+				}
 				for _, plugin := range plugins {
 					// Skip language plugins; by definition, we already have one installed.
 					// TODO[pulumi/pulumi#956]: eventually we will want to honor and install these in the usual way.
-					if plugin.Kind != workspace.LanguagePlugin {
-						installs = append(installs, plugin)
+					if plugin.Kind == workspace.LanguagePlugin {
+						continue
 					}
+
+					// If the project has a plugin lockfile, install exactly the locked version.
+					if lock != nil {
+						if locked, ok := lock.Find(plugin.Kind, plugin.Name); ok {
+							if plugin, err = locked.Info(); err != nil {
+								return err
+							}
+						}
+					}
+					installs = append(installs, plugin)
 				}
 			}
 
@@ -145,13 +175,14 @@ func newPluginInstallCmd() *cobra.Command {
 				var tarball io.ReadCloser
 				var err error
 				if file == "" {
+					source = install.DownloadURL()
 					if verbose {
 						cmdutil.Diag().Infoerrf(
-							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
+							diag.Message("", "%s downloading from %s"), label, source)
 					}
 					var size int64
 					if tarball, size, err = install.Download(); err != nil {
-						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
+						return errors.Wrapf(err, "%s downloading from %s", label, source)
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
 				} else {
@@ -163,6 +194,9 @@ func newPluginInstallCmd() *cobra.Command {
 					if tarball, err = os.Open(file); err != nil {
 						return errors.Wrapf(err, "opening file %s", source)
 					}
+					if tarball, err = workspace.VerifyPluginTarball(filepath.Base(file), tarball); err != nil {
+						return errors.Wrapf(err, "%s verifying %s", label, source)
+					}
 				}
 				if verbose {
 					cmdutil.Diag().Infoerrf(
@@ -184,7 +218,7 @@ func newPluginInstallCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&exact,
 		"exact", false, "Force installation of an exact version match (usually >= is accepted)")
 	cmd.PersistentFlags().StringVarP(&file,
-		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it")
+		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it (alias: --from-file)")
 	cmd.PersistentFlags().BoolVar(&reinstall,
 		"reinstall", false, "Reinstall a plugin even if it already exists")
 	cmd.PersistentFlags().BoolVar(&verbose,
@@ -193,5 +227,13 @@ func newPluginInstallCmd() *cobra.Command {
 	// We are moving away from supporting this option, for now we mark it hidden.
 	contract.AssertNoError(cmd.PersistentFlags().MarkHidden("cloud-url"))
 
+	// --from-file is another name for --file.
+	cmd.SetGlobalNormalizationFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "from-file" {
+			name = "file"
+		}
+		return pflag.NormalizedName(name)
+	})
+
 	return cmd
 }
@@ -0,0 +1,92 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginLockCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "lock",
+		Args:  cmdutil.NoArgs,
+		Short: "Record the exact plugins required by the current project",
+		Long: "Record the exact plugins required by the current project.\n" +
+			"\n" +
+			"This command resolves every plugin the current project requires against the\n" +
+			"plugins that are installed, and records the name, kind, version and checksum of\n" +
+			"each in a " + workspace.PluginLockFile + " file next to the project file. Commit\n" +
+			"this file so that everyone running the project uses the same plugins.\n" +
+			"\n" +
+			"When a project has a lockfile, Pulumi installs the locked version of each plugin\n" +
+			"if it is missing, and refuses to run if the program requires a plugin that is\n" +
+			"not locked, requires a different version, or if an installed plugin does not\n" +
+			"match its locked checksum. Run this command again after upgrading packages.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			projPath, err := workspace.DetectProjectPath()
+			if err != nil {
+				return err
+			} else if projPath == "" {
+				return errors.New("no Pulumi.yaml project file found")
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+
+			lock := &workspace.PluginLock{}
+			for _, plugin := range plugins {
+				// Skip language plugins; they ship alongside the CLI.
+				if plugin.Kind == workspace.LanguagePlugin {
+					continue
+				}
+
+				info, path, err := workspace.GetPluginInfo(plugin.Kind, plugin.Name, plugin.Version)
+				if err != nil {
+					return errors.Wrapf(err, "resolving %s plugin %s; install it with `pulumi plugin install`",
+						plugin.Kind, plugin)
+				}
+				if info.Version == nil {
+					return errors.Errorf("cannot lock %s plugin %s found at %s because its version is unknown",
+						plugin.Kind, plugin.Name, path)
+				}
+				info.ServerURL = plugin.ServerURL
+
+				locked, err := workspace.NewLockedPlugin(info, path)
+				if err != nil {
+					return err
+				}
+				lock.Plugins = append(lock.Plugins, locked)
+			}
+
+			lockPath := workspace.PluginLockPath(projPath)
+			if err = lock.Save(lockPath); err != nil {
+				return errors.Wrapf(err, "saving %s", lockPath)
+			}
+
+			fmt.Printf("Locked %d plugin(s) in %s\n", len(lock.Plugins), lockPath)
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,262 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginPruneCmd() *cobra.Command {
+	var dryRun bool
+	var skipUnreadable bool
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove resource plugins that are no longer referenced",
+		Long: "Remove resource plugins that are no longer referenced.\n" +
+			"\n" +
+			"This command scans the checkpoint of every stack in the current backend for the\n" +
+			"resource provider plugins it uses. When run inside a project, the plugins that\n" +
+			"project requires (and any plugins in its plugin lockfile) are also kept. Every\n" +
+			"other installed version of a resource plugin is removed from the download cache,\n" +
+			"and the disk space that is reclaimed is reported.\n" +
+			"\n" +
+			"Pass --dry-run to list the plugins that would be removed without removing them.\n" +
+			"If a removed plugin is subsequently required, it will be downloaded again.\n" +
+			"\n" +
+			"If any stack's checkpoint cannot be read, nothing is removed, since the plugins that\n" +
+			"stack uses cannot be known. Pass --skip-unreadable-stacks to skip such stacks with a\n" +
+			"warning instead, removing the plugins that only they use.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			installed, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrap(err, "loading plugins")
+			}
+
+			b, err := currentBackend(opts)
+			if err != nil {
+				return err
+			}
+			referenced, err := getBackendPlugins(b, skipUnreadable)
+			if err != nil {
+				return err
+			}
+
+			// If we're in a project, keep the plugins it needs too.
+			if projPath, err := workspace.DetectProjectPath(); err == nil && projPath != "" {
+				plugins, err := getProjectPlugins()
+				if err != nil {
+					return errors.Wrap(err, "loading project plugins")
+				}
+				referenced = append(referenced, plugins...)
+
+				lock, err := workspace.LoadPluginLock(workspace.PluginLockPath(projPath))
+				if err != nil {
+					return err
+				}
+				if lock != nil {
+					for _, locked := range lock.Plugins {
+						info, err := locked.Info()
+						if err != nil {
+							return err
+						}
+						referenced = append(referenced, info)
+					}
+				}
+			}
+
+			prunes := selectUnreferencedPlugins(installed, referenced)
+			if len(prunes) == 0 {
+				fmt.Println("No unreferenced plugins found.")
+				return nil
+			}
+
+			var totalSize uint64
+			var suffix string
+			if len(prunes) != 1 {
+				suffix = "s"
+			}
+			verb := "This will remove"
+			if dryRun {
+				verb = "Would remove"
+			}
+			fmt.Print(
+				opts.Color.Colorize(
+					fmt.Sprintf("%s%s %d unreferenced plugin%s from the cache:%s\n",
+						colors.SpecAttention, verb, len(prunes), suffix, colors.Reset)))
+			for _, prune := range prunes {
+				fmt.Printf("    %s %s (%s)\n", prune.Kind, prune.String(), humanize.Bytes(uint64(prune.Size)))
+				totalSize += uint64(prune.Size)
+			}
+			fmt.Printf("Reclaimable disk space: %s\n", humanize.Bytes(totalSize))
+
+			if dryRun {
+				return nil
+			}
+			if yes || confirmPrompt("", "yes", opts) {
+				var result error
+				for _, plugin := range prunes {
+					if err := plugin.Delete(); err != nil {
+						result = multierror.Append(
+							result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
+					}
+				}
+				if result != nil {
+					return result
+				}
+			}
+
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"List the plugins that would be removed, without removing them")
+	cmd.PersistentFlags().BoolVar(
+		&skipUnreadable, "skip-unreadable-stacks", false,
+		"Skip stacks whose checkpoints cannot be read, rather than removing nothing")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// getBackendPlugins returns the resource plugins used by the providers in every stack in the given backend. If the
+// plugins of any stack cannot be read, an error is returned, as pruning would remove the plugins that only that stack
+// uses, which may not be available for download again. If skipUnreadable is true, such stacks are instead skipped
+// with a warning.
+func getBackendPlugins(b backend.Backend, skipUnreadable bool) ([]workspace.PluginInfo, error) {
+	summaries, err := b.ListStacks(commandContext(), backend.ListStacksFilter{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing stacks")
+	}
+
+	var plugins []workspace.PluginInfo
+	for _, summary := range summaries {
+		stackPlugins, err := getStackPlugins(b, summary.Name())
+		if err != nil {
+			if !skipUnreadable {
+				return nil, errors.Wrapf(err, "reading the plugins of stack %s (pass --skip-unreadable-stacks "+
+					"to skip stacks that cannot be read)", summary.Name())
+			}
+			cmdutil.Diag().Warningf(diag.Message("", "skipping stack %s: %v"), summary.Name(), err)
+			continue
+		}
+		plugins = append(plugins, stackPlugins...)
+	}
+	return plugins, nil
+}
+
+// getStackPlugins returns the resource plugins used by the providers in the given stack.
+func getStackPlugins(b backend.Backend, stackRef backend.StackReference) ([]workspace.PluginInfo, error) {
+	s, err := b.GetStack(commandContext(), stackRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading stack")
+	}
+	if s == nil {
+		return nil, nil
+	}
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return nil, errors.Wrap(err, "loading checkpoint")
+	}
+	plugins, err := getSnapshotPlugins(snap)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading providers")
+	}
+	return plugins, nil
+}
+
+// getSnapshotPlugins returns the resource plugins used by the first-class providers in the given snapshot.
+func getSnapshotPlugins(snap *deploy.Snapshot) ([]workspace.PluginInfo, error) {
+	if snap == nil {
+		return nil, nil
+	}
+
+	var plugins []workspace.PluginInfo
+	for _, res := range snap.Resources {
+		if !providers.IsProviderType(res.URN.Type()) {
+			continue
+		}
+		version, err := providers.GetProviderVersion(res.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, workspace.PluginInfo{
+			Name:    providers.GetProviderPackage(res.URN.Type()).String(),
+			Kind:    workspace.ResourcePlugin,
+			Version: version,
+		})
+	}
+	return plugins, nil
+}
+
+// selectUnreferencedPlugins returns the installed resource plugins that would not be loaded for any of the
+// referenced plugins. A reference with a version keeps the installed plugin that would be selected for that version;
+// a reference without a version keeps the newest installed version, which is what the loader would pick.
+func selectUnreferencedPlugins(installed, referenced []workspace.PluginInfo) []workspace.PluginInfo {
+	keep := make(map[string]bool)
+	for _, ref := range referenced {
+		if ref.Kind != workspace.ResourcePlugin {
+			continue
+		}
+
+		var requested semver.Range = func(semver.Version) bool { return true }
+		if ref.Version != nil {
+			requested = semver.MustParseRange(ref.Version.String())
+		}
+		candidates := append([]workspace.PluginInfo(nil), installed...)
+		if match, err := workspace.SelectCompatiblePlugin(candidates, ref.Kind, ref.Name, requested); err == nil {
+			keep[match.Dir()] = true
+		}
+	}
+
+	var prunes []workspace.PluginInfo
+	for _, plugin := range installed {
+		if plugin.Kind == workspace.ResourcePlugin && !keep[plugin.Dir()] {
+			prunes = append(prunes, plugin)
+		}
+	}
+	sort.Slice(prunes, func(i, j int) bool {
+		if prunes[i].Name != prunes[j].Name {
+			return prunes[i].Name < prunes[j].Name
+		}
+		return prunes[i].Version != nil && prunes[j].Version != nil && prunes[i].Version.LT(*prunes[j].Version)
+	})
+	return prunes
+}
@@ -0,0 +1,132 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func makePlugin(kind workspace.PluginKind, name, version string) workspace.PluginInfo {
+	info := workspace.PluginInfo{Kind: kind, Name: name}
+	if version != "" {
+		v := semver.MustParse(version)
+		info.Version = &v
+	}
+	return info
+}
+
+func TestGetSnapshotPlugins(t *testing.T) {
+	providerURN := resource.NewURN("stack", "proj", "", providers.MakeProviderType("aws"), "default")
+	unversionedURN := resource.NewURN("stack", "proj", "", providers.MakeProviderType("gcp"), "default")
+	bucketURN := resource.NewURN("stack", "proj", "", "aws:s3/bucket:Bucket", "bucket")
+
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{URN: providerURN, Inputs: resource.PropertyMap{"version": resource.NewStringProperty("1.2.3")}},
+			{URN: unversionedURN, Inputs: resource.PropertyMap{}},
+			{URN: bucketURN, Inputs: resource.PropertyMap{"version": resource.NewStringProperty("9.9.9")}},
+		},
+	}
+
+	plugins, err := getSnapshotPlugins(snap)
+	assert.NoError(t, err)
+	assert.Equal(t, []workspace.PluginInfo{
+		makePlugin(workspace.ResourcePlugin, "aws", "1.2.3"),
+		makePlugin(workspace.ResourcePlugin, "gcp", ""),
+	}, plugins)
+
+	plugins, err = getSnapshotPlugins(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+}
+
+type testStackRef string
+
+func (ref testStackRef) String() string     { return string(ref) }
+func (ref testStackRef) Name() tokens.QName { return tokens.QName(ref) }
+
+type testStackSummary string
+
+func (s testStackSummary) Name() backend.StackReference { return testStackRef(s) }
+func (s testStackSummary) LastUpdate() *time.Time       { return nil }
+func (s testStackSummary) ResourceCount() *int          { return nil }
+
+func TestGetBackendPlugins(t *testing.T) {
+	providerURN := resource.NewURN("dev", "proj", "", providers.MakeProviderType("aws"), "default")
+	b := &backend.MockBackend{
+		ListStacksF: func(context.Context, backend.ListStacksFilter) ([]backend.StackSummary, error) {
+			return []backend.StackSummary{testStackSummary("broken"), testStackSummary("dev")}, nil
+		},
+		GetStackF: func(_ context.Context, ref backend.StackReference) (backend.Stack, error) {
+			return &backend.MockStack{SnapshotF: func(context.Context) (*deploy.Snapshot, error) {
+				if ref.String() == "broken" {
+					return nil, errors.New("corrupt checkpoint")
+				}
+				return &deploy.Snapshot{Resources: []*resource.State{
+					{URN: providerURN, Inputs: resource.PropertyMap{"version": resource.NewStringProperty("1.2.3")}},
+				}}, nil
+			}}, nil
+		},
+	}
+
+	// By default, a stack whose checkpoint cannot be loaded prevents any plugins from being pruned.
+	_, err := getBackendPlugins(b, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "broken")
+		assert.Contains(t, err.Error(), "corrupt checkpoint")
+	}
+
+	// When asked to, such stacks are skipped and the others are still read.
+	plugins, err := getBackendPlugins(b, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []workspace.PluginInfo{makePlugin(workspace.ResourcePlugin, "aws", "1.2.3")}, plugins)
+}
+
+func TestSelectUnreferencedPlugins(t *testing.T) {
+	installed := []workspace.PluginInfo{
+		makePlugin(workspace.ResourcePlugin, "aws", "1.0.0"),
+		makePlugin(workspace.ResourcePlugin, "aws", "1.2.3"),
+		makePlugin(workspace.ResourcePlugin, "aws", "2.0.0"),
+		makePlugin(workspace.ResourcePlugin, "gcp", "3.0.0"),
+		makePlugin(workspace.ResourcePlugin, "gcp", "3.1.0"),
+		makePlugin(workspace.ResourcePlugin, "azure", "1.0.0"),
+		makePlugin(workspace.AnalyzerPlugin, "policy", "0.1.0"),
+	}
+	referenced := []workspace.PluginInfo{
+		makePlugin(workspace.ResourcePlugin, "aws", "1.2.3"),
+		makePlugin(workspace.ResourcePlugin, "gcp", ""),
+		makePlugin(workspace.ResourcePlugin, "kubernetes", "0.22.0"),
+	}
+
+	prunes := selectUnreferencedPlugins(installed, referenced)
+	var names []string
+	for _, p := range prunes {
+		names = append(names, p.String())
+	}
+	assert.Equal(t, []string{"aws-1.0.0", "aws-2.0.0", "azure-1.0.0", "gcp-3.0.0"}, names)
+}
@@ -41,11 +41,19 @@ func newPreviewCmd() *cobra.Command {
 	var eventLogPath string
 	var parallel int
 	var refresh bool
+	var reportPath string
+	var policyReportPath string
+	var policyReportFormat string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
 	var showReads bool
 	var suppressOutputs bool
+	var rawStringDiffs bool
+	var summary string
+	var showOnly []string
+	var hide []string
+	var subtree string
 	var targets []string
 	var replaces []string
 	var targetReplaces []string
@@ -69,11 +77,23 @@ func newPreviewCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			groupedSummary, err := summaryFlagToGrouped(summary)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			var displayType = display.DisplayProgress
 			if diffDisplay {
 				displayType = display.DisplayDiff
 			}
 
+			switch display.PolicyReportFormat(policyReportFormat) {
+			case display.PolicyReportJUnit, display.PolicyReportSARIF:
+			default:
+				return result.Errorf("unknown policy report format %q; expected %s or %s",
+					policyReportFormat, display.PolicyReportJUnit, display.PolicyReportSARIF)
+			}
+
 			displayOpts := display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
@@ -81,17 +101,28 @@ func newPreviewCmd() *cobra.Command {
 				ShowSameResources:    showSames,
 				ShowReads:            showReads,
 				SuppressOutputs:      suppressOutputs,
+				RawStringDiffs:       rawStringDiffs,
+				GroupedSummary:       groupedSummary,
 				IsInteractive:        cmdutil.Interactive(),
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
+				ReportPath:           reportPath,
+				PolicyReportPath:     policyReportPath,
+				PolicyReportFormat:   display.PolicyReportFormat(policyReportFormat),
 				Debug:                debug,
 			}
+			if err := applyDisplayFilterFlags(&displayOpts, showOnly, hide, subtree); err != nil {
+				return result.FromError(err)
+			}
 
 			s, err := requireStack(stack, true, displayOpts, true /*setCurrent*/)
 			if err != nil {
 				return result.FromError(err)
 			}
+			if err := applySubtreeParents(&displayOpts, s); err != nil {
+				return result.FromError(err)
+			}
 
 			// Save any config values passed via flags.
 			if err := parseAndSaveConfigArray(s, configArray, configPath); err != nil {
@@ -102,6 +133,7 @@ func newPreviewCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			displayOpts.ProjectFileSource = projectFileSource(root)
 
 			m, err := getUpdateMetadata(message, root)
 			if err != nil {
@@ -224,6 +256,16 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
+	cmd.PersistentFlags().StringVar(
+		&reportPath, "report", "",
+		"Write a Markdown report of the preview, e.g. for a pull request comment, to a file at this path")
+	cmd.PersistentFlags().StringVar(
+		&policyReportPath, "policy-report", "",
+		"Write a report of the policy violations found by the preview to a file at this path. Its format is set "+
+			"with --policy-report-format rather than a second argument to this flag")
+	cmd.PersistentFlags().StringVar(
+		&policyReportFormat, "policy-report-format", string(display.PolicyReportJUnit),
+		"The format of the policy violation report: junit or sarif")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -241,6 +283,24 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&rawStringDiffs, "raw-string-diffs", false,
+		"Diff strings that contain JSON or YAML documents as text rather than as structured documents")
+	cmd.PersistentFlags().StringVar(
+		&summary, "summary", "default",
+		"The summary of changes to display: default or grouped, which also counts the changes to each "+
+			"resource type, module and top-level component")
+	cmd.PersistentFlags().StringArrayVar(
+		&showOnly, "show-only", []string{},
+		"Only show the resources that match this filter: type=<glob>, name=<glob> or urn=<glob>, or ~= and a regular "+
+			"expression in place of = and a glob (e.g. type=aws:iam/*). Multiple filters can be specified")
+	cmd.PersistentFlags().StringArrayVar(
+		&hide, "hide", []string{},
+		"Hide the resources that match this filter, which takes the same form as --show-only. "+
+			"Multiple filters can be specified")
+	cmd.PersistentFlags().StringVar(
+		&subtree, "subtree", "",
+		"Only show the resource with this URN, e.g. a component, and its descendants")
 
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
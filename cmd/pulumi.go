@@ -104,6 +104,9 @@ func NewPulumiCmd() *cobra.Command {
 			}
 
 			logging.InitLogging(logToStderr, verbose, logFlow)
+			if tracing == "" {
+				tracing = cmdutil.OTLPTracingEndpointFromEnv()
+			}
 			cmdutil.InitTracing("pulumi-cli", "pulumi", tracing)
 			if tracingHeaderFlag != "" {
 				tracingHeader = tracingHeaderFlag
@@ -159,7 +162,8 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&cmdutil.DisableInteractive, "non-interactive", false,
 		"Disable interactive mode for all commands")
 	cmd.PersistentFlags().StringVar(&tracing, "tracing", "",
-		"Emit tracing to the specified endpoint. Use the `file:` scheme to write tracing data to a local file")
+		"Emit tracing to the specified endpoint. Use the `file:` scheme to write tracing data to a local file, or the "+
+			"`otlp+http:` or `otlp+https:` schemes to send it to an OpenTelemetry collector")
 	cmd.PersistentFlags().StringVar(&profiling, "profiling", "",
 		"Emit CPU and memory profiles and an execution trace to '[filename].[pid].{cpu,mem,trace}', respectively")
 	cmd.PersistentFlags().IntVarP(&verbose, "verbose", "v", 0,
@@ -187,7 +191,9 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newStateCmd())
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newDisplayCmd())
 	cmd.AddCommand(newPluginCmd())
+	cmd.AddCommand(newPackageCmd())
 	cmd.AddCommand(newVersionCmd())
 	cmd.AddCommand(newHistoryCmd())
 
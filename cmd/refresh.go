@@ -43,6 +43,11 @@ func newRefreshCmd() *cobra.Command {
 	var showSames bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var rawStringDiffs bool
+	var summary string
+	var showOnly []string
+	var hide []string
+	var subtree string
 	var yes bool
 	var targets *[]string
 
@@ -60,6 +65,11 @@ func newRefreshCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			groupedSummary, err := summaryFlagToGrouped(summary)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			interactive := cmdutil.Interactive()
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
@@ -81,21 +91,30 @@ func newRefreshCmd() *cobra.Command {
 				ShowReplacementSteps: showReplacementSteps,
 				ShowSameResources:    showSames,
 				SuppressOutputs:      suppressOutputs,
+				RawStringDiffs:       rawStringDiffs,
+				GroupedSummary:       groupedSummary,
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
+			if err := applyDisplayFilterFlags(&opts.Display, showOnly, hide, subtree); err != nil {
+				return result.FromError(err)
+			}
 
 			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 			if err != nil {
 				return result.FromError(err)
 			}
+			if err := applySubtreeParents(&opts.Display, s); err != nil {
+				return result.FromError(err)
+			}
 
 			proj, root, err := readProject()
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.Display.ProjectFileSource = projectFileSource(root)
 
 			m, err := getUpdateMetadata(message, root)
 			if err != nil {
@@ -187,6 +206,24 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&rawStringDiffs, "raw-string-diffs", false,
+		"Diff strings that contain JSON or YAML documents as text rather than as structured documents")
+	cmd.PersistentFlags().StringVar(
+		&summary, "summary", "default",
+		"The summary of changes to display: default or grouped, which also counts the changes to each "+
+			"resource type, module and top-level component")
+	cmd.PersistentFlags().StringArrayVar(
+		&showOnly, "show-only", []string{},
+		"Only show the resources that match this filter: type=<glob>, name=<glob> or urn=<glob>, or ~= and a regular "+
+			"expression in place of = and a glob (e.g. type=aws:iam/*). Multiple filters can be specified")
+	cmd.PersistentFlags().StringArrayVar(
+		&hide, "hide", []string{},
+		"Hide the resources that match this filter, which takes the same form as --show-only. "+
+			"Multiple filters can be specified")
+	cmd.PersistentFlags().StringVar(
+		&subtree, "subtree", "",
+		"Only show the resource with this URN, e.g. a component, and its descendants")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the refresh after previewing it")
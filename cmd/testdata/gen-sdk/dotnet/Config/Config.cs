@@ -0,0 +1,17 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Immutable;
+
+namespace Pulumi.Example
+{
+    public static class Config
+    {
+        private static readonly Pulumi.Config __config = new Pulumi.Config("example");
+        /// <summary>
+        /// The region in which to create resources.
+        /// </summary>
+        public static string? Region { get; set; } = __config.Get("region");
+
+    }
+}
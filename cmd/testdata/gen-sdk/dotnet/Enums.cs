@@ -0,0 +1,79 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.ComponentModel;
+using Pulumi;
+
+namespace Pulumi.Example
+{
+    /// <summary>
+    /// The action taken for traffic that matches a firewall.
+    /// </summary>
+    [EnumType]
+    public readonly struct Action : IEquatable<Action>
+    {
+        private readonly string _value;
+
+        private Action(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static Action Allow { get; } = new Action("allow");
+        public static Action Deny { get; } = new Action("deny");
+
+        public static bool operator ==(Action left, Action right) => left.Equals(right);
+        public static bool operator !=(Action left, Action right) => !left.Equals(right);
+
+        public static explicit operator string(Action value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is Action other && Equals(other);
+        public bool Equals(Action other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+
+    /// <summary>
+    /// The protocol of a firewall rule.
+    /// </summary>
+    [EnumType]
+    public readonly struct Protocol : IEquatable<Protocol>
+    {
+        private readonly string _value;
+
+        private Protocol(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        /// <summary>
+        /// Transmission Control Protocol.
+        /// </summary>
+        public static Protocol TCP { get; } = new Protocol("tcp");
+        /// <summary>
+        /// User Datagram Protocol.
+        /// </summary>
+        public static Protocol UDP { get; } = new Protocol("udp");
+        [Obsolete(@"ICMP rules are no longer supported.")]
+        public static Protocol ICMP { get; } = new Protocol("icmp");
+
+        public static bool operator ==(Protocol left, Protocol right) => left.Equals(right);
+        public static bool operator !=(Protocol left, Protocol right) => !left.Equals(right);
+
+        public static explicit operator string(Protocol value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is Protocol other && Equals(other);
+        public bool Equals(Protocol other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+}
@@ -0,0 +1,115 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example
+{
+    /// <summary>
+    /// A firewall.
+    /// </summary>
+    public partial class Firewall : Pulumi.CustomResource
+    {
+        /// <summary>
+        /// The action taken for matching traffic.
+        /// </summary>
+        [Output("action")]
+        public Output<Action> Action { get; private set; } = null!;
+
+        /// <summary>
+        /// The name of the firewall.
+        /// </summary>
+        [Output("name")]
+        public Output<string> Name { get; private set; } = null!;
+
+        /// <summary>
+        /// The rules of the firewall.
+        /// </summary>
+        [Output("rules")]
+        public Output<ImmutableArray<Outputs.Rule>> Rules { get; private set; } = null!;
+
+        /// <summary>
+        /// The URL of the firewall.
+        /// </summary>
+        [Output("url")]
+        public Output<string> Url { get; private set; } = null!;
+
+
+        /// <summary>
+        /// Create a Firewall resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public Firewall(string name, FirewallArgs args, CustomResourceOptions? options = null)
+            : base("example:index:Firewall", name, args ?? ResourceArgs.Empty, MakeResourceOptions(options, ""))
+        {
+        }
+
+        private Firewall(string name, Input<string> id, CustomResourceOptions? options = null)
+            : base("example:index:Firewall", name, null, MakeResourceOptions(options, id))
+        {
+        }
+
+        private static CustomResourceOptions MakeResourceOptions(CustomResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new CustomResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = CustomResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+        /// <summary>
+        /// Get an existing Firewall resource's state with the given name, ID, and optional extra
+        /// properties used to qualify the lookup.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resulting resource.</param>
+        /// <param name="id">The unique provider ID of the resource to lookup.</param>
+        /// <param name="state">Any extra arguments used during the lookup.</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public static Firewall Get(string name, Input<string> id, CustomResourceOptions? options = null)
+        {
+            return new Firewall(name, id, options);
+        }
+    }
+
+    public sealed class FirewallArgs : Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The action taken for matching traffic.
+        /// </summary>
+        [Input("action")]
+        public Input<Action>? Action { get; set; }
+
+        /// <summary>
+        /// The name of the firewall.
+        /// </summary>
+        [Input("name", required: true)]
+        public Input<string> Name { get; set; } = null!;
+
+        [Input("rules")]
+        private InputList<Inputs.RuleArgs>? _rules;
+
+        /// <summary>
+        /// The rules of the firewall.
+        /// </summary>
+        public InputList<Inputs.RuleArgs> Rules
+        {
+            get => _rules ?? (_rules = new InputList<Inputs.RuleArgs>());
+            set => _rules = value;
+        }
+
+        public FirewallArgs()
+        {
+            Action = Action.Allow;
+        }
+    }
+}
@@ -0,0 +1,49 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example
+{
+    public static partial class Invokes
+    {
+        /// <summary>
+        /// Looks up a firewall by name.
+        /// </summary>
+        public static Task<GetFirewallResult> GetFirewall(GetFirewallArgs args, InvokeOptions? options = null)
+            => Pulumi.Deployment.Instance.InvokeAsync<GetFirewallResult>("example:index:getFirewall", args ?? InvokeArgs.Empty, options.WithVersion());
+    }
+
+
+    public sealed class GetFirewallArgs : Pulumi.InvokeArgs
+    {
+        /// <summary>
+        /// The name of the firewall.
+        /// </summary>
+        [Input("name", required: true)]
+        public string Name { get; set; } = null!;
+
+        public GetFirewallArgs()
+        {
+        }
+    }
+
+
+    [OutputType]
+    public sealed class GetFirewallResult
+    {
+        /// <summary>
+        /// The URL of the firewall.
+        /// </summary>
+        public readonly string Url;
+
+        [OutputConstructor]
+        private GetFirewallResult(string url)
+        {
+            Url = url;
+        }
+    }
+}
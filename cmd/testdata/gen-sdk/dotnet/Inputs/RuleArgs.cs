@@ -0,0 +1,33 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example.Inputs
+{
+
+    /// <summary>
+    /// A firewall rule.
+    /// </summary>
+    public sealed class RuleArgs : Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The port to open.
+        /// </summary>
+        [Input("port", required: true)]
+        public Input<int> Port { get; set; } = null!;
+
+        /// <summary>
+        /// The protocol of the rule.
+        /// </summary>
+        [Input("protocol")]
+        public Input<Protocol>? Protocol { get; set; }
+
+        public RuleArgs()
+        {
+        }
+    }
+}
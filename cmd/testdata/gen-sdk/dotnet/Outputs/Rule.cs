@@ -0,0 +1,34 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example.Outputs
+{
+
+    [OutputType]
+    public sealed class Rule
+    {
+        /// <summary>
+        /// The port to open.
+        /// </summary>
+        public readonly int Port;
+        /// <summary>
+        /// The protocol of the rule.
+        /// </summary>
+        public readonly Protocol? Protocol;
+
+        [OutputConstructor]
+        private Rule(
+            int port,
+
+            Protocol? protocol)
+        {
+            Port = port;
+            Protocol = protocol;
+        }
+    }
+}
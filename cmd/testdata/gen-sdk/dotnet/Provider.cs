@@ -0,0 +1,53 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Example
+{
+    /// <summary>
+    /// The provider type for the example package.
+    /// </summary>
+    public partial class Provider : Pulumi.ProviderResource
+    {
+        /// <summary>
+        /// Create a Provider resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public Provider(string name, ProviderArgs? args = null, ResourceOptions? options = null)
+            : base("example", name, args ?? ResourceArgs.Empty, MakeResourceOptions(options, ""))
+        {
+        }
+
+        private static ResourceOptions MakeResourceOptions(ResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new ResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = ResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+    }
+
+    public sealed class ProviderArgs : Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The region in which to create resources.
+        /// </summary>
+        [Input("region")]
+        public Input<string>? Region { get; set; }
+
+        public ProviderArgs()
+        {
+        }
+    }
+}
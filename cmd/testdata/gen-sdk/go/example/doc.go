@@ -0,0 +1,5 @@
+// Package example exports types, functions, subpackages for provisioning example resources.//
+// An example package for testing SDK generation.
+//
+// nolint: lll
+package example
@@ -0,0 +1,109 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+// nolint: lll
+package example
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// A firewall.
+type Firewall struct {
+	pulumi.CustomResourceState
+
+	// The action taken for matching traffic.
+	Action ActionOutput `pulumi:"action"`
+	// The name of the firewall.
+	Name pulumi.StringOutput `pulumi:"name"`
+	// The rules of the firewall.
+	Rules RuleArrayOutput `pulumi:"rules"`
+	// The URL of the firewall.
+	Url pulumi.StringOutput `pulumi:"url"`
+}
+
+// NewFirewall registers a new resource with the given unique name, arguments, and options.
+func NewFirewall(ctx *pulumi.Context,
+	name string, args *FirewallArgs, opts ...pulumi.ResourceOption) (*Firewall, error) {
+	if args == nil || args.Name == nil {
+		return nil, errors.New("missing required argument 'Name'")
+	}
+	if args == nil {
+		args = &FirewallArgs{}
+	}
+	if args.Action == nil {
+		args.Action = ActionPtr("allow")
+	}
+	var resource Firewall
+	err := ctx.RegisterResource("example:index:Firewall", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// GetFirewall gets an existing Firewall resource's state with the given name, ID, and optional
+// state properties that are used to uniquely qualify the lookup (nil if not required).
+func GetFirewall(ctx *pulumi.Context,
+	name string, id pulumi.IDInput, state *FirewallState, opts ...pulumi.ResourceOption) (*Firewall, error) {
+	var resource Firewall
+	err := ctx.ReadResource("example:index:Firewall", name, id, state, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// Input properties used for looking up and filtering Firewall resources.
+type firewallState struct {
+	// The action taken for matching traffic.
+	Action *Action `pulumi:"action"`
+	// The name of the firewall.
+	Name *string `pulumi:"name"`
+	// The rules of the firewall.
+	Rules []Rule `pulumi:"rules"`
+	// The URL of the firewall.
+	Url *string `pulumi:"url"`
+}
+
+type FirewallState struct {
+	// The action taken for matching traffic.
+	Action ActionPtrInput
+	// The name of the firewall.
+	Name pulumi.StringPtrInput
+	// The rules of the firewall.
+	Rules RuleArrayInput
+	// The URL of the firewall.
+	Url pulumi.StringPtrInput
+}
+
+func (FirewallState) ElementType() reflect.Type {
+	return reflect.TypeOf((*firewallState)(nil)).Elem()
+}
+
+type firewallArgs struct {
+	// The action taken for matching traffic.
+	Action *Action `pulumi:"action"`
+	// The name of the firewall.
+	Name string `pulumi:"name"`
+	// The rules of the firewall.
+	Rules []Rule `pulumi:"rules"`
+}
+
+// The set of arguments for constructing a Firewall resource.
+type FirewallArgs struct {
+	// The action taken for matching traffic.
+	Action ActionPtrInput
+	// The name of the firewall.
+	Name pulumi.StringInput
+	// The rules of the firewall.
+	Rules RuleArrayInput
+}
+
+func (FirewallArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*firewallArgs)(nil)).Elem()
+}
+
@@ -0,0 +1,31 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+// nolint: lll
+package example
+
+import (
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// Looks up a firewall by name.
+func LookupFirewall(ctx *pulumi.Context, args *LookupFirewallArgs, opts ...pulumi.InvokeOption) (*LookupFirewallResult, error) {
+	var rv LookupFirewallResult
+	err := ctx.Invoke("example:index:getFirewall", args, &rv, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+type LookupFirewallArgs struct {
+	// The name of the firewall.
+	Name string `pulumi:"name"`
+}
+
+
+type LookupFirewallResult struct {
+	// The URL of the firewall.
+	Url string `pulumi:"url"`
+}
+
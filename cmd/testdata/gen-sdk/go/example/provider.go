@@ -0,0 +1,47 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+// nolint: lll
+package example
+
+import (
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// The provider type for the example package.
+type Provider struct {
+	pulumi.ProviderResourceState
+
+}
+
+// NewProvider registers a new resource with the given unique name, arguments, and options.
+func NewProvider(ctx *pulumi.Context,
+	name string, args *ProviderArgs, opts ...pulumi.ResourceOption) (*Provider, error) {
+	if args == nil {
+		args = &ProviderArgs{}
+	}
+	var resource Provider
+	err := ctx.RegisterResource("pulumi:providers:example", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+type providerArgs struct {
+	// The region in which to create resources.
+	Region *string `pulumi:"region"`
+}
+
+// The set of arguments for constructing a Provider resource.
+type ProviderArgs struct {
+	// The region in which to create resources.
+	Region pulumi.StringPtrInput
+}
+
+func (ProviderArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*providerArgs)(nil)).Elem()
+}
+
@@ -0,0 +1,118 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+// nolint: lll
+package example
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// A firewall rule.
+type Rule struct {
+	// The port to open.
+	Port int `pulumi:"port"`
+	// The protocol of the rule.
+	Protocol *Protocol `pulumi:"protocol"`
+}
+
+type RuleInput interface {
+	pulumi.Input
+
+	ToRuleOutput() RuleOutput
+	ToRuleOutputWithContext(context.Context) RuleOutput
+}
+
+// A firewall rule.
+type RuleArgs struct {
+	// The port to open.
+	Port pulumi.IntInput `pulumi:"port"`
+	// The protocol of the rule.
+	Protocol ProtocolPtrInput `pulumi:"protocol"`
+}
+
+func (RuleArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Rule)(nil)).Elem()
+}
+
+func (i RuleArgs) ToRuleOutput() RuleOutput {
+	return i.ToRuleOutputWithContext(context.Background())
+}
+
+func (i RuleArgs) ToRuleOutputWithContext(ctx context.Context) RuleOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RuleOutput)
+}
+
+type RuleArrayInput interface {
+	pulumi.Input
+
+	ToRuleArrayOutput() RuleArrayOutput
+	ToRuleArrayOutputWithContext(context.Context) RuleArrayOutput
+}
+
+type RuleArray []RuleInput
+
+func (RuleArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Rule)(nil)).Elem()
+}
+
+func (i RuleArray) ToRuleArrayOutput() RuleArrayOutput {
+	return i.ToRuleArrayOutputWithContext(context.Background())
+}
+
+func (i RuleArray) ToRuleArrayOutputWithContext(ctx context.Context) RuleArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RuleArrayOutput)
+}
+
+// A firewall rule.
+type RuleOutput struct { *pulumi.OutputState }
+
+func (RuleOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Rule)(nil)).Elem()
+}
+
+func (o RuleOutput) ToRuleOutput() RuleOutput {
+	return o
+}
+
+func (o RuleOutput) ToRuleOutputWithContext(ctx context.Context) RuleOutput {
+	return o
+}
+
+// The port to open.
+func (o RuleOutput) Port() pulumi.IntOutput {
+	return o.ApplyT(func (v Rule) int { return v.Port }).(pulumi.IntOutput)
+}
+
+// The protocol of the rule.
+func (o RuleOutput) Protocol() ProtocolPtrOutput {
+	return o.ApplyT(func (v Rule) *Protocol { return v.Protocol }).(ProtocolPtrOutput)
+}
+
+type RuleArrayOutput struct { *pulumi.OutputState}
+
+func (RuleArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Rule)(nil)).Elem()
+}
+
+func (o RuleArrayOutput) ToRuleArrayOutput() RuleArrayOutput {
+	return o
+}
+
+func (o RuleArrayOutput) ToRuleArrayOutputWithContext(ctx context.Context) RuleArrayOutput {
+	return o
+}
+
+func (o RuleArrayOutput) Index(i pulumi.IntInput) RuleOutput {
+	return pulumi.All(o, i).ApplyT(func (vs []interface{}) Rule {
+		return vs[0].([]Rule)[vs[1].(int)]
+	}).(RuleOutput)
+}
+
+func init() {
+	pulumi.RegisterOutputType(RuleOutput{})
+	pulumi.RegisterOutputType(RuleArrayOutput{})
+}
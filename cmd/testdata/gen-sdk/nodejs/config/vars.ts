@@ -0,0 +1,14 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as utilities from "./utilities";
+
+let __config = new pulumi.Config("example");
+
+/**
+ * The region in which to create resources.
+ */
+export let region: string | undefined = __config.get("region");
@@ -0,0 +1,109 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as utilities from "./utilities";
+
+/**
+ * A firewall.
+ */
+export class Firewall extends pulumi.CustomResource {
+    /**
+     * Get an existing Firewall resource's state with the given name, ID, and optional extra
+     * properties used to qualify the lookup.
+     *
+     * @param name The _unique_ name of the resulting resource.
+     * @param id The _unique_ provider ID of the resource to lookup.
+     * @param state Any extra arguments used during the lookup.
+     */
+    public static get(name: string, id: pulumi.Input<pulumi.ID>, opts?: pulumi.CustomResourceOptions): Firewall {
+        return new Firewall(name, undefined{ ...opts, id: id });
+    }
+
+    /** @internal */
+    public static readonly __pulumiType = 'example:index:Firewall';
+
+    /**
+     * Returns true if the given object is an instance of Firewall.  This is designed to work even
+     * when multiple copies of the Pulumi SDK have been loaded into the same process.
+     */
+    public static isInstance(obj: any): obj is Firewall {
+        if (obj === undefined || obj === null) {
+            return false;
+        }
+        return obj['__pulumiType'] === Firewall.__pulumiType;
+    }
+
+    /**
+     * The action taken for matching traffic.
+     */
+    public readonly action!: pulumi.Output<"allow" | "deny">;
+    /**
+     * The name of the firewall.
+     */
+    public readonly name!: pulumi.Output<string>;
+    /**
+     * The rules of the firewall.
+     */
+    public readonly rules!: pulumi.Output<outputs.Rule[] | undefined>;
+    /**
+     * The URL of the firewall.
+     */
+    public /*out*/ readonly url!: pulumi.Output<string>;
+
+    /**
+     * Create a Firewall resource with the given unique name, arguments, and options.
+     *
+     * @param name The _unique_ name of the resource.
+     * @param args The arguments to use to populate this resource's properties.
+     * @param opts A bag of options that control this resource's behavior.
+     */
+    constructor(name: string, args: FirewallArgs, opts?: pulumi.CustomResourceOptions)
+    constructor(name: string, argsOrState?: FirewallArgs | FirewallState, opts?: pulumi.CustomResourceOptions) {
+        let inputs: pulumi.Inputs = {};
+        if (opts && opts.id) {
+            const state = argsOrState as FirewallState | undefined;
+            inputs["action"] = state ? state.action : undefined;
+            inputs["name"] = state ? state.name : undefined;
+            inputs["rules"] = state ? state.rules : undefined;
+            inputs["url"] = state ? state.url : undefined;
+        } else {
+            const args = argsOrState as FirewallArgs | undefined;
+            if (!args || args.name === undefined) {
+                throw new Error("Missing required property 'name'");
+            }
+            inputs["action"] = (args ? args.action : undefined) || "allow";
+            inputs["name"] = args ? args.name : undefined;
+            inputs["rules"] = args ? args.rules : undefined;
+            inputs["url"] = undefined /*out*/;
+        }
+        if (!opts) {
+            opts = {}
+        }
+
+        if (!opts.version) {
+            opts.version = utilities.getVersion();
+        }
+        super(Firewall.__pulumiType, name, inputs, opts);
+    }
+}
+
+/**
+ * The set of arguments for constructing a Firewall resource.
+ */
+export interface FirewallArgs {
+    /**
+     * The action taken for matching traffic.
+     */
+    readonly action?: pulumi.Input<"allow" | "deny">;
+    /**
+     * The name of the firewall.
+     */
+    readonly name: pulumi.Input<string>;
+    /**
+     * The rules of the firewall.
+     */
+    readonly rules?: pulumi.Input<pulumi.Input<inputs.Rule>[]>;
+}
@@ -0,0 +1,39 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as utilities from "./utilities";
+
+/**
+ * Looks up a firewall by name.
+ */
+export function getFirewall(args: GetFirewallArgs, opts?: pulumi.InvokeOptions): Promise<GetFirewallResult> & GetFirewallResult {
+    if (!opts) {
+        opts = {}
+    }
+
+    if (!opts.version) {
+        opts.version = utilities.getVersion();
+    }
+    const promise: Promise<GetFirewallResult> = pulumi.runtime.invoke("example:index:getFirewall", {
+        "name": args.name,
+    }, opts);
+
+    return pulumi.utils.liftProperties(promise, opts);
+}
+
+export interface GetFirewallArgs {
+    /**
+     * The name of the firewall.
+     */
+    readonly name: string;
+}
+
+export interface GetFirewallResult {
+    /**
+     * The URL of the firewall.
+     */
+    readonly url: string;
+}
@@ -0,0 +1,12 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+// Export members:
+export * from "./firewall";
+export * from "./getFirewall";
+export * from "./provider";
+
+// Export sub-modules:
+import * as config from "./config";
+import * as types from "./types";
+export {config, types};
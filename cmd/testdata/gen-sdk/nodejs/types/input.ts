@@ -0,0 +1,20 @@
+// *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+
+/**
+ * A firewall rule.
+ */
+export interface Rule {
+    /**
+     * The port to open.
+     */
+    port: pulumi.Input<number>;
+    /**
+     * The protocol of the rule.
+     */
+    protocol?: pulumi.Input<"tcp" | "udp" | "icmp">;
+}
@@ -0,0 +1,16 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import importlib
+# Make subpackages available:
+__all__ = ['config']
+for pkg in __all__:
+    if pkg != 'config':
+        importlib.import_module(f'{__name__}.{pkg}')
+
+# Export this package's modules as members:
+from ._enums import *
+from .firewall import *
+from .get_firewall import *
+from .provider import *
@@ -0,0 +1,31 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+from enum import Enum
+
+
+class Action(str, Enum):
+    """
+    The action taken for traffic that matches a firewall.
+    """
+    ALLOW = 'allow'
+    DENY = 'deny'
+
+
+class Protocol(str, Enum):
+    """
+    The protocol of a firewall rule.
+    """
+    TCP = 'tcp'
+    """
+    Transmission Control Protocol.
+    """
+    UDP = 'udp'
+    """
+    User Datagram Protocol.
+    """
+    ICMP = 'icmp'
+    """
+    Deprecated: ICMP rules are no longer supported.
+    """
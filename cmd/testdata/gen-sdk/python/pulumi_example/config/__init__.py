@@ -0,0 +1,6 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+# Export this package's modules as members:
+from .vars import *
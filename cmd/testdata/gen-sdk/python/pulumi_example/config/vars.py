@@ -0,0 +1,18 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import json
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Union
+from . import utilities, tables
+
+__config__ = pulumi.Config('example')
+
+region = __config__.get('region')
+"""
+The region in which to create resources.
+"""
+
@@ -0,0 +1,96 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import json
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Union
+from . import utilities, tables
+
+class Firewall(pulumi.CustomResource):
+    action: pulumi.Output[str]
+    """
+    The action taken for matching traffic.
+    """
+    name: pulumi.Output[str]
+    """
+    The name of the firewall.
+    """
+    rules: pulumi.Output[list]
+    """
+    The rules of the firewall.
+      * `port` (`float`) - The port to open.
+      * `protocol` (`str`) - The protocol of the rule.
+    """
+    url: pulumi.Output[str]
+    """
+    The URL of the firewall.
+    """
+    def __init__(__self__, resource_name, opts=None, action=None, name=None, rules=None, __props__=None, __name__=None, __opts__=None):
+        """
+        A firewall.
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[str] action: The action taken for matching traffic.
+        :param pulumi.Input[str] name: The name of the firewall.
+        :param pulumi.Input[list] rules: The rules of the firewall.
+
+        The **rules** object supports the following:
+
+          * `port` (`pulumi.Input[float]`) - The port to open.
+          * `protocol` (`pulumi.Input[str]`) - The protocol of the rule.
+        """
+        if __name__ is not None:
+            warnings.warn("explicit use of __name__ is deprecated", DeprecationWarning)
+            resource_name = __name__
+        if __opts__ is not None:
+            warnings.warn("explicit use of __opts__ is deprecated, use 'opts' instead", DeprecationWarning)
+            opts = __opts__
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = utilities.get_version()
+        if opts.id is None:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = dict()
+
+            if action is None:
+                action = 'allow'
+            __props__['action'] = action
+            if name is None:
+                raise TypeError("Missing required property 'name'")
+            __props__['name'] = name
+            __props__['rules'] = rules
+            __props__['url'] = None
+        super(Firewall, __self__).__init__(
+            'example:index:Firewall',
+            resource_name,
+            __props__,
+            opts)
+
+    @staticmethod
+    def get(resource_name, id, opts=None):
+        """
+        Get an existing Firewall resource's state with the given name, id, and optional extra
+        properties used to qualify the lookup.
+
+        :param str resource_name: The unique name of the resulting resource.
+        :param str id: The unique provider ID of the resource to lookup.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        opts = pulumi.ResourceOptions.merge(opts, pulumi.ResourceOptions(id=id))
+
+        __props__ = dict()
+
+        return Firewall(resource_name, opts=opts, __props__=__props__)
+    def translate_output_property(self, prop):
+        return tables._CAMEL_TO_SNAKE_CASE_TABLE.get(prop) or prop
+
+    def translate_input_property(self, prop):
+        return tables._SNAKE_TO_CAMEL_CASE_TABLE.get(prop) or prop
+
@@ -0,0 +1,45 @@
+# coding=utf-8
+# *** WARNING: this file was generated by the Pulumi SDK Generator (pulumi package gen-sdk). ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import json
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Union
+from . import utilities, tables
+
+class GetFirewallResult:
+    def __init__(__self__, url=None):
+        if url and not isinstance(url, str):
+            raise TypeError("Expected argument 'url' to be a str")
+        __self__.url = url
+        """
+        The URL of the firewall.
+        """
+class AwaitableGetFirewallResult(GetFirewallResult):
+    # pylint: disable=using-constant-test
+    def __await__(self):
+        if False:
+            yield self
+        return GetFirewallResult(
+            url=self.url)
+
+def get_firewall(name=None,opts=None):
+    """
+    Looks up a firewall by name.
+
+    :param str name: The name of the firewall.
+    """
+    __args__ = dict()
+
+
+    __args__['name'] = name
+    if opts is None:
+        opts = pulumi.InvokeOptions()
+    if opts.version is None:
+        opts.version = utilities.get_version()
+    __ret__ = pulumi.runtime.invoke('example:index:getFirewall', __args__, opts=opts).value
+
+    return AwaitableGetFirewallResult(
+        url=__ret__.get('url'))
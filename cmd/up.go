@@ -65,6 +65,12 @@ func newUpCmd() *cobra.Command {
 	var showReads bool
 	var skipPreview bool
 	var suppressOutputs bool
+	var rawStringDiffs bool
+	var summary string
+	var showOnly []string
+	var hide []string
+	var subtree string
+	var statusAddr string
 	var yes bool
 	var secretsProvider string
 	var targets []string
@@ -78,6 +84,9 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		if err := applySubtreeParents(&opts.Display, s); err != nil {
+			return result.FromError(err)
+		}
 
 		// Save any config values passed via flags.
 		if err := parseAndSaveConfigArray(s, configArray, path); err != nil {
@@ -88,6 +97,7 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		opts.Display.ProjectFileSource = projectFileSource(root)
 
 		m, err := getUpdateMetadata(message, root)
 		if err != nil {
@@ -331,6 +341,11 @@ func newUpCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			groupedSummary, err := summaryFlagToGrouped(summary)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			interactive := cmdutil.Interactive()
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
@@ -353,11 +368,17 @@ func newUpCmd() *cobra.Command {
 				ShowSameResources:    showSames,
 				ShowReads:            showReads,
 				SuppressOutputs:      suppressOutputs,
+				RawStringDiffs:       rawStringDiffs,
+				GroupedSummary:       groupedSummary,
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				StatusAddr:           statusAddr,
 				Debug:                debug,
 			}
+			if err := applyDisplayFilterFlags(&opts.Display, showOnly, hide, subtree); err != nil {
+				return result.FromError(err)
+			}
 
 			if len(args) > 0 {
 				return upTemplateNameOrURL(args[0], opts)
@@ -444,6 +465,28 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&rawStringDiffs, "raw-string-diffs", false,
+		"Diff strings that contain JSON or YAML documents as text rather than as structured documents")
+	cmd.PersistentFlags().StringVar(
+		&summary, "summary", "default",
+		"The summary of changes to display: default or grouped, which also counts the changes to each "+
+			"resource type, module and top-level component")
+	cmd.PersistentFlags().StringArrayVar(
+		&showOnly, "show-only", []string{},
+		"Only show the resources that match this filter: type=<glob>, name=<glob> or urn=<glob>, or ~= and a regular "+
+			"expression in place of = and a glob (e.g. type=aws:iam/*). Multiple filters can be specified")
+	cmd.PersistentFlags().StringArrayVar(
+		&hide, "hide", []string{},
+		"Hide the resources that match this filter, which takes the same form as --show-only. "+
+			"Multiple filters can be specified")
+	cmd.PersistentFlags().StringVar(
+		&subtree, "subtree", "",
+		"Only show the resource with this URN, e.g. a component, and its descendants")
+	cmd.PersistentFlags().StringVar(
+		&statusAddr, "status-addr", "",
+		"Serve the status of the update as JSON at this address (e.g. localhost:8080), and stream its "+
+			"engine events as server-sent events at /events")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the update after previewing it")
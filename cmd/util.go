@@ -42,6 +42,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/ciutil"
@@ -386,6 +387,28 @@ func readProject() (*workspace.Project, string, error) {
 	return proj, filepath.Dir(path), nil
 }
 
+// projectFileSource returns the path of the project file in the given project root, relative to the root of the Git
+// repository that contains it, or to the project root if there is no such repository. The path uses forward slashes so
+// that it may be used as a URI reference.
+func projectFileSource(root string) string {
+	path, err := workspace.DetectProjectPathFrom(root)
+	if err != nil || path == "" {
+		return "Pulumi.yaml"
+	}
+
+	base := root
+	if repo, err := gitutil.GetGitRepository(root); err == nil && repo != nil {
+		if wt, err := repo.Worktree(); err == nil {
+			base = wt.Filesystem.Root()
+		}
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return filepath.Base(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
 // readPolicyProject attempts to detect and read a Pulumi PolicyPack project for the current
 // workspace. If the project is successfully detected and read, it is returned along with the path
 // to its containing directory, which will be used as the root of the project's Pulumi program.
@@ -687,6 +710,67 @@ func printJSON(v interface{}) error {
 	return nil
 }
 
+// applyDisplayFilterFlags validates the values of the --show-only, --hide and --subtree flags and sets the display
+// filters they describe.
+func applyDisplayFilterFlags(opts *display.Options, showOnly, hide []string, subtree string) error {
+	for _, spec := range showOnly {
+		filter, err := display.ParseResourceFilter(spec)
+		if err != nil {
+			return err
+		}
+		opts.ShowOnly = append(opts.ShowOnly, filter)
+	}
+	for _, spec := range hide {
+		filter, err := display.ParseResourceFilter(spec)
+		if err != nil {
+			return err
+		}
+		opts.Hide = append(opts.Hide, filter)
+	}
+	if subtree != "" && !resource.URN(subtree).IsValid() {
+		return errors.Errorf("invalid --subtree %q: expected a resource URN", subtree)
+	}
+	opts.Subtree = resource.URN(subtree)
+	return nil
+}
+
+// applySubtreeParents records the parents of the stack's resources in the display options if the display is limited
+// to a subtree, so that resources that are deleted before their parents are still placed in the subtree.
+func applySubtreeParents(opts *display.Options, s backend.Stack) error {
+	if opts.Subtree == "" {
+		return nil
+	}
+
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return err
+	}
+	if snap == nil {
+		return nil
+	}
+
+	opts.SubtreeParents = make(map[resource.URN]resource.URN)
+	for _, res := range snap.Resources {
+		if res.Parent != "" {
+			opts.SubtreeParents[res.URN] = res.Parent
+		}
+	}
+	return nil
+}
+
+// summaryFlagToGrouped validates the value of the --summary flag and returns true if it requests a summary of the
+// changes grouped by resource type, module and top-level component.
+func summaryFlagToGrouped(summary string) (bool, error) {
+	switch summary {
+	case "", "default":
+		return false, nil
+	case "grouped":
+		return true, nil
+	default:
+		return false, errors.Errorf("unknown summary %q; expected default or grouped", summary)
+	}
+}
+
 // updateFlagsToOptions ensures that the given update flags represent a valid combination.  If so, an UpdateOptions
 // is returned with a nil-error; otherwise, the non-nil error contains information about why the combination is invalid.
 func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOptions, error) {
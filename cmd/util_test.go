@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pulumi/pulumi/pkg/backend"
@@ -226,3 +227,18 @@ func TestReadingGitLabMetadata(t *testing.T) {
 		assertEnvValue(t, test, backend.VCSRepoKind, gitutil.GitLabHostName)
 	}
 }
+
+// TestProjectFileSource tests that project files are located relative to the root of their Git repository.
+func TestProjectFileSource(t *testing.T) {
+	e := pul_testing.NewEnvironment(t)
+	defer e.DeleteIfNotFailed()
+
+	e.WriteTestFile("infra/Pulumi.yaml", "name: infra\nruntime: nodejs\n")
+	root := filepath.Join(e.RootPath, "infra")
+
+	// Without a repository, the project file is relative to the project root.
+	assert.Equal(t, "Pulumi.yaml", projectFileSource(root))
+
+	e.RunCommand("git", "init")
+	assert.Equal(t, "infra/Pulumi.yaml", projectFileSource(root))
+}
@@ -0,0 +1,393 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/ciutil"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ciFormat describes how the log viewer of a CI system renders collapsible groups, annotations and job summaries.
+type ciFormat interface {
+	// startGroup begins a collapsible group with the given title. Groups are identified by a sequence number.
+	startGroup(out io.Writer, id int, title string)
+	// endGroup ends the group with the given sequence number.
+	endGroup(out io.Writer, id int)
+	// annotate writes an annotation of the given severity, attached to the given file if it is not empty. It does
+	// nothing if the CI system does not support annotations.
+	annotate(out io.Writer, severity diag.Severity, file, title, message string)
+	// summaryPath returns the path of a Markdown file that the CI system renders as the job's summary, if any.
+	summaryPath() string
+}
+
+// detectCIFormat returns the format of the CI system in which the CLI is running, or nil if the CLI is not running in
+// a CI system whose log viewer is supported or if the CI display has been disabled.
+func detectCIFormat() ciFormat {
+	if cmdutil.IsTruthy(os.Getenv("PULUMI_DISABLE_CI_DISPLAY")) {
+		return nil
+	}
+
+	switch ciutil.DetectVars().Name {
+	case ciutil.GitHub:
+		return githubActionsFormat{}
+	case ciutil.GitLab:
+		return gitlabFormat{now: time.Now}
+	default:
+		return nil
+	}
+}
+
+// githubActionsFormat renders logs using GitHub Actions' workflow commands.
+type githubActionsFormat struct{}
+
+func (githubActionsFormat) startGroup(out io.Writer, id int, title string) {
+	fprintfIgnoreError(out, "::group::%s\n", githubEscapeData(title))
+}
+
+func (githubActionsFormat) endGroup(out io.Writer, id int) {
+	fprintIgnoreError(out, "::endgroup::\n")
+}
+
+func (githubActionsFormat) annotate(out io.Writer, severity diag.Severity, file, title, message string) {
+	command := "notice"
+	switch severity {
+	case diag.Error:
+		command = "error"
+	case diag.Warning:
+		command = "warning"
+	}
+
+	properties := "title=" + githubEscapeProperty(title)
+	if file != "" {
+		properties = "file=" + githubEscapeProperty(file) + "," + properties
+	}
+	fprintfIgnoreError(out, "::%s %s::%s\n",
+		command, properties, githubEscapeData(strings.TrimRight(message, "\n")))
+}
+
+func (githubActionsFormat) summaryPath() string {
+	return os.Getenv("GITHUB_STEP_SUMMARY")
+}
+
+// githubEscapeData escapes the data of a workflow command.
+func githubEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubEscapeProperty escapes the value of a workflow command's property.
+func githubEscapeProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(githubEscapeData(s))
+}
+
+// gitlabFormat renders logs using GitLab's collapsible sections. GitLab does not support annotations or job summaries.
+type gitlabFormat struct {
+	now func() time.Time
+}
+
+func (f gitlabFormat) startGroup(out io.Writer, id int, title string) {
+	fprintfIgnoreError(out, "\x1b[0Ksection_start:%d:pulumi_resource_%d[collapsed=true]\r\x1b[0K%s\n",
+		f.now().Unix(), id, title)
+}
+
+func (f gitlabFormat) endGroup(out io.Writer, id int) {
+	fprintfIgnoreError(out, "\x1b[0Ksection_end:%d:pulumi_resource_%d\r\x1b[0K\n", f.now().Unix(), id)
+}
+
+func (gitlabFormat) annotate(out io.Writer, severity diag.Severity, file, title, message string) {
+}
+
+func (gitlabFormat) summaryPath() string {
+	return ""
+}
+
+// ciAnnotation is an annotation that is written once the group it pertains to has been written.
+type ciAnnotation struct {
+	severity diag.Severity
+	title    string
+	message  string
+}
+
+// ciGroup accumulates the output for a single resource until the resource's step completes.
+type ciGroup struct {
+	metadata    engine.StepEventMetadata
+	planning    bool
+	out         bytes.Buffer
+	annotations []ciAnnotation
+}
+
+// ciRenderer renders engine events for the log viewer of a CI system. Output that pertains to a resource is grouped
+// into a collapsible group per resource, errors and policy violations are annotated, and a summary of the operation is
+// written to the job summary if the CI system supports one.
+type ciRenderer struct {
+	op     string
+	action apitype.UpdateKind
+	opts   Options
+	format ciFormat
+	out    io.Writer
+
+	groups  map[resource.URN]*ciGroup // the groups of the resources whose steps are in progress.
+	nextID  int                       // the sequence number of the next group.
+	seen    map[resource.URN]engine.StepEventMetadata
+	summary *markdownReport // the job summary, if the CI system supports one.
+}
+
+// ShowCIEvents displays the engine events in a form suited to the log viewer of the CI system in which the CLI is
+// running. It must only be called if such a system has been detected.
+func ShowCIEvents(op string, action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool, opts Options) {
+	format := detectCIFormat()
+	contract.Assert(format != nil)
+	showCIEvents(op, action, events, done, opts, format, os.Stdout)
+}
+
+func showCIEvents(op string, action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool, opts Options,
+	format ciFormat, out io.Writer) {
+
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	r := newCIRenderer(op, action, opts, format, out)
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+		r.add(e)
+	}
+	r.finish()
+}
+
+func newCIRenderer(op string, action apitype.UpdateKind, opts Options, format ciFormat, out io.Writer) *ciRenderer {
+	r := &ciRenderer{
+		op:     op,
+		action: action,
+		opts:   opts,
+		format: format,
+		out:    out,
+		groups: map[resource.URN]*ciGroup{},
+		seen:   map[resource.URN]engine.StepEventMetadata{},
+	}
+	if format.summaryPath() != "" {
+		r.summary = newMarkdownReport(op, action, opts)
+	}
+	return r
+}
+
+// add renders a single event.
+func (r *ciRenderer) add(e engine.Event) {
+	switch e.Type {
+	case engine.PreludeEvent, engine.StdoutColorEvent:
+		fprintIgnoreError(r.out, RenderDiffEvent(r.action, e, r.seen, r.opts))
+	case engine.SummaryEvent:
+		// Flush the groups of any resources that did not complete before rendering the summary.
+		r.flushAll()
+
+		p := e.Payload.(engine.SummaryEventPayload)
+		fprintIgnoreError(r.out, "\n")
+		fprintIgnoreError(r.out, renderSummaryEvent(r.action, p, false /*wroteDiagnosticHeader*/, r.opts))
+		if r.summary != nil {
+			r.summary.add(e)
+		}
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		if p.Ephemeral || (p.Severity == diag.Debug && !r.opts.Debug) {
+			return
+		}
+
+		var annotation *ciAnnotation
+		if p.Severity == diag.Error || p.Severity == diag.Warning {
+			title := "Pulumi"
+			if p.URN != "" {
+				title = string(p.URN.Name())
+			}
+			annotation = &ciAnnotation{severity: p.Severity, title: title, message: colors.Never.Colorize(p.Message)}
+		}
+		r.write(p.URN, renderDiffDiagEvent(p, r.opts), annotation)
+	case engine.PolicyViolationEvent:
+		p := e.Payload.(engine.PolicyViolationEventPayload)
+		severity := diag.Warning
+		if p.EnforcementLevel == apitype.Mandatory {
+			severity = diag.Error
+		}
+		r.write(p.ResourceURN, renderDiffPolicyViolationEvent(p, r.opts), &ciAnnotation{
+			severity: severity,
+			title:    fmt.Sprintf("%s: %s", p.PolicyPackName, p.PolicyName),
+			message:  colors.Never.Colorize(p.Message),
+		})
+		if r.summary != nil {
+			r.summary.add(e)
+		}
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		r.seen[p.Metadata.URN] = p.Metadata
+
+		g := &ciGroup{metadata: p.Metadata, planning: p.Planning}
+		r.groups[p.Metadata.URN] = g
+
+		// Each group is rendered on its own, so the diff is not indented beneath the resource's parent.
+		seen := map[resource.URN]engine.StepEventMetadata{}
+		fprintIgnoreError(&g.out, renderDiffResourcePreEvent(p, seen, r.opts))
+
+		// Groups are written once their steps complete; mark the start of long-running steps so that the log shows
+		// progress in the meantime.
+		if !p.Planning && r.showStep(p.Metadata) {
+			fprintfIgnoreError(r.out, "%s...\n", ciStepTitle(p.Metadata, true /*planning*/, ""))
+		}
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		g := r.group(p.Metadata, p.Planning)
+		seen := map[resource.URN]engine.StepEventMetadata{p.Metadata.URN: g.metadata}
+		fprintIgnoreError(&g.out, renderDiffResourceOutputsEvent(p, seen, r.opts))
+		r.flush(p.Metadata.URN, "")
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		r.group(p.Metadata, false)
+		r.flush(p.Metadata.URN, "failed")
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+}
+
+// showStep returns true if a step is worth a group of its own even if it produced no output.
+func (r *ciRenderer) showStep(m engine.StepEventMetadata) bool {
+	return m.Op != deploy.OpRefresh && shouldShow(m, r.opts) && !isRootStack(m)
+}
+
+// group returns the group for the resource that the given step pertains to, creating it if necessary.
+func (r *ciRenderer) group(m engine.StepEventMetadata, planning bool) *ciGroup {
+	g, ok := r.groups[m.URN]
+	if !ok {
+		g = &ciGroup{metadata: m, planning: planning}
+		r.groups[m.URN] = g
+	}
+	return g
+}
+
+// write writes output that pertains to the given resource. If the resource's step is in progress, the output and its
+// annotation are added to the resource's group; otherwise, they are written immediately.
+func (r *ciRenderer) write(urn resource.URN, text string, annotation *ciAnnotation) {
+	if g, ok := r.groups[urn]; ok {
+		fprintIgnoreError(&g.out, text)
+		if annotation != nil {
+			g.annotations = append(g.annotations, *annotation)
+		}
+		return
+	}
+
+	fprintIgnoreError(r.out, text)
+	if annotation != nil {
+		r.format.annotate(r.out, annotation.severity, r.opts.ProjectFileSource, annotation.title, annotation.message)
+	}
+}
+
+// flush writes the group of the given resource, followed by the group's annotations.
+func (r *ciRenderer) flush(urn resource.URN, status string) {
+	g, ok := r.groups[urn]
+	if !ok {
+		return
+	}
+	delete(r.groups, urn)
+
+	if g.out.Len() != 0 || status != "" || r.showStep(g.metadata) {
+		id := r.nextID
+		r.nextID++
+
+		r.format.startGroup(r.out, id, ciStepTitle(g.metadata, g.planning, status))
+		text := g.out.String()
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		fprintIgnoreError(r.out, text)
+		r.format.endGroup(r.out, id)
+	}
+	for _, a := range g.annotations {
+		r.format.annotate(r.out, a.severity, r.opts.ProjectFileSource, a.title, a.message)
+	}
+}
+
+// flushAll writes the groups of all resources whose steps are in progress.
+func (r *ciRenderer) flushAll() {
+	var urns []resource.URN
+	for urn := range r.groups {
+		urns = append(urns, urn)
+	}
+	sort.Slice(urns, func(i, j int) bool { return urns[i] < urns[j] })
+	for _, urn := range urns {
+		r.flush(urn, "")
+	}
+}
+
+// finish writes any remaining groups and the job summary.
+func (r *ciRenderer) finish() {
+	r.flushAll()
+
+	if r.summary == nil {
+		return
+	}
+	if err := r.writeJobSummary(r.format.summaryPath()); err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not write job summary to %s: %v"),
+			r.format.summaryPath(), err)
+	}
+}
+
+// writeJobSummary appends the operation's resource changes and policy violations to the job summary at the given path.
+func (r *ciRenderer) writeJobSummary(path string) error {
+	out := &bytes.Buffer{}
+	fprintfIgnoreError(out, "### %s\n", r.op)
+	r.summary.writeSummary(out)
+	r.summary.writePolicyViolations(out)
+	fprintIgnoreError(out, "\n")
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(out.Bytes()); err != nil {
+		contract.IgnoreClose(f)
+		return err
+	}
+	return f.Close()
+}
+
+// ciStepTitle returns the title of a resource's group, e.g. "+ aws:s3/bucket:Bucket my-bucket (create)".
+func ciStepTitle(m engine.StepEventMetadata, planning bool, status string) string {
+	if status == "" {
+		status = string(m.Op)
+		if !planning {
+			status = m.Op.PastTense()
+		}
+	}
+	if m.Op == deploy.OpSame {
+		status = "unchanged"
+	}
+	title := fmt.Sprintf("%s %s (%s)", m.URN.Type(), m.URN.Name(), status)
+	if prefix := strings.TrimSpace(m.Op.RawPrefix()); prefix != "" {
+		title = prefix + " " + title
+	}
+	return title
+}
@@ -0,0 +1,149 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func renderCIEvents(events []engine.Event, format ciFormat) string {
+	var out bytes.Buffer
+	ch, done := make(chan engine.Event), make(chan bool)
+	opts := Options{Color: colors.Never, ProjectFileSource: "infra/Pulumi.yaml"}
+	go showCIEvents("Previewing update", apitype.PreviewUpdate, ch, done, opts, format, &out)
+	for _, e := range events {
+		ch <- e
+	}
+	ch <- engine.Event{Type: engine.CancelEvent}
+	<-done
+	return out.String()
+}
+
+func TestCIEventsGitHubActions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-ci-display")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	summaryPath := filepath.Join(dir, "summary.md")
+
+	old, had := os.LookupEnv("GITHUB_STEP_SUMMARY")
+	if had {
+		defer os.Setenv("GITHUB_STEP_SUMMARY", old)
+	} else {
+		defer os.Unsetenv("GITHUB_STEP_SUMMARY")
+	}
+	assert.NoError(t, os.Setenv("GITHUB_STEP_SUMMARY", summaryPath))
+
+	out := renderCIEvents(testPreviewEvents(false), githubActionsFormat{})
+
+	// Each resource's output is grouped, and followed by the annotations for its diagnostics and policy violations.
+	assert.Contains(t, out, "::group::~ aws:s3/bucket:Bucket my-bucket (update)\n"+
+		"~ aws:s3/bucket:Bucket: (update)\n"+
+		"    [urn=urn:pulumi:dev::proj::aws:s3/bucket:Bucket::my-bucket]\n"+
+		"  ~ acl   : \"private\" => \"public-read\"\n"+
+		"  ~ secret: \"[secret]\" => \"[secret]\"\n"+
+		"bucket | acl is deprecated\n"+
+		"Buckets must\nnot be public.\n"+
+		"::endgroup::\n"+
+		"::warning file=infra/Pulumi.yaml,title=my-bucket::bucket | acl is deprecated\n"+
+		"::error file=infra/Pulumi.yaml,title=security%3A no-public-buckets::Buckets must%0Anot be public.\n")
+	assert.Contains(t, out, "::group::pulumi:pulumi:Stack proj-dev (unchanged)\n")
+	assert.NotContains(t, out, "spinner")
+
+	// The summary is written to the log, and to the job summary.
+	assert.Contains(t, out, "not be public.\n\nResources:\n    ~ 1 to update\n")
+	b, err := ioutil.ReadFile(summaryPath)
+	if assert.NoError(t, err) {
+		summary := string(b)
+		assert.True(t, strings.HasPrefix(summary, "### Previewing update\n\n#### Resources\n"), summary)
+		assert.Contains(t, summary, "| `~` to update | 1 |\n")
+		assert.Contains(t, summary, "`no-public-buckets`")
+		assert.NotContains(t, summary, "```diff")
+	}
+}
+
+func TestGitHubActionsAnnotate(t *testing.T) {
+	var out bytes.Buffer
+	githubActionsFormat{}.annotate(&out, diag.Error, "infra/Pulumi.yaml", "my-bucket", "oh no\n")
+	githubActionsFormat{}.annotate(&out, diag.Warning, "", "Pulumi", "careful")
+	assert.Equal(t, "::error file=infra/Pulumi.yaml,title=my-bucket::oh no\n"+
+		"::warning title=Pulumi::careful\n", out.String())
+}
+
+func TestCIEventsGitLab(t *testing.T) {
+	now := time.Unix(1580000000, 0)
+	out := renderCIEvents(testPreviewEvents(false), gitlabFormat{now: func() time.Time { return now }})
+
+	assert.Contains(t, out,
+		"\x1b[0Ksection_start:1580000000:pulumi_resource_1[collapsed=true]\r\x1b[0K"+
+			"~ aws:s3/bucket:Bucket my-bucket (update)\n")
+	assert.Contains(t, out, "not be public.\n\x1b[0Ksection_end:1580000000:pulumi_resource_1\r\x1b[0K\n")
+
+	// GitLab does not support annotations.
+	assert.NotContains(t, out, "::error")
+}
+
+func TestCIEventsUpdate(t *testing.T) {
+	var events []engine.Event
+	for _, e := range testPreviewEvents(false) {
+		// Turn the preview into an update whose bucket update fails.
+		switch p := e.Payload.(type) {
+		case engine.ResourcePreEventPayload:
+			p.Planning = false
+			e.Payload = p
+		case engine.ResourceOutputsEventPayload:
+			p.Planning = false
+			e.Payload = p
+		case engine.SummaryEventPayload:
+			continue
+		}
+		events = append(events, e)
+		if e.Type == engine.PolicyViolationEvent {
+			events = append(events, engine.Event{Type: engine.ResourceOperationFailed,
+				Payload: engine.ResourceOperationFailedPayload{Metadata: engine.StepEventMetadata{
+					Op: deploy.OpUpdate, URN: testBucketURN, Type: testBucketURN.Type(),
+				}}})
+		}
+	}
+
+	out := renderCIEvents(events, githubActionsFormat{})
+
+	// Steps that are being applied are marked as such before their groups are written.
+	assert.Contains(t, out, "~ aws:s3/bucket:Bucket my-bucket (update)...\n")
+	assert.Contains(t, out, "::group::~ aws:s3/bucket:Bucket my-bucket (failed)\n")
+	assert.Contains(t, out, "::group::pulumi:pulumi:Stack proj-dev (unchanged)\n")
+}
+
+func TestCIStepTitle(t *testing.T) {
+	m := engine.StepEventMetadata{Op: deploy.OpReplace, URN: testBucketURN}
+	assert.Equal(t, "+- aws:s3/bucket:Bucket my-bucket (replace)", ciStepTitle(m, true, ""))
+	assert.Equal(t, "+- aws:s3/bucket:Bucket my-bucket (replaced)", ciStepTitle(m, false, ""))
+	assert.Equal(t, "+- aws:s3/bucket:Bucket my-bucket (failed)", ciStepTitle(m, false, "failed"))
+}
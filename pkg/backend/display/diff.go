@@ -21,6 +21,7 @@ import (
 	"math"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -218,6 +219,16 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 		fprintfIgnoreError(out, "\n")
 	}
 
+	if event.HiddenSteps > 0 {
+		fprintfIgnoreError(out, "    %d %s hidden by display filters\n",
+			event.HiddenSteps, english.PluralWord(event.HiddenSteps, "step", ""))
+	}
+
+	// If requested, break the changes down by resource type, module and top-level component.
+	if opts.GroupedSummary {
+		renderChangeGroups(out, event.ChangeGroups, event.IsPreview, opts)
+	}
+
 	// Print policy packs loaded. Data is rendered as a table of {policy-pack-name, version}.
 	renderPolicyPacks(out, event.PolicyPacks, opts)
 
@@ -235,6 +246,80 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	return out.String()
 }
 
+// renderChangeGroups renders tables of the resource changes per resource type, module and top-level component.
+func renderChangeGroups(out io.Writer, groups engine.ResourceChangeGroups, isPreview bool, opts Options) {
+	types := make(map[string]engine.ResourceChanges, len(groups.Types))
+	for typ, changes := range groups.Types {
+		types[string(typ)] = changes
+	}
+	renderChangeGroup(out, "Resources by type", types, isPreview, opts)
+
+	modules := make(map[string]engine.ResourceChanges, len(groups.Modules))
+	for module, changes := range groups.Modules {
+		modules[string(module)] = changes
+	}
+	renderChangeGroup(out, "Resources by module", modules, isPreview, opts)
+
+	components := make(map[string]engine.ResourceChanges, len(groups.Components))
+	for urn, changes := range groups.Components {
+		components[fmt.Sprintf("%s (%s)", urn.Name(), urn.Type())] = changes
+	}
+	renderChangeGroup(out, "Resources by component", components, isPreview, opts)
+}
+
+// renderChangeGroup renders a table of the resource changes per group, sorted by the groups' names. Groups that only
+// read resources are omitted.
+func renderChangeGroup(out io.Writer, title string, groups map[string]engine.ResourceChanges, isPreview bool,
+	opts Options) {
+
+	var planTo string
+	if isPreview {
+		planTo = "to "
+	}
+
+	var names []string
+	summaries := make(map[string]string)
+	maxNameLen := 0
+	for name, changes := range groups {
+		// As in the summary of all changes, sames are listed after the changes and reads are ignored.
+		var pieces []string
+		for _, op := range deploy.StepOps {
+			c := changes[op]
+			if c == 0 || op == deploy.OpSame || op == deploy.OpRead || op == deploy.OpReadDiscard ||
+				op == deploy.OpReadReplacement {
+				continue
+			}
+
+			opDescription := string(op)
+			if !isPreview {
+				opDescription = op.PastTense()
+			}
+			pieces = append(pieces, fmt.Sprintf("%s%d %s%s%s", op.Prefix(), c, planTo, opDescription, colors.Reset))
+		}
+		if c := changes[deploy.OpSame]; c > 0 {
+			pieces = append(pieces, fmt.Sprintf("%d unchanged", c))
+		}
+		if len(pieces) == 0 {
+			continue
+		}
+
+		names, summaries[name] = append(names, name), strings.Join(pieces, ", ")
+		if l := len(name); l > maxNameLen {
+			maxNameLen = l
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%s%s:%s\n", colors.SpecHeadline, title, colors.Reset)))
+	for _, name := range names {
+		fprintIgnoreError(out, opts.Color.Colorize(
+			fmt.Sprintf("    %s%s%s\n", name, messagePadding(name, maxNameLen, 2), summaries[name])))
+	}
+}
+
 func renderPolicyPacks(out io.Writer, policyPacks map[string]string, opts Options) {
 	if len(policyPacks) == 0 {
 		return
@@ -311,7 +396,8 @@ func renderDiff(
 	if metadata.DetailedDiff != nil {
 		var buf bytes.Buffer
 		if diff := translateDetailedDiff(metadata); diff != nil {
-			engine.PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug)
+			engine.PrintObjectDiff(
+				&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, debug, !opts.RawStringDiffs)
 		} else {
 			engine.PrintObject(
 				&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, debug)
@@ -319,7 +405,7 @@ func renderDiff(
 		details = buf.String()
 	} else {
 		details = engine.GetResourcePropertiesDetails(
-			metadata, indent, planning, opts.SummaryDiff, debug)
+			metadata, indent, planning, opts.SummaryDiff, debug, !opts.RawStringDiffs)
 	}
 
 	fprintIgnoreError(out, opts.Color.Colorize(summary))
@@ -371,7 +457,7 @@ func renderDiffResourceOutputsEvent(
 			// things that are the same.
 			text := engine.GetResourceOutputsPropertiesString(
 				payload.Metadata, indent+1, payload.Planning,
-				payload.Debug, refresh, opts.ShowSameResources)
+				payload.Debug, refresh, opts.ShowSameResources, !opts.RawStringDiffs)
 			if text != "" {
 				header := fmt.Sprintf("%v%v--outputs:--%v\n",
 					payload.Metadata.Op.Color(), engine.GetIndentationString(indent+1), colors.Reset)
@@ -0,0 +1,100 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestGroupedSummary(t *testing.T) {
+	compURN := resource.URN("urn:pulumi:dev::proj::my:index:Component::web")
+	summary := engine.SummaryEventPayload{
+		IsPreview:       true,
+		ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 3, deploy.OpUpdate: 1, deploy.OpSame: 1},
+		ChangeGroups: engine.ResourceChangeGroups{
+			Types: map[tokens.Type]engine.ResourceChanges{
+				"aws:s3/bucket:Bucket":       {deploy.OpCreate: 2, deploy.OpSame: 1},
+				"aws:ec2/instance:Instance":  {deploy.OpUpdate: 1},
+				"my:index:Component":         {deploy.OpCreate: 1},
+				"aws:s3/getBucket:getBucket": {deploy.OpRead: 1},
+			},
+			Modules: map[tokens.Module]engine.ResourceChanges{
+				"aws:s3":   {deploy.OpCreate: 2, deploy.OpSame: 1},
+				"aws:ec2":  {deploy.OpUpdate: 1},
+				"my:index": {deploy.OpCreate: 1},
+			},
+			Components: map[resource.URN]engine.ResourceChanges{
+				compURN: {deploy.OpCreate: 2},
+			},
+		},
+	}
+
+	// The groups are only rendered if requested.
+	opts := Options{Color: colors.Never}
+	assert.Equal(t, "Resources:\n"+
+		"    + 3 to create\n"+
+		"    ~ 1 to update\n"+
+		"    4 changes. 1 unchanged\n", renderSummaryEvent(apitype.UpdateUpdate, summary, false, opts))
+
+	opts.GroupedSummary = true
+	assert.Equal(t, "Resources:\n"+
+		"    + 3 to create\n"+
+		"    ~ 1 to update\n"+
+		"    4 changes. 1 unchanged\n"+
+		"\n"+
+		"Resources by type:\n"+
+		"    aws:ec2/instance:Instance  ~ 1 to update\n"+
+		"    aws:s3/bucket:Bucket       + 2 to create, 1 unchanged\n"+
+		"    my:index:Component         + 1 to create\n"+
+		"\n"+
+		"Resources by module:\n"+
+		"    aws:ec2   ~ 1 to update\n"+
+		"    aws:s3    + 2 to create, 1 unchanged\n"+
+		"    my:index  + 1 to create\n"+
+		"\n"+
+		"Resources by component:\n"+
+		"    web (my:index:Component)  + 2 to create\n", renderSummaryEvent(apitype.UpdateUpdate, summary, false, opts))
+
+	// Updates report the operations that were performed.
+	summary.IsPreview = false
+	assert.Contains(t, renderSummaryEvent(apitype.UpdateUpdate, summary, false, opts),
+		"    web (my:index:Component)  + 2 created\n")
+}
+
+func TestSummaryHiddenSteps(t *testing.T) {
+	summary := engine.SummaryEventPayload{
+		IsPreview:       true,
+		ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 3},
+		HiddenSteps:     2,
+	}
+
+	opts := Options{Color: colors.Never}
+	assert.Equal(t, "Resources:\n"+
+		"    + 3 to create\n"+
+		"    2 steps hidden by display filters\n", renderSummaryEvent(apitype.UpdateUpdate, summary, false, opts))
+
+	summary.HiddenSteps = 1
+	assert.Contains(t, renderSummaryEvent(apitype.UpdateUpdate, summary, false, opts),
+		"    1 step hidden by display filters\n")
+}
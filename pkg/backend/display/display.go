@@ -40,6 +40,20 @@ func ShowEvents(
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts.EventLogPath)
 	}
+	if opts.ReportPath != "" {
+		events, done = startMarkdownReport(op, action, events, done, opts.ReportPath, opts)
+	}
+	if opts.PolicyReportPath != "" {
+		events, done = startPolicyReport(events, done, opts.PolicyReportPath, opts.PolicyReportFormat,
+			opts.ProjectFileSource)
+	}
+	if opts.StatusAddr != "" {
+		events, done = startStatusServer(action, stack, proj, events, done, opts, isPreview)
+	}
+	if opts.filtersResources() {
+		// Filters only apply to the display itself: the event log and reports above describe all resources.
+		events, done = startDisplayFilter(events, done, opts)
+	}
 
 	if opts.JSONDisplay {
 		// TODO[pulumi/pulumi#2390]: enable JSON display for real deployments.
@@ -52,12 +66,19 @@ func ShowEvents(
 	case DisplayDiff:
 		ShowDiffEvents(op, action, events, done, opts)
 	case DisplayProgress:
-		ShowProgressEvents(op, action, stack, proj, events, done, opts, isPreview)
+		// When running non-interactively in a CI system with a supported log viewer, tailor the output to it.
+		if !opts.IsInteractive && detectCIFormat() != nil {
+			ShowCIEvents(op, action, events, done, opts)
+		} else {
+			ShowProgressEvents(op, action, stack, proj, events, done, opts, isPreview)
+		}
 	case DisplayQuery:
 		contract.Failf("DisplayQuery can only be used in query mode, which should be invoked " +
 			"directly instead of through ShowEvents")
 	case DisplayWatch:
 		ShowWatchEvents(op, action, events, done, opts)
+	case DisplayMarkdown:
+		ShowMarkdownEvents(op, action, events, done, opts)
 	default:
 		contract.Failf("Unknown display type %d", opts.Type)
 	}
@@ -138,8 +159,9 @@ func shouldShow(step engine.StepEventMetadata, opts Options) bool {
 	}
 
 	// For logical replacement operations, only show them during progress-style updates (since this is integrated
-	// into the resource status update), or if it is requested explicitly (for diffs and JSON outputs).
-	if (opts.Type == DisplayDiff || opts.JSONDisplay) && !step.Logical && !opts.ShowReplacementSteps {
+	// into the resource status update), or if it is requested explicitly (for diffs, JSON and Markdown outputs).
+	if (opts.Type == DisplayDiff || opts.Type == DisplayMarkdown || opts.JSONDisplay) &&
+		!step.Logical && !opts.ShowReplacementSteps {
 		return false
 	}
 
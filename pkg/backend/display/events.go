@@ -1,12 +1,19 @@
 package display
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
@@ -47,6 +54,7 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			Message:   p.Message,
 			Color:     string(p.Color),
 			Severity:  string(p.Severity),
+			StreamID:  int(p.StreamID),
 			Ephemeral: p.Ephemeral,
 		}
 
@@ -218,3 +226,240 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.S
 		InitErrors: md.InitErrors,
 	}
 }
+
+// ConvertJSONEvent converts an apitype.EngineEvent, such as one read from an event log, back into the engine.Event
+// that produced it. Returns an error if the event does not hold exactly one payload.
+//
+// Because ConvertEngineEvent is lossy, so is the result: secret values are blinded, and the IsPreview flags of
+// prelude and summary events and the Debug flags of resource events are always false.
+func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
+	var events []engine.Event
+
+	if apiEvent.CancelEvent != nil {
+		events = append(events, engine.Event{Type: engine.CancelEvent})
+	}
+
+	if p := apiEvent.StdoutEvent; p != nil {
+		events = append(events, engine.Event{
+			Type: engine.StdoutColorEvent,
+			Payload: engine.StdoutEventPayload{
+				Message: p.Message,
+				Color:   colors.Colorization(p.Color),
+			},
+		})
+	}
+
+	if p := apiEvent.DiagnosticEvent; p != nil {
+		events = append(events, engine.Event{
+			Type: engine.DiagEvent,
+			Payload: engine.DiagEventPayload{
+				URN:       resource.URN(p.URN),
+				Prefix:    p.Prefix,
+				Message:   p.Message,
+				Color:     colors.Colorization(p.Color),
+				Severity:  diag.Severity(p.Severity),
+				StreamID:  int32(p.StreamID),
+				Ephemeral: p.Ephemeral,
+			},
+		})
+	}
+
+	if p := apiEvent.PolicyEvent; p != nil {
+		events = append(events, engine.Event{
+			Type: engine.PolicyViolationEvent,
+			Payload: engine.PolicyViolationEventPayload{
+				ResourceURN:       resource.URN(p.ResourceURN),
+				Message:           p.Message,
+				Color:             colors.Colorization(p.Color),
+				PolicyName:        p.PolicyName,
+				PolicyPackName:    p.PolicyPackName,
+				PolicyPackVersion: p.PolicyPackVersion,
+				EnforcementLevel:  apitype.EnforcementLevel(p.EnforcementLevel),
+			},
+		})
+	}
+
+	if p := apiEvent.PreludeEvent; p != nil {
+		cfg := make(map[string]string)
+		for k, v := range p.Config {
+			cfg[k] = v
+		}
+		events = append(events, engine.Event{
+			Type:    engine.PreludeEvent,
+			Payload: engine.PreludeEventPayload{Config: cfg},
+		})
+	}
+
+	if p := apiEvent.SummaryEvent; p != nil {
+		changes := make(engine.ResourceChanges)
+		for op, count := range p.ResourceChanges {
+			changes[deploy.StepOp(op)] = count
+		}
+		events = append(events, engine.Event{
+			Type: engine.SummaryEvent,
+			Payload: engine.SummaryEventPayload{
+				MaybeCorrupt:    p.MaybeCorrupt,
+				Duration:        time.Duration(p.DurationSeconds) * time.Second,
+				ResourceChanges: changes,
+				PolicyPacks:     p.PolicyPacks,
+			},
+		})
+	}
+
+	if p := apiEvent.ResourcePreEvent; p != nil {
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		events = append(events, engine.Event{
+			Type: engine.ResourcePreEvent,
+			Payload: engine.ResourcePreEventPayload{
+				Metadata: md,
+				Planning: p.Planning,
+			},
+		})
+	}
+
+	if p := apiEvent.ResOutputsEvent; p != nil {
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		events = append(events, engine.Event{
+			Type: engine.ResourceOutputsEvent,
+			Payload: engine.ResourceOutputsEventPayload{
+				Metadata: md,
+				Planning: p.Planning,
+			},
+		})
+	}
+
+	if p := apiEvent.ResOpFailedEvent; p != nil {
+		md, err := convertJSONStepEventMetadata(p.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		events = append(events, engine.Event{
+			Type: engine.ResourceOperationFailed,
+			Payload: engine.ResourceOperationFailedPayload{
+				Metadata: md,
+				Status:   resource.Status(p.Status),
+				Steps:    p.Steps,
+			},
+		})
+	}
+
+	if len(events) != 1 {
+		return engine.Event{}, errors.Errorf("expected exactly one event payload, found %d", len(events))
+	}
+	return events[0], nil
+}
+
+func convertJSONStepEventMetadata(md apitype.StepEventMetadata) (engine.StepEventMetadata, error) {
+	keys := make([]resource.PropertyKey, len(md.Keys))
+	for i, v := range md.Keys {
+		keys[i] = resource.PropertyKey(v)
+	}
+	var diffs []resource.PropertyKey
+	for _, v := range md.Diffs {
+		diffs = append(diffs, resource.PropertyKey(v))
+	}
+	var detailedDiff map[string]plugin.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]plugin.PropertyDiff)
+		for k, v := range md.DetailedDiff {
+			var d plugin.DiffKind
+			switch v.Kind {
+			case apitype.DiffAdd:
+				d = plugin.DiffAdd
+			case apitype.DiffAddReplace:
+				d = plugin.DiffAddReplace
+			case apitype.DiffDelete:
+				d = plugin.DiffDelete
+			case apitype.DiffDeleteReplace:
+				d = plugin.DiffDeleteReplace
+			case apitype.DiffUpdate:
+				d = plugin.DiffUpdate
+			case apitype.DiffUpdateReplace:
+				d = plugin.DiffUpdateReplace
+			default:
+				return engine.StepEventMetadata{}, errors.Errorf("unrecognized diff kind %q", v.Kind)
+			}
+			detailedDiff[k] = plugin.PropertyDiff{
+				Kind:      d,
+				InputDiff: v.InputDiff,
+			}
+		}
+	}
+
+	old, err := convertJSONStepEventStateMetadata(md.Old)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+	new, err := convertJSONStepEventStateMetadata(md.New)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+
+	// The latest known state of the resource is not sent over the wire, but is always the new state if there is one.
+	res := new
+	if res == nil {
+		res = old
+	}
+
+	return engine.StepEventMetadata{
+		Op:   deploy.StepOp(md.Op),
+		URN:  resource.URN(md.URN),
+		Type: tokens.Type(md.Type),
+
+		Old: old,
+		New: new,
+		Res: res,
+
+		Keys:         keys,
+		Diffs:        diffs,
+		DetailedDiff: detailedDiff,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+	}, nil
+}
+
+// convertJSONStepEventStateMetadata converts the API type we send over the wire back into the internal
+// StepEventStateMetadata, reconstructing the raw resource state from the metadata's fields.
+func convertJSONStepEventStateMetadata(md *apitype.StepEventStateMetadata) (*engine.StepEventStateMetadata, error) {
+	if md == nil {
+		return nil, nil
+	}
+	if md.Type == "" {
+		return nil, errors.Errorf("missing type for resource %s", md.URN)
+	}
+
+	// Secret values were blinded when the event was converted, so there is nothing to decrypt.
+	decrypter := config.NewBlindingDecrypter()
+	inputs, err := stack.DeserializeProperties(md.Inputs, decrypter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "deserializing inputs of %s", md.URN)
+	}
+	outputs, err := stack.DeserializeProperties(md.Outputs, decrypter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "deserializing outputs of %s", md.URN)
+	}
+
+	t, urn, id, parent := tokens.Type(md.Type), resource.URN(md.URN), resource.ID(md.ID), resource.URN(md.Parent)
+	return &engine.StepEventStateMetadata{
+		State: resource.NewState(t, urn, md.Custom, md.Delete, id, inputs, outputs, parent, md.Protect,
+			false, nil, md.InitErrors, md.Provider, nil, false, nil, nil, nil),
+		Type: t,
+		URN:  urn,
+
+		Custom:     md.Custom,
+		Delete:     md.Delete,
+		ID:         id,
+		Parent:     parent,
+		Protect:    md.Protect,
+		Inputs:     inputs,
+		Outputs:    outputs,
+		Provider:   md.Provider,
+		InitErrors: md.InitErrors,
+	}, nil
+}
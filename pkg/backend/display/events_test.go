@@ -0,0 +1,161 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestConvertJSONEventRoundtrip(t *testing.T) {
+	urn := resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b")
+	state := &engine.StepEventStateMetadata{
+		Type:    "aws:s3/bucket:Bucket",
+		URN:     urn,
+		Custom:  true,
+		ID:      "b-1234",
+		Parent:  "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+		Inputs:  resource.PropertyMap{"acl": resource.NewStringProperty("private")},
+		Outputs: resource.PropertyMap{"arn": resource.NewStringProperty("arn:aws:s3:::b-1234")},
+	}
+	metadata := engine.StepEventMetadata{
+		Op:    deploy.OpUpdate,
+		URN:   urn,
+		Type:  "aws:s3/bucket:Bucket",
+		Old:   state,
+		New:   state,
+		Keys:  []resource.PropertyKey{},
+		Diffs: []resource.PropertyKey{"acl"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"acl": {Kind: plugin.DiffUpdate, InputDiff: true},
+		},
+		Provider: "urn:pulumi:dev::proj::pulumi:providers:aws::default",
+	}
+
+	events := []engine.Event{
+		{Type: engine.CancelEvent},
+		{Type: engine.StdoutColorEvent, Payload: engine.StdoutEventPayload{Message: "hi", Color: colors.Never}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: urn, Message: "warning", Color: colors.Raw, Severity: diag.Warning, StreamID: 2,
+		}},
+		{Type: engine.PolicyViolationEvent, Payload: engine.PolicyViolationEventPayload{
+			ResourceURN: urn, Message: "no", Color: colors.Raw, PolicyName: "p", PolicyPackName: "pack",
+			PolicyPackVersion: "1", EnforcementLevel: apitype.Mandatory,
+		}},
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{Config: map[string]string{"a": "b"}}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			Duration:        3 * time.Second,
+			ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1},
+			PolicyPacks:     map[string]string{"pack": "1"},
+		}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: metadata, Planning: true}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{Metadata: metadata}},
+		{Type: engine.ResourceOperationFailed, Payload: engine.ResourceOperationFailedPayload{
+			Metadata: metadata, Status: resource.StatusUnknown, Steps: 1,
+		}},
+	}
+
+	for _, e := range events {
+		t.Run(string(e.Type), func(t *testing.T) {
+			apiEvent, err := ConvertEngineEvent(e)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Round-trip the event through JSON, as the event log does.
+			b, err := json.Marshal(apiEvent)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var decoded apitype.EngineEvent
+			if !assert.NoError(t, json.Unmarshal(b, &decoded)) {
+				return
+			}
+
+			actual, err := ConvertJSONEvent(decoded)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, e.Type, actual.Type)
+
+			switch p := actual.Payload.(type) {
+			case engine.ResourcePreEventPayload:
+				assertMetadataEqual(t, metadata, p.Metadata)
+				assert.True(t, p.Planning)
+			case engine.ResourceOutputsEventPayload:
+				assertMetadataEqual(t, metadata, p.Metadata)
+			case engine.ResourceOperationFailedPayload:
+				assertMetadataEqual(t, metadata, p.Metadata)
+				assert.Equal(t, resource.StatusUnknown, p.Status)
+				assert.Equal(t, 1, p.Steps)
+			default:
+				assert.Equal(t, e.Payload, actual.Payload)
+			}
+		})
+	}
+}
+
+func assertMetadataEqual(t *testing.T, expected, actual engine.StepEventMetadata) {
+	assert.Equal(t, expected.Op, actual.Op)
+	assert.Equal(t, expected.URN, actual.URN)
+	assert.Equal(t, expected.Type, actual.Type)
+	assert.Equal(t, expected.Keys, actual.Keys)
+	assert.Equal(t, expected.Diffs, actual.Diffs)
+	assert.Equal(t, expected.DetailedDiff, actual.DetailedDiff)
+	assert.Equal(t, expected.Provider, actual.Provider)
+
+	for _, md := range []*engine.StepEventStateMetadata{actual.Old, actual.New, actual.Res} {
+		if !assert.NotNil(t, md) {
+			continue
+		}
+		assert.Equal(t, expected.New.URN, md.URN)
+		assert.Equal(t, expected.New.ID, md.ID)
+		assert.Equal(t, expected.New.Parent, md.Parent)
+		assert.Equal(t, expected.New.Inputs, md.Inputs)
+		assert.Equal(t, expected.New.Outputs, md.Outputs)
+		if assert.NotNil(t, md.State) {
+			assert.Equal(t, expected.New.Outputs, md.State.Outputs)
+		}
+	}
+}
+
+func TestConvertJSONEventErrors(t *testing.T) {
+	_, err := ConvertJSONEvent(apitype.EngineEvent{})
+	assert.Error(t, err)
+
+	_, err = ConvertJSONEvent(apitype.EngineEvent{
+		CancelEvent: &apitype.CancelEvent{},
+		StdoutEvent: &apitype.StdoutEngineEvent{},
+	})
+	assert.Error(t, err)
+
+	_, err = ConvertJSONEvent(apitype.EngineEvent{
+		ResourcePreEvent: &apitype.ResourcePreEvent{Metadata: apitype.StepEventMetadata{
+			DetailedDiff: map[string]apitype.PropertyDiff{"a": {Kind: "bogus"}},
+		}},
+	})
+	assert.Error(t, err)
+}
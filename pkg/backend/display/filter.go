@@ -0,0 +1,222 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// ResourceFilter selects resources by their type, name or URN, e.g. "type=aws:iam/*" or "urn~=-test$".
+type ResourceFilter struct {
+	spec    string
+	field   string
+	pattern *regexp.Regexp
+}
+
+// ParseResourceFilter parses a resource filter of the form `<field>=<glob>` or `<field>~=<regexp>`, where the field is
+// one of "type", "name" or "urn". In globs, `*` matches any sequence of characters and `?` matches any one character.
+func ParseResourceFilter(spec string) (ResourceFilter, error) {
+	i := strings.Index(spec, "=")
+	if i == -1 {
+		return ResourceFilter{}, errors.Errorf("invalid resource filter %q: expected <field>=<glob> or <field>~=<regexp>",
+			spec)
+	}
+	field, value, isRegexp := spec[:i], spec[i+1:], false
+	if strings.HasSuffix(field, "~") {
+		field, isRegexp = field[:len(field)-1], true
+	}
+
+	switch field {
+	case "type", "name", "urn":
+	default:
+		return ResourceFilter{}, errors.Errorf("invalid resource filter %q: unknown field %q; expected type, name or urn",
+			spec, field)
+	}
+
+	expr := value
+	if !isRegexp {
+		expr = globToRegexp(value)
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return ResourceFilter{}, errors.Wrapf(err, "invalid resource filter %q", spec)
+	}
+
+	return ResourceFilter{spec: spec, field: field, pattern: pattern}, nil
+}
+
+// globToRegexp converts a glob into an anchored regular expression.
+func globToRegexp(glob string) string {
+	expr := regexp.QuoteMeta(glob)
+	expr = strings.Replace(expr, `\*`, ".*", -1)
+	expr = strings.Replace(expr, `\?`, ".", -1)
+	return "^" + expr + "$"
+}
+
+// Matches returns true if the resource with the given URN is selected by the filter.
+func (f ResourceFilter) Matches(urn resource.URN) bool {
+	switch f.field {
+	case "type":
+		return f.pattern.MatchString(string(urn.Type()))
+	case "name":
+		return f.pattern.MatchString(string(urn.Name()))
+	default:
+		return f.pattern.MatchString(string(urn))
+	}
+}
+
+func (f ResourceFilter) String() string {
+	return f.spec
+}
+
+// filtersResources returns true if the options hide some resources from the display.
+func (opts Options) filtersResources() bool {
+	return len(opts.ShowOnly) != 0 || len(opts.Hide) != 0 || opts.Subtree != ""
+}
+
+// displayFilter decides which resources are hidden from the display by the filters in the display options.
+type displayFilter struct {
+	opts    Options
+	parents map[resource.URN]resource.URN // the parent of each resource we have heard about.
+	hidden  map[resource.URN]bool         // whether each resource we have heard about is hidden.
+}
+
+func newDisplayFilter(opts Options) *displayFilter {
+	// Children are deleted before their parents, so start with the parents of the resources that existed before the
+	// update in order to place deleted resources in the subtree.
+	parents := make(map[resource.URN]resource.URN, len(opts.SubtreeParents))
+	for urn, parent := range opts.SubtreeParents {
+		parents[urn] = parent
+	}
+
+	return &displayFilter{
+		opts:    opts,
+		parents: parents,
+		hidden:  make(map[resource.URN]bool),
+	}
+}
+
+// startDisplayFilter forwards events to the display that is rendering them, except for the events of resources that
+// are hidden by the display options. The root stack, errors, failed steps and policy violations are never hidden. The
+// number of steps that were hidden is recorded in the summary event.
+func startDisplayFilter(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		filter := newDisplayFilter(opts)
+		hiddenSteps := 0
+		for e := range events {
+			show := true
+			switch e.Type {
+			case engine.ResourcePreEvent:
+				step := e.Payload.(engine.ResourcePreEventPayload).Metadata
+				if filter.hideStep(step) {
+					show = false
+					if shouldShow(step, opts) {
+						hiddenSteps++
+					}
+				}
+			case engine.ResourceOutputsEvent:
+				show = !filter.hideStep(e.Payload.(engine.ResourceOutputsEventPayload).Metadata)
+			case engine.DiagEvent:
+				p := e.Payload.(engine.DiagEventPayload)
+				show = p.URN == "" || p.Severity == diag.Error || !filter.hide(p.URN)
+			case engine.SummaryEvent:
+				p := e.Payload.(engine.SummaryEventPayload)
+				p.HiddenSteps = hiddenSteps
+				e.Payload = p
+			}
+
+			if show {
+				outEvents <- e
+			}
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+	}()
+
+	return outEvents, outDone
+}
+
+// hideStep records the parent of a step's resource and returns true if the resource is hidden. Once a resource's
+// parent is known, whether it is hidden is decided for good.
+func (f *displayFilter) hideStep(step engine.StepEventMetadata) bool {
+	if step.Res != nil && step.Res.Parent != "" {
+		f.parents[step.URN] = step.Res.Parent
+	}
+	hidden := f.hide(step.URN)
+	f.hidden[step.URN] = hidden
+	return hidden
+}
+
+// hide returns true if the resource with the given URN is hidden. Resources whose steps have not been seen yet, e.g.
+// those that providers report diagnostics for while checking them, are hidden if their parents are not yet known to
+// be in the subtree, but may be shown once their steps are.
+func (f *displayFilter) hide(urn resource.URN) bool {
+	if isRootURN(urn) {
+		return false
+	}
+	if hidden, ok := f.hidden[urn]; ok {
+		return hidden
+	}
+
+	hidden := !f.inSubtree(urn)
+	if !hidden && len(f.opts.ShowOnly) != 0 {
+		hidden = !matchesAnyFilter(urn, f.opts.ShowOnly)
+	}
+	if !hidden {
+		hidden = matchesAnyFilter(urn, f.opts.Hide)
+	}
+	return hidden
+}
+
+// inSubtree returns true if there is no subtree to show, or if the resource is the root of the subtree or one of its
+// descendants.
+func (f *displayFilter) inSubtree(urn resource.URN) bool {
+	root := f.opts.Subtree
+	if root == "" {
+		return true
+	}
+
+	for urn != "" {
+		if urn == root {
+			return true
+		}
+
+		urn = f.parents[urn]
+	}
+	return false
+}
+
+func matchesAnyFilter(urn resource.URN, filters []ResourceFilter) bool {
+	for _, filter := range filters {
+		if filter.Matches(urn) {
+			return true
+		}
+	}
+	return false
+}
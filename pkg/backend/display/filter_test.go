@@ -0,0 +1,225 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+const (
+	testRoleURN      = resource.URN("urn:pulumi:dev::proj::aws:iam/role:Role::role")
+	testWebURN       = resource.URN("urn:pulumi:dev::proj::my:index:Web::web")
+	testWebBucketURN = resource.URN("urn:pulumi:dev::proj::my:index:Web$aws:s3/bucket:Bucket::web-bucket")
+	testWebPolicyURN = resource.URN(
+		"urn:pulumi:dev::proj::my:index:Web$aws:s3/bucket:Bucket$aws:s3/bucketPolicy:BucketPolicy::web-policy")
+)
+
+func mustParseResourceFilter(t *testing.T, spec string) ResourceFilter {
+	filter, err := ParseResourceFilter(spec)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return filter
+}
+
+func TestParseResourceFilter(t *testing.T) {
+	cases := []struct {
+		spec    string
+		matches []resource.URN
+	}{
+		{"type=aws:iam*", []resource.URN{testRoleURN}},
+		{"type=aws:s3/*", []resource.URN{testBucketURN, testWebBucketURN, testWebPolicyURN}},
+		{"type=aws:s3/bucket:Bucket", []resource.URN{testBucketURN, testWebBucketURN}},
+		{"type~=^my:", []resource.URN{testWebURN}},
+		{"name=web-?olicy", []resource.URN{testWebPolicyURN}},
+		{"urn=*Web*", []resource.URN{testWebURN, testWebBucketURN, testWebPolicyURN}},
+		{"urn~=::my-bucket$", []resource.URN{testBucketURN}},
+	}
+	all := []resource.URN{testStackURN, testBucketURN, testRoleURN, testWebURN, testWebBucketURN, testWebPolicyURN}
+	for _, c := range cases {
+		filter := mustParseResourceFilter(t, c.spec)
+		assert.Equal(t, c.spec, filter.String())
+
+		var matches []resource.URN
+		for _, urn := range all {
+			if filter.Matches(urn) {
+				matches = append(matches, urn)
+			}
+		}
+		assert.Equal(t, c.matches, matches, c.spec)
+	}
+
+	for _, spec := range []string{"aws:iam:*", "kind=aws:iam:*", "urn~=(", ""} {
+		_, err := ParseResourceFilter(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func testFilterStep(urn, parent resource.URN, op deploy.StepOp) engine.StepEventMetadata {
+	state := &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Parent: parent}
+	return engine.StepEventMetadata{Op: op, URN: urn, Type: urn.Type(), Old: state, New: state, Res: state}
+}
+
+func filterEvents(opts Options, events []engine.Event) []engine.Event {
+	in, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startDisplayFilter(in, done, opts)
+
+	var filtered []engine.Event
+	go func() {
+		for e := range out {
+			filtered = append(filtered, e)
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+		close(outDone)
+	}()
+
+	for _, e := range events {
+		in <- e
+	}
+	in <- engine.Event{Type: engine.CancelEvent}
+	<-done
+	return filtered
+}
+
+func filteredURNs(events []engine.Event) []resource.URN {
+	var urns []resource.URN
+	for _, e := range events {
+		if e.Type == engine.ResourcePreEvent {
+			urns = append(urns, e.Payload.(engine.ResourcePreEventPayload).Metadata.URN)
+		}
+	}
+	return urns
+}
+
+func TestDisplayFilter(t *testing.T) {
+	steps := []engine.StepEventMetadata{
+		testFilterStep(testStackURN, "", deploy.OpSame),
+		testFilterStep(testRoleURN, testStackURN, deploy.OpCreate),
+		testFilterStep(testWebURN, testStackURN, deploy.OpCreate),
+		testFilterStep(testWebBucketURN, testWebURN, deploy.OpCreate),
+		testFilterStep(testWebPolicyURN, testWebBucketURN, deploy.OpUpdate),
+		testFilterStep(testBucketURN, testStackURN, deploy.OpSame),
+	}
+	var events []engine.Event
+	for _, step := range steps {
+		events = append(events, engine.Event{
+			Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: step},
+		})
+	}
+	events = append(events,
+		engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testRoleURN, Message: "role is deprecated", Severity: diag.Warning,
+		}},
+		engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testRoleURN, Message: "role failed", Severity: diag.Error,
+		}},
+		engine.Event{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{}})
+
+	// Without filters, all events are shown.
+	filtered := filterEvents(Options{}, events)
+	assert.Equal(t, []resource.URN{testStackURN, testRoleURN, testWebURN, testWebBucketURN, testWebPolicyURN,
+		testBucketURN}, filteredURNs(filtered))
+	assert.Len(t, filtered, len(events)+1)
+
+	// Only the subtree is shown, along with the stack and errors. Hidden steps that would not have been shown anyway
+	// are not counted.
+	filtered = filterEvents(Options{Subtree: testWebURN}, events)
+	assert.Equal(t, []resource.URN{testStackURN, testWebURN, testWebBucketURN, testWebPolicyURN},
+		filteredURNs(filtered))
+	if assert.Len(t, filtered, 7) {
+		assert.Equal(t, "role failed", filtered[4].Payload.(engine.DiagEventPayload).Message)
+		assert.Equal(t, 1, filtered[5].Payload.(engine.SummaryEventPayload).HiddenSteps)
+	}
+
+	// Filters are combined with the subtree.
+	filtered = filterEvents(Options{
+		Subtree:  testWebURN,
+		ShowOnly: []ResourceFilter{mustParseResourceFilter(t, "type=aws:s3/*")},
+		Hide:     []ResourceFilter{mustParseResourceFilter(t, "name=*-policy")},
+	}, events)
+	assert.Equal(t, []resource.URN{testStackURN, testWebBucketURN}, filteredURNs(filtered))
+
+	filtered = filterEvents(Options{Hide: []ResourceFilter{mustParseResourceFilter(t, "type~=^aws:iam")}}, events)
+	assert.Equal(t, []resource.URN{testStackURN, testWebURN, testWebBucketURN, testWebPolicyURN, testBucketURN},
+		filteredURNs(filtered))
+	assert.Equal(t, 1, filtered[len(filtered)-2].Payload.(engine.SummaryEventPayload).HiddenSteps)
+}
+
+func TestDisplayFilterSubtreeDeletes(t *testing.T) {
+	// When resources are deleted, children are deleted before their parents. Another component of the same type has
+	// children of the same types, which are not in the subtree.
+	otherWebURN := resource.URN("urn:pulumi:dev::proj::my:index:Web::other")
+	otherWebBucketURN := resource.URN("urn:pulumi:dev::proj::my:index:Web$aws:s3/bucket:Bucket::other-bucket")
+	otherWebPolicyURN := resource.URN(
+		"urn:pulumi:dev::proj::my:index:Web$aws:s3/bucket:Bucket$aws:s3/bucketPolicy:BucketPolicy::other-policy")
+	var events []engine.Event
+	for _, step := range []engine.StepEventMetadata{
+		testFilterStep(testWebPolicyURN, testWebBucketURN, deploy.OpDelete),
+		testFilterStep(otherWebPolicyURN, otherWebBucketURN, deploy.OpDelete),
+		testFilterStep(otherWebBucketURN, otherWebURN, deploy.OpDelete),
+		testFilterStep(testWebBucketURN, testWebURN, deploy.OpDelete),
+		testFilterStep(otherWebURN, testStackURN, deploy.OpDelete),
+		testFilterStep(testWebURN, testStackURN, deploy.OpDelete),
+	} {
+		events = append(events, engine.Event{
+			Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: step},
+		})
+	}
+
+	// The parents of the resources before the update place the resources in the subtree.
+	parents := map[resource.URN]resource.URN{
+		testWebURN:        testStackURN,
+		testWebBucketURN:  testWebURN,
+		testWebPolicyURN:  testWebBucketURN,
+		otherWebURN:       testStackURN,
+		otherWebBucketURN: otherWebURN,
+		otherWebPolicyURN: otherWebBucketURN,
+	}
+	filtered := filterEvents(Options{Subtree: testWebURN, SubtreeParents: parents}, events)
+	assert.Equal(t, []resource.URN{testWebPolicyURN, testWebBucketURN, testWebURN}, filteredURNs(filtered))
+}
+
+func TestDisplayFilterSubtreeDiagBeforeStep(t *testing.T) {
+	// Providers may report diagnostics for a new resource while checking it, before its step is seen.
+	events := []engine.Event{
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{
+			Metadata: testFilterStep(testWebURN, testStackURN, deploy.OpCreate),
+		}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testWebBucketURN, Message: "bucket is deprecated", Severity: diag.Warning,
+		}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{
+			Metadata: testFilterStep(testWebBucketURN, testWebURN, deploy.OpCreate),
+		}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testWebBucketURN, Message: "bucket is still deprecated", Severity: diag.Warning,
+		}},
+	}
+
+	filtered := filterEvents(Options{Subtree: testWebURN}, events)
+	assert.Equal(t, []resource.URN{testWebURN, testWebBucketURN}, filteredURNs(filtered))
+	if assert.Len(t, filtered, 4) {
+		assert.Equal(t, "bucket is still deprecated", filtered[2].Payload.(engine.DiagEventPayload).Message)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 )
@@ -185,7 +186,15 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 			p := e.Payload.(engine.SummaryEventPayload)
 			digest.Duration = p.Duration
 			digest.ChangeSummary = p.ResourceChanges
+			if opts.GroupedSummary {
+				digest.GroupedChangeSummary = &groupedChangeSummary{
+					Types:      p.ChangeGroups.Types,
+					Modules:    p.ChangeGroups.Modules,
+					Components: p.ChangeGroups.Components,
+				}
+			}
 			digest.MaybeCorrupt = p.MaybeCorrupt
+			digest.HiddenSteps = p.HiddenSteps
 		default:
 			contract.Failf("unknown event type '%s'", e.Type)
 		}
@@ -212,8 +221,22 @@ type previewDigest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	// ChangeSummary contains a map of count per operation (create, update, etc).
 	ChangeSummary engine.ResourceChanges `json:"changeSummary,omitempty"`
+	// GroupedChangeSummary contains the counts per operation grouped by resource type, module and component.
+	GroupedChangeSummary *groupedChangeSummary `json:"groupedChangeSummary,omitempty"`
 	// MaybeCorrupt indicates whether one or more resources may be corrupt.
 	MaybeCorrupt bool `json:"maybeCorrupt,omitempty"`
+	// HiddenSteps is the number of steps omitted from Steps by the display's filters.
+	HiddenSteps int `json:"hiddenSteps,omitempty"`
+}
+
+// groupedChangeSummary contains maps of count per operation for each resource type, module and top-level component.
+type groupedChangeSummary struct {
+	// Types contains the changes to the resources of each type.
+	Types map[tokens.Type]engine.ResourceChanges `json:"types"`
+	// Modules contains the changes to the resources of each module, e.g. "aws:ec2".
+	Modules map[tokens.Module]engine.ResourceChanges `json:"modules"`
+	// Components contains the changes to the resources that are children of each top-level component.
+	Components map[resource.URN]engine.ResourceChanges `json:"components"`
 }
 
 // propertyDiff contains information about the difference in a single property value.
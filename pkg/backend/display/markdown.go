@@ -0,0 +1,414 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// ShowMarkdownEvents renders engine events into a Markdown document that is suitable for e.g. pull request comments.
+// Like ShowJSONEvents, this does not emit events incrementally: the document is written to stdout once the event
+// stream is closed or canceled.
+func ShowMarkdownEvents(op string, action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool,
+	opts Options) {
+
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	report := newMarkdownReport(op, action, opts)
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			break
+		}
+		report.add(e)
+	}
+	report.write(os.Stdout)
+}
+
+// startMarkdownReport forwards events to the display that is rendering them while accumulating a Markdown report of
+// the operation, which is written to the given path once the display is done.
+func startMarkdownReport(op string, action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool,
+	path string, opts Options) (<-chan engine.Event, chan<- bool) {
+
+	reportOpts := opts
+	reportOpts.Type, reportOpts.JSONDisplay = DisplayMarkdown, false
+	report := newMarkdownReport(op, action, reportOpts)
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			if e.Type != engine.CancelEvent {
+				report.add(e)
+			}
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+
+		if err := report.writeFile(path); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "could not write report to %s: %v"), path, err)
+		}
+	}()
+
+	return outEvents, outDone
+}
+
+// markdownStep is a resource step that is included in a Markdown report.
+type markdownStep struct {
+	Metadata engine.StepEventMetadata
+	Planning bool
+	Debug    bool
+}
+
+// markdownReport accumulates the events of an operation and renders them as Markdown.
+type markdownReport struct {
+	op     string
+	action apitype.UpdateKind
+	opts   Options
+
+	summary          *engine.SummaryEventPayload
+	config           map[string]string
+	steps            []markdownStep
+	stackOutputs     *markdownStep
+	diagnostics      []engine.DiagEventPayload
+	policyViolations []engine.PolicyViolationEventPayload
+}
+
+func newMarkdownReport(op string, action apitype.UpdateKind, opts Options) *markdownReport {
+	// Markdown is never colorized.
+	opts.Color = colors.Never
+	return &markdownReport{op: op, action: action, opts: opts}
+}
+
+// add records the parts of an event that are included in the report.
+func (r *markdownReport) add(e engine.Event) {
+	switch e.Type {
+	case engine.PreludeEvent:
+		r.config = e.Payload.(engine.PreludeEventPayload).Config
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		r.summary = &p
+	case engine.StdoutColorEvent:
+		p := e.Payload.(engine.StdoutEventPayload)
+		r.diagnostics = append(r.diagnostics, engine.DiagEventPayload{Message: p.Message, Severity: diag.Info})
+	case engine.DiagEvent:
+		// Skip any ephemeral messages, and debug messages unless they were requested.
+		p := e.Payload.(engine.DiagEventPayload)
+		if !p.Ephemeral && (p.Severity != diag.Debug || r.opts.Debug) {
+			r.diagnostics = append(r.diagnostics, p)
+		}
+	case engine.PolicyViolationEvent:
+		r.policyViolations = append(r.policyViolations, e.Payload.(engine.PolicyViolationEventPayload))
+	case engine.ResourcePreEvent:
+		// Imports are rendered once their outputs are known. Refreshes do not change resources and are omitted.
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		if op := p.Metadata.Op; op != deploy.OpRefresh && op != deploy.OpImport && shouldShow(p.Metadata, r.opts) {
+			step := markdownStep{Metadata: maskSecrets(p.Metadata), Planning: p.Planning, Debug: p.Debug}
+			r.steps = append(r.steps, step)
+		}
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		step := markdownStep{Metadata: maskSecrets(p.Metadata), Planning: p.Planning, Debug: p.Debug}
+		if p.Metadata.Op == deploy.OpImport && shouldShow(p.Metadata, r.opts) {
+			r.steps = append(r.steps, step)
+		}
+		if isRootStack(p.Metadata) {
+			r.stackOutputs = &step
+		}
+	case engine.ResourceOperationFailed:
+		// Failures are reported by the diagnostics that accompany them.
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+}
+
+// maskSecrets replaces the secret values in a step's inputs and outputs with "[secret]".
+func maskSecrets(m engine.StepEventMetadata) engine.StepEventMetadata {
+	mask := func(md *engine.StepEventStateMetadata) *engine.StepEventStateMetadata {
+		if md == nil {
+			return nil
+		}
+		masked := *md
+		masked.Inputs = MassageSecrets(md.Inputs, false)
+		masked.Outputs = MassageSecrets(md.Outputs, false)
+		return &masked
+	}
+	m.Old, m.New, m.Res = mask(m.Old), mask(m.New), mask(m.Res)
+	return m
+}
+
+// writeFile writes the report to the file at the given path.
+func (r *markdownReport) writeFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	r.write(f)
+	return f.Close()
+}
+
+// write renders the report to the given writer.
+func (r *markdownReport) write(w io.Writer) {
+	out := &bytes.Buffer{}
+	fprintfIgnoreError(out, "### %s\n", r.op)
+
+	r.writeConfig(out)
+	r.writeSummary(out)
+	r.writeSteps(out)
+	r.writePolicyViolations(out)
+	r.writeDiagnostics(out)
+	r.writeOutputs(out)
+
+	_, err := w.Write(out.Bytes())
+	contract.IgnoreError(err)
+}
+
+func (r *markdownReport) isPreview() bool {
+	return r.summary != nil && r.summary.IsPreview
+}
+
+func (r *markdownReport) writeConfig(out io.Writer) {
+	if !r.opts.ShowConfig || len(r.config) == 0 {
+		return
+	}
+
+	var keys []string
+	for key := range r.config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fprintIgnoreError(out, "\n#### Configuration\n\n| Key | Value |\n| --- | --- |\n")
+	for _, key := range keys {
+		fprintfIgnoreError(out, "| %s | %s |\n", markdownTableCell(key), markdownTableCell(r.config[key]))
+	}
+}
+
+// writeSummary renders the summary event's resource changes as a table with one row per operation.
+func (r *markdownReport) writeSummary(out io.Writer) {
+	if r.summary == nil {
+		return
+	}
+
+	fprintIgnoreError(out, "\n#### Resources\n\n")
+
+	var rows []string
+	changeCount := 0
+	for _, op := range deploy.StepOps {
+		// As with the other displays, reads are not changes, and sames are counted separately.
+		if op == deploy.OpSame || op == deploy.OpRead || op == deploy.OpReadDiscard || op == deploy.OpReadReplacement {
+			continue
+		}
+		if c := r.summary.ResourceChanges[op]; c > 0 {
+			opDescription := string(op)
+			if r.isPreview() {
+				opDescription = "to " + opDescription
+			} else {
+				opDescription = op.PastTense()
+			}
+			rows = append(rows, fmt.Sprintf("| `%s` %s | %d |\n", strings.TrimSpace(op.RawPrefix()), opDescription, c))
+			changeCount += c
+		}
+	}
+	if c := r.summary.ResourceChanges[deploy.OpSame]; c > 0 {
+		rows = append(rows, fmt.Sprintf("| unchanged | %d |\n", c))
+	}
+
+	if len(rows) == 0 {
+		fprintIgnoreError(out, "No resources.\n")
+	} else {
+		fprintIgnoreError(out, "| Operation | Count |\n| --- | ---: |\n")
+		for _, row := range rows {
+			fprintIgnoreError(out, row)
+		}
+		fprintfIgnoreError(out, "\n**%d %s**\n", changeCount, english.PluralWord(changeCount, "change", ""))
+	}
+
+	if len(r.summary.PolicyPacks) != 0 {
+		var packs []string
+		for pp := range r.summary.PolicyPacks {
+			packs = append(packs, pp)
+		}
+		sort.Strings(packs)
+
+		fprintIgnoreError(out, "\n| Policy Pack | Version |\n| --- | --- |\n")
+		for _, pp := range packs {
+			fprintfIgnoreError(out, "| %s | %s |\n",
+				markdownTableCell(pp), markdownTableCell(r.summary.PolicyPacks[pp]))
+		}
+	}
+
+	if !r.isPreview() {
+		// Round up to the nearest second, as the other displays do.
+		roundedSeconds := int64(math.Ceil(r.summary.Duration.Seconds()))
+		fprintfIgnoreError(out, "\nDuration: %s\n", time.Duration(roundedSeconds)*time.Second)
+	}
+}
+
+// writeSteps renders each resource step as a collapsible section that contains the step's detailed diff.
+func (r *markdownReport) writeSteps(out io.Writer) {
+	if len(r.steps) == 0 {
+		return
+	}
+
+	fprintIgnoreError(out, "\n#### Changes\n")
+	for _, step := range r.steps {
+		m := step.Metadata
+
+		// Each step is rendered on its own, so it is not indented beneath its parent.
+		var diff bytes.Buffer
+		renderDiff(&diff, m, step.Planning, step.Debug, map[resource.URN]engine.StepEventMetadata{}, r.opts)
+
+		fprintfIgnoreError(out, "\n<details>\n<summary><code>%s</code> <code>%s</code> <b>%s</b></summary>\n\n",
+			html.EscapeString(strings.TrimSpace(m.Op.RawPrefix())+" "+string(m.Op)),
+			html.EscapeString(string(m.Type)), html.EscapeString(string(m.URN.Name())))
+		writeMarkdownCodeBlock(out, "diff", markdownDiff(diff.String()))
+		fprintIgnoreError(out, "\n</details>\n")
+	}
+}
+
+func (r *markdownReport) writePolicyViolations(out io.Writer) {
+	if len(r.policyViolations) == 0 {
+		return
+	}
+
+	fprintIgnoreError(out, "\n#### Policy Violations\n\n")
+	for _, v := range r.policyViolations {
+		resourceName := ""
+		if v.ResourceURN != "" {
+			resourceName = fmt.Sprintf(" (%s)", markdownTableCell(string(v.ResourceURN.Name())))
+		}
+		fprintfIgnoreError(out, "- **[%s]** %s v%s `%s`%s\n", v.EnforcementLevel,
+			markdownTableCell(v.PolicyPackName), markdownTableCell(v.PolicyPackVersion), v.PolicyName, resourceName)
+
+		// The message may span multiple lines, so we indent it beneath the list item.
+		message := strings.TrimSpace(colors.Never.Colorize(v.Message))
+		fprintfIgnoreError(out, "\n  %s\n", strings.ReplaceAll(message, "\n", "\n  "))
+	}
+}
+
+// writeDiagnostics renders the diagnostics grouped by the resource they pertain to.
+func (r *markdownReport) writeDiagnostics(out io.Writer) {
+	if len(r.diagnostics) == 0 {
+		return
+	}
+
+	var urns []resource.URN
+	messages := map[resource.URN]*bytes.Buffer{}
+	for _, d := range r.diagnostics {
+		buf, has := messages[d.URN]
+		if !has {
+			buf = &bytes.Buffer{}
+			messages[d.URN] = buf
+			urns = append(urns, d.URN)
+		}
+
+		msg := colors.Never.Colorize(d.Prefix + d.Message)
+		if d.Severity != diag.Info && d.Severity != diag.Infoerr && d.Prefix == "" {
+			msg = string(d.Severity) + ": " + msg
+		}
+		fprintIgnoreError(buf, msg)
+	}
+
+	fprintIgnoreError(out, "\n#### Diagnostics\n")
+	for _, urn := range urns {
+		heading := "Global"
+		if urn != "" {
+			heading = fmt.Sprintf("%s (%s)", urn.Type(), urn.Name())
+		}
+		fprintfIgnoreError(out, "\n**%s**\n\n", markdownTableCell(heading))
+		writeMarkdownCodeBlock(out, "", messages[urn].String())
+	}
+}
+
+func (r *markdownReport) writeOutputs(out io.Writer) {
+	if r.opts.SuppressOutputs || r.stackOutputs == nil {
+		return
+	}
+
+	text := engine.GetResourceOutputsPropertiesString(r.stackOutputs.Metadata, 1, r.stackOutputs.Planning,
+		r.stackOutputs.Debug, false /* refresh */, r.opts.ShowSameResources, !r.opts.RawStringDiffs)
+	if text == "" {
+		return
+	}
+
+	fprintIgnoreError(out, "\n#### Outputs\n\n")
+	writeMarkdownCodeBlock(out, "diff", markdownDiff(r.opts.Color.Colorize(text)))
+}
+
+// markdownDiff moves the operation markers at the start of each line of a rendered diff to the first column, where
+// Markdown's "diff" syntax highlighting expects them. Updates are marked with "!", which is highlighted as a change.
+func markdownDiff(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		body := strings.TrimLeft(line, " ")
+		if len(body) < 2 || !strings.ContainsAny(body[:1], "+-~") || !strings.ContainsAny(body[1:2], " +-~") {
+			lines[i] = " " + line
+			continue
+		}
+
+		marker := body[:1]
+		if marker == "~" {
+			marker = "!"
+		}
+		lines[i] = marker + line[:len(line)-len(body)] + " " + body[1:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// writeMarkdownCodeBlock writes text to a fenced code block, using a fence that cannot occur within the text.
+func writeMarkdownCodeBlock(out io.Writer, lang, text string) {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	fprintfIgnoreError(out, "%s%s\n%s%s\n", fence, lang, text, fence)
+}
+
+// markdownTableCell escapes text so that it can be used as inline text, including within a table cell.
+func markdownTableCell(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,200 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+const (
+	testStackURN  = resource.URN("urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev")
+	testBucketURN = resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::my-bucket")
+)
+
+func testStepState(urn resource.URN, inputs, outputs resource.PropertyMap) *engine.StepEventStateMetadata {
+	parent := testStackURN
+	if urn == testStackURN {
+		parent = ""
+	}
+	return &engine.StepEventStateMetadata{
+		Type:    urn.Type(),
+		URN:     urn,
+		Custom:  urn != testStackURN,
+		Parent:  parent,
+		Inputs:  inputs,
+		Outputs: outputs,
+	}
+}
+
+// testPreviewEvents returns the events of a preview that updates a bucket. The engine replaces secret values with
+// "[secret]" before it emits events; if rawSecrets is true, the events contain secret values instead.
+func testPreviewEvents(rawSecrets bool) []engine.Event {
+	secret := func(s string) resource.PropertyValue {
+		if rawSecrets {
+			return resource.MakeSecret(resource.NewStringProperty(s))
+		}
+		return resource.NewStringProperty("[secret]")
+	}
+
+	oldStack := testStepState(testStackURN, resource.PropertyMap{}, resource.PropertyMap{})
+	newStack := testStepState(testStackURN, resource.PropertyMap{}, resource.PropertyMap{
+		"bucketName": resource.NewStringProperty("my-bucket-1234"),
+		"password":   secret("hunter2"),
+	})
+	oldBucket := testStepState(testBucketURN, resource.PropertyMap{
+		"acl":    resource.NewStringProperty("private"),
+		"secret": secret("old-secret"),
+	}, nil)
+	newBucket := testStepState(testBucketURN, resource.PropertyMap{
+		"acl":    resource.NewStringProperty("public-read"),
+		"secret": secret("new-secret"),
+	}, nil)
+
+	stackStep := engine.StepEventMetadata{
+		Op: deploy.OpSame, URN: testStackURN, Type: testStackURN.Type(),
+		Old: oldStack, New: newStack, Res: newStack,
+	}
+	bucketStep := engine.StepEventMetadata{
+		Op: deploy.OpUpdate, URN: testBucketURN, Type: testBucketURN.Type(),
+		Old: oldBucket, New: newBucket, Res: newBucket,
+		Diffs: []resource.PropertyKey{"acl", "secret"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"acl":    {Kind: plugin.DiffUpdate, InputDiff: true},
+			"secret": {Kind: plugin.DiffUpdate, InputDiff: true},
+		},
+		Logical: true,
+	}
+
+	return []engine.Event{
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{IsPreview: true}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: stackStep, Planning: true}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: bucketStep, Planning: true}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testBucketURN, Message: "bucket | acl is deprecated\n", Severity: diag.Warning,
+		}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: testBucketURN, Message: "spinner", Severity: diag.Info, Ephemeral: true,
+		}},
+		{Type: engine.PolicyViolationEvent, Payload: engine.PolicyViolationEventPayload{
+			ResourceURN: testBucketURN, Message: "Buckets must\nnot be public.", PolicyName: "no-public-buckets",
+			PolicyPackName: "security", PolicyPackVersion: "1.0.0", EnforcementLevel: apitype.Mandatory,
+		}},
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{
+			Metadata: stackStep, Planning: true,
+		}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			IsPreview:       true,
+			ResourceChanges: engine.ResourceChanges{deploy.OpUpdate: 1, deploy.OpSame: 1},
+			PolicyPacks:     map[string]string{"security": "1.0.0"},
+		}},
+	}
+}
+
+func TestMarkdownReport(t *testing.T) {
+	report := newMarkdownReport("Previewing update", apitype.PreviewUpdate, Options{Type: DisplayMarkdown})
+	for _, e := range testPreviewEvents(true) {
+		report.add(e)
+	}
+
+	var out bytes.Buffer
+	report.write(&out)
+	md := out.String()
+
+	assert.Contains(t, md, "### Previewing update\n")
+
+	// The summary table.
+	assert.Contains(t, md, "| Operation | Count |\n| --- | ---: |\n| `~` to update | 1 |\n| unchanged | 1 |\n")
+	assert.Contains(t, md, "**1 change**")
+	assert.Contains(t, md, "| security | 1.0.0 |")
+	assert.NotContains(t, md, "Duration:")
+
+	// The collapsible diff of the bucket. The stack is unchanged and so is not shown.
+	assert.Contains(t, md, "<summary><code>~ update</code> <code>aws:s3/bucket:Bucket</code> <b>my-bucket</b>")
+	assert.Contains(t, md, "```diff\n")
+	assert.Contains(t, md, "\n!    acl   : \"private\" => \"public-read\"\n")
+	assert.NotContains(t, md, "<b>proj-dev</b>")
+
+	// Policy violations and diagnostics.
+	assert.Contains(t, md, "- **[mandatory]** security v1.0.0 `no-public-buckets` (my-bucket)\n\n"+
+		"  Buckets must\n  not be public.\n")
+	assert.Contains(t, md, "**aws:s3/bucket:Bucket (my-bucket)**\n\n```\nwarning: bucket | acl is deprecated\n```\n")
+	assert.NotContains(t, md, "spinner")
+
+	// The stack's outputs, with secrets masked.
+	assert.Contains(t, md, "#### Outputs")
+	assert.Contains(t, md, "my-bucket-1234")
+	assert.NotContains(t, md, "hunter2")
+	assert.NotContains(t, md, "old-secret")
+	assert.NotContains(t, md, "new-secret")
+
+	// Outputs may be suppressed.
+	report.opts.SuppressOutputs = true
+	out.Reset()
+	report.write(&out)
+	assert.NotContains(t, out.String(), "#### Outputs")
+}
+
+func TestMarkdownReportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-markdown-report")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.md")
+
+	// The report is written alongside the regular display.
+	events, done := make(chan engine.Event), make(chan bool)
+	opts := Options{Color: colors.Never, Type: DisplayDiff, ReportPath: path}
+	go ShowEvents("Previewing update", apitype.PreviewUpdate, tokens.QName("dev"), tokens.PackageName("proj"),
+		events, done, opts, true)
+	for _, e := range testPreviewEvents(false) {
+		events <- e
+	}
+	events <- engine.Event{Type: engine.CancelEvent}
+	<-done
+
+	b, err := ioutil.ReadFile(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, string(b), "### Previewing update\n")
+	assert.Contains(t, string(b), "<b>my-bucket</b>")
+}
+
+func TestMarkdownDiff(t *testing.T) {
+	assert.Equal(t, "+  a\n   b\n!      c: 1 => 2\n+   -d\n",
+		markdownDiff("+ a\n  b\n    ~ c: 1 => 2\n  +-d\n"))
+}
+
+func TestWriteMarkdownCodeBlock(t *testing.T) {
+	var out bytes.Buffer
+	writeMarkdownCodeBlock(&out, "", "a ``` b")
+	assert.Equal(t, "````\na ``` b\n````\n", out.String())
+}
@@ -14,7 +14,10 @@
 
 package display
 
-import "github.com/pulumi/pulumi/pkg/diag/colors"
+import (
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
 
 // Type of output to display.
 type Type int
@@ -28,6 +31,18 @@ const (
 	DisplayQuery
 	// DisplayQuery displays query output.
 	DisplayWatch
+	// DisplayMarkdown displays a Markdown report, e.g. for use in pull request comments.
+	DisplayMarkdown
+)
+
+// PolicyReportFormat is the format of a policy violation report.
+type PolicyReportFormat string
+
+const (
+	// PolicyReportJUnit reports each policy violation as a failed JUnit test case.
+	PolicyReportJUnit PolicyReportFormat = "junit"
+	// PolicyReportSARIF reports each policy violation as a SARIF result.
+	PolicyReportSARIF PolicyReportFormat = "sarif"
 )
 
 // Options controls how the output of events are rendered
@@ -37,11 +52,25 @@ type Options struct {
 	ShowReplacementSteps bool                // true to show the replacement steps in the plan.
 	ShowSameResources    bool                // true to show the resources that aren't updated in addition to updates.
 	ShowReads            bool                // true to show resources that are being read in
+	ShowOnly             []ResourceFilter    // if non-empty, only show the resources that match one of these filters.
+	Hide                 []ResourceFilter    // hide the resources that match any of these filters.
+	Subtree              resource.URN        // if non-empty, only show this resource and its descendants.
 	SuppressOutputs      bool                // true to suppress output summarization, e.g. if contains sensitive info.
 	SummaryDiff          bool                // true if diff display should be summarized.
+	GroupedSummary       bool                // true to summarize changes by resource type, module and component.
+	RawStringDiffs       bool                // true to diff JSON and YAML strings as text rather than as documents.
 	IsInteractive        bool                // true if we should display things interactively.
 	Type                 Type                // type of display (rich diff, progress, or query).
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
+	ReportPath           string              // the path to the file to write a Markdown report to, if any.
+	PolicyReportPath     string              // the path to the file to write a policy violation report to, if any.
+	PolicyReportFormat   PolicyReportFormat  // the format of the policy violation report.
+	ProjectFileSource    string              // the project file to locate violations and annotations in, if any.
+	StatusAddr           string              // the address at which to serve the status of the update, if any.
 	Debug                bool                // true to enable debug output.
+
+	// SubtreeParents are the parents of the stack's resources before the update. They place the resources that are
+	// deleted before their parents in the subtree.
+	SubtreeParents map[resource.URN]resource.URN
 }
@@ -0,0 +1,365 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+// startPolicyReport forwards events to the display that is rendering them while collecting the policy violations they
+// report, which are written to the given path in the given format once the display is done. Formats that locate
+// violations in source files locate them in the given project file.
+func startPolicyReport(events <-chan engine.Event, done chan<- bool, path string,
+	format PolicyReportFormat, source string) (<-chan engine.Event, chan<- bool) {
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		var violations []engine.PolicyViolationEventPayload
+		for e := range events {
+			if e.Type == engine.PolicyViolationEvent {
+				violations = append(violations, e.Payload.(engine.PolicyViolationEventPayload))
+			}
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+
+		if err := writePolicyReportFile(path, format, source, violations); err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "could not write policy report to %s: %v"), path, err)
+		}
+	}()
+
+	return outEvents, outDone
+}
+
+// writePolicyReportFile writes a report of the given policy violations to the file at the given path.
+func writePolicyReportFile(path string, format PolicyReportFormat, source string,
+	violations []engine.PolicyViolationEventPayload) error {
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = writePolicyReport(f, format, source, violations); err != nil {
+		contract.IgnoreClose(f)
+		return err
+	}
+	return f.Close()
+}
+
+// writePolicyReport writes a report of the given policy violations in the given format.
+func writePolicyReport(w io.Writer, format PolicyReportFormat, source string,
+	violations []engine.PolicyViolationEventPayload) error {
+
+	switch format {
+	case PolicyReportJUnit, "":
+		return writeJUnitPolicyReport(w, violations)
+	case PolicyReportSARIF:
+		return writeSARIFPolicyReport(w, source, violations)
+	default:
+		return errors.Errorf("unknown policy report format %q", format)
+	}
+}
+
+// policyViolationMessage returns the uncolorized message of a policy violation.
+func policyViolationMessage(v engine.PolicyViolationEventPayload) string {
+	return strings.TrimSpace(colors.Never.Colorize(v.Message))
+}
+
+// The following types are the subset of the JUnit XML format that is understood by common CI systems.
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitPolicyReport reports each policy violation as a failed test case. Test cases are grouped into a test
+// suite per policy pack, and the violation's enforcement level is recorded as the failure's type.
+func writeJUnitPolicyReport(w io.Writer, violations []engine.PolicyViolationEventPayload) error {
+	suites := map[string]*junitTestSuite{}
+	for _, v := range violations {
+		name := fmt.Sprintf("%s@v%s", v.PolicyPackName, v.PolicyPackVersion)
+		suite, ok := suites[name]
+		if !ok {
+			suite = &junitTestSuite{Name: name}
+			suites[name] = suite
+		}
+
+		message := policyViolationMessage(v)
+		text := message
+		if v.Description != "" {
+			text = v.Description + "\n\n" + text
+		}
+		if v.ResourceURN != "" {
+			text += "\n\nResource: " + string(v.ResourceURN)
+		}
+
+		suite.Tests++
+		suite.Failures++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      policyViolationTestName(v),
+			ClassName: v.PolicyPackName + "." + v.PolicyName,
+			Properties: []junitProperty{
+				{Name: "policyPack", Value: v.PolicyPackName},
+				{Name: "policyPackVersion", Value: v.PolicyPackVersion},
+				{Name: "policyName", Value: v.PolicyName},
+				{Name: "enforcementLevel", Value: string(v.EnforcementLevel)},
+				{Name: "resourceURN", Value: string(v.ResourceURN)},
+			},
+			Failure: &junitFailure{Message: message, Type: string(v.EnforcementLevel), Text: text},
+		})
+	}
+
+	var names []string
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := junitTestSuites{Name: "Pulumi policy violations"}
+	for _, name := range names {
+		suite := suites[name]
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// policyViolationTestName returns the name of the test case for a policy violation.
+func policyViolationTestName(v engine.PolicyViolationEventPayload) string {
+	if v.ResourceURN == "" {
+		// Stack policies are not associated with a resource.
+		return v.PolicyName
+	}
+	return fmt.Sprintf("%s (%s)", v.PolicyName, v.ResourceURN)
+}
+
+// The following types are the subset of the SARIF 2.1.0 format that is necessary to describe policy violations. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html for the full definitions.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	ShortDescription *sarifMessage          `json:"shortDescription,omitempty"`
+	Properties       map[string]interface{} `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// writeSARIFPolicyReport reports each policy violation as a SARIF result. Each policy that was violated is a rule, and
+// each violation's resource is a logical location. Consumers such as GitHub code scanning require every result to have
+// a physical location as well, so each result is also located in the given project file, which defaults to
+// Pulumi.yaml.
+func writeSARIFPolicyReport(w io.Writer, source string, violations []engine.PolicyViolationEventPayload) error {
+	if source == "" {
+		source = "Pulumi.yaml"
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "Pulumi",
+			Version:        version.Version,
+			InformationURI: "https://www.pulumi.com/docs/guides/crossguard/",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndices := map[string]int{}
+	for _, v := range violations {
+		id := v.PolicyPackName + "/" + v.PolicyName
+		index, ok := ruleIndices[id]
+		if !ok {
+			rule := sarifRule{
+				ID:   id,
+				Name: v.PolicyName,
+				Properties: map[string]interface{}{
+					"policyPack":        v.PolicyPackName,
+					"policyPackVersion": v.PolicyPackVersion,
+				},
+			}
+			if v.Description != "" {
+				rule.ShortDescription = &sarifMessage{Text: v.Description}
+			}
+			if len(v.Tags) != 0 {
+				rule.Properties["tags"] = v.Tags
+			}
+
+			index = len(run.Tool.Driver.Rules)
+			ruleIndices[id] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+
+		level := "warning"
+		if v.EnforcementLevel == apitype.Mandatory {
+			level = "error"
+		}
+
+		result := sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     level,
+			Message:   sarifMessage{Text: policyViolationMessage(v)},
+			Properties: map[string]interface{}{
+				"policyPack":        v.PolicyPackName,
+				"policyPackVersion": v.PolicyPackVersion,
+				"policyName":        v.PolicyName,
+				"enforcementLevel":  string(v.EnforcementLevel),
+			},
+		}
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: source, URIBaseID: "%SRCROOT%"},
+			Region:           sarifRegion{StartLine: 1},
+		}}
+		if v.ResourceURN != "" {
+			result.Properties["resourceURN"] = string(v.ResourceURN)
+			location.LogicalLocations = []sarifLogicalLocation{{
+				Name:               string(v.ResourceURN.Name()),
+				FullyQualifiedName: string(v.ResourceURN),
+				Kind:               "resource",
+			}}
+		}
+		result.Locations = []sarifLocation{location}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
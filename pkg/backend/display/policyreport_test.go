@@ -0,0 +1,183 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func testPolicyViolations() []engine.PolicyViolationEventPayload {
+	return []engine.PolicyViolationEventPayload{
+		{
+			ResourceURN:       testBucketURN,
+			Message:           "<{%fg 13%}>Buckets must not be public.<{%reset%}>\n",
+			PolicyName:        "no-public-buckets",
+			PolicyPackName:    "security",
+			PolicyPackVersion: "1.0.0",
+			EnforcementLevel:  apitype.Mandatory,
+			Description:       "Prohibits public buckets.",
+			Tags:              []string{"s3"},
+		},
+		{
+			Message:           "The stack has too many resources & should be split.",
+			PolicyName:        "max-resources",
+			PolicyPackName:    "cost",
+			PolicyPackVersion: "0.1.0",
+			EnforcementLevel:  apitype.Advisory,
+		},
+	}
+}
+
+func TestJUnitPolicyReport(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, writePolicyReport(&out, PolicyReportJUnit, "", testPolicyViolations()))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Pulumi policy violations" tests="2" failures="2">
+  <testsuite name="cost@v0.1.0" tests="1" failures="1">
+    <testcase name="max-resources" classname="cost.max-resources">
+      <properties>
+        <property name="policyPack" value="cost"></property>
+        <property name="policyPackVersion" value="0.1.0"></property>
+        <property name="policyName" value="max-resources"></property>
+        <property name="enforcementLevel" value="advisory"></property>
+        <property name="resourceURN" value=""></property>
+      </properties>
+      <failure message="The stack has too many resources &amp; should be split." type="advisory">`+
+		`The stack has too many resources &amp; should be split.</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="security@v1.0.0" tests="1" failures="1">
+    <testcase name="no-public-buckets (urn:pulumi:dev::proj::aws:s3/bucket:Bucket::my-bucket)" `+
+		`classname="security.no-public-buckets">
+      <properties>
+        <property name="policyPack" value="security"></property>
+        <property name="policyPackVersion" value="1.0.0"></property>
+        <property name="policyName" value="no-public-buckets"></property>
+        <property name="enforcementLevel" value="mandatory"></property>
+        <property name="resourceURN" value="urn:pulumi:dev::proj::aws:s3/bucket:Bucket::my-bucket"></property>
+      </properties>
+      <failure message="Buckets must not be public." type="mandatory">Prohibits public buckets.&#xA;&#xA;`+
+		`Buckets must not be public.&#xA;&#xA;Resource: urn:pulumi:dev::proj::aws:s3/bucket:Bucket::my-bucket</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
+
+func TestSARIFPolicyReport(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, writePolicyReport(&out, PolicyReportSARIF, "infra/Pulumi.yaml", testPolicyViolations()))
+
+	var log sarifLog
+	if !assert.NoError(t, json.Unmarshal(out.Bytes(), &log)) {
+		t.FailNow()
+	}
+	assert.Equal(t, "2.1.0", log.Version)
+	if !assert.Len(t, log.Runs, 1) {
+		t.FailNow()
+	}
+	run := log.Runs[0]
+
+	if assert.Len(t, run.Tool.Driver.Rules, 2) {
+		rule := run.Tool.Driver.Rules[0]
+		assert.Equal(t, "security/no-public-buckets", rule.ID)
+		assert.Equal(t, "no-public-buckets", rule.Name)
+		assert.Equal(t, &sarifMessage{Text: "Prohibits public buckets."}, rule.ShortDescription)
+		assert.Equal(t, []interface{}{"s3"}, rule.Properties["tags"])
+		assert.Nil(t, run.Tool.Driver.Rules[1].ShortDescription)
+	}
+
+	if assert.Len(t, run.Results, 2) {
+		bucket, stack := run.Results[0], run.Results[1]
+		assert.Equal(t, "security/no-public-buckets", bucket.RuleID)
+		assert.Equal(t, 0, bucket.RuleIndex)
+		assert.Equal(t, "error", bucket.Level)
+		assert.Equal(t, "Buckets must not be public.", bucket.Message.Text)
+		assert.Equal(t, "mandatory", bucket.Properties["enforcementLevel"])
+		assert.Equal(t, "security", bucket.Properties["policyPack"])
+		assert.Equal(t, string(testBucketURN), bucket.Properties["resourceURN"])
+		project := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "infra/Pulumi.yaml", URIBaseID: "%SRCROOT%"},
+			Region:           sarifRegion{StartLine: 1},
+		}
+		assert.Equal(t, []sarifLocation{{
+			PhysicalLocation: project,
+			LogicalLocations: []sarifLogicalLocation{{
+				Name: "my-bucket", FullyQualifiedName: string(testBucketURN), Kind: "resource",
+			}},
+		}}, bucket.Locations)
+
+		assert.Equal(t, "cost/max-resources", stack.RuleID)
+		assert.Equal(t, 1, stack.RuleIndex)
+		assert.Equal(t, "warning", stack.Level)
+		assert.Equal(t, []sarifLocation{{PhysicalLocation: project}}, stack.Locations)
+	}
+
+	// Results are located in Pulumi.yaml when no project file is given.
+	out.Reset()
+	assert.NoError(t, writePolicyReport(&out, PolicyReportSARIF, "", testPolicyViolations()))
+	log = sarifLog{}
+	if assert.NoError(t, json.Unmarshal(out.Bytes(), &log)) && assert.Len(t, log.Runs, 1) {
+		for _, result := range log.Runs[0].Results {
+			if assert.Len(t, result.Locations, 1) {
+				assert.Equal(t, "Pulumi.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			}
+		}
+	}
+
+	// A report without violations is still a valid log.
+	out.Reset()
+	assert.NoError(t, writePolicyReport(&out, PolicyReportSARIF, "", nil))
+	assert.Contains(t, out.String(), `"results": []`)
+}
+
+func TestPolicyReportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-policy-report")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "policy.xml")
+
+	// The report is written alongside the regular display.
+	events, done := make(chan engine.Event), make(chan bool)
+	opts := Options{Color: colors.Never, Type: DisplayDiff, PolicyReportPath: path, PolicyReportFormat: PolicyReportJUnit}
+	go ShowEvents("Previewing update", apitype.PreviewUpdate, tokens.QName("dev"), tokens.PackageName("proj"),
+		events, done, opts, true)
+	for _, e := range testPreviewEvents(false) {
+		events <- e
+	}
+	events <- engine.Event{Type: engine.CancelEvent}
+	<-done
+
+	b, err := ioutil.ReadFile(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, string(b), `<testsuite name="security@v1.0.0" tests="1" failures="1">`)
+	assert.Contains(t, string(b), `type="mandatory">Buckets must&#xA;not be public.`)
+}
@@ -254,22 +254,7 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.QName
 	// from to display to the console.
 	progressOutput := make(chan Progress)
 
-	display := &ProgressDisplay{
-		action:                 action,
-		isPreview:              isPreview,
-		opts:                   opts,
-		stack:                  stack,
-		proj:                   proj,
-		progressOutput:         progressOutput,
-		eventUrnToResourceRow:  make(map[resource.URN]ResourceRow),
-		suffixColumn:           int(statusColumn),
-		suffixesArray:          []string{"", ".", "..", "..."},
-		urnToID:                make(map[resource.URN]string),
-		colorizedToUncolorized: make(map[string]string),
-		printedProgressCache:   make(map[string]Progress),
-		displayOrderCounter:    1,
-		nonInteractiveSpinner:  spinner,
-	}
+	display := newProgressDisplay(action, stack, proj, opts, isPreview, progressOutput, spinner)
 
 	terminalWidth, terminalHeight, err := terminal.GetSize(int(os.Stdout.Fd()))
 	contract.IgnoreError(err)
@@ -295,6 +280,28 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.QName
 	close(done)
 }
 
+// newProgressDisplay creates a progress display that writes its progress messages to the given channel.
+func newProgressDisplay(action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName, opts Options,
+	isPreview bool, progressOutput chan<- Progress, spinner cmdutil.Spinner) *ProgressDisplay {
+
+	return &ProgressDisplay{
+		action:                 action,
+		isPreview:              isPreview,
+		opts:                   opts,
+		stack:                  stack,
+		proj:                   proj,
+		progressOutput:         progressOutput,
+		eventUrnToResourceRow:  make(map[resource.URN]ResourceRow),
+		suffixColumn:           int(statusColumn),
+		suffixesArray:          []string{"", ".", "..", "..."},
+		urnToID:                make(map[resource.URN]string),
+		colorizedToUncolorized: make(map[string]string),
+		printedProgressCache:   make(map[string]Progress),
+		displayOrderCounter:    1,
+		nonInteractiveSpinner:  spinner,
+	}
+}
+
 // Gets the padding necessary to prepend to a message in order to keep it aligned in the
 // terminal.
 func (display *ProgressDisplay) getMessagePadding(
@@ -838,7 +845,7 @@ func (display *ProgressDisplay) printOutputs() {
 
 	props := engine.GetResourceOutputsPropertiesString(
 		stackStep, 1, display.isPreview, display.opts.Debug,
-		false /* refresh */, display.opts.ShowSameResources)
+		false /* refresh */, display.opts.ShowSameResources, !display.opts.RawStringDiffs)
 	if props != "" {
 		display.writeSimpleMessage(colors.SpecHeadline + "Outputs:" + colors.Reset)
 		display.writeSimpleMessage(props)
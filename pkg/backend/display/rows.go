@@ -53,6 +53,7 @@ type ResourceRow interface {
 
 	IsDone() bool
 
+	Failed() bool
 	SetFailed()
 
 	DiagInfo() *DiagInfo
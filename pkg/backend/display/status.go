@@ -0,0 +1,429 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+const (
+	// maxStatusDiagnostics is the number of recent diagnostics reported by the status server.
+	maxStatusDiagnostics = 100
+	// maxStatusEvents is the number of recent engine events that the status server retains for streaming.
+	maxStatusEvents = 10000
+	// statusShutdownTimeout is the time the status server waits for its clients to finish once the update is done.
+	statusShutdownTimeout = 5 * time.Second
+)
+
+// startStatusServer forwards events to the display that is rendering them while serving the status of the update
+// they describe over HTTP at the address in the given options. The server is shut down once the display is done.
+func startStatusServer(action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName,
+	events <-chan engine.Event, done chan<- bool, opts Options, isPreview bool) (<-chan engine.Event, chan<- bool) {
+
+	listener, err := net.Listen("tcp", opts.StatusAddr)
+	if err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not serve the update status at %s: %v"), opts.StatusAddr, err)
+		return events, done
+	}
+
+	status := newStatusServer(action, stack, proj, opts, isPreview)
+	server := &http.Server{Handler: status}
+	go func() {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			logging.V(7).Infof("status server failed: %v", err)
+		}
+	}()
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			status.processEvent(e)
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+
+		// Let any clients that are following the update see its end before shutting down.
+		status.finish()
+		ctx, cancel := context.WithTimeout(context.Background(), statusShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			contract.IgnoreError(server.Close())
+		}
+	}()
+
+	return outEvents, outDone
+}
+
+// statusServer serves the status of an update. Its state is maintained by a progress display that does not write to
+// the console, so the resources it reports are the rows that the progress display would show.
+type statusServer struct {
+	mux *http.ServeMux
+
+	m           sync.Mutex
+	display     *ProgressDisplay
+	progress    chan Progress
+	startTime   time.Time
+	endTime     time.Time
+	timings     map[resource.URN]*stepTiming
+	diagnostics []statusDiagnostic
+	summary     *engine.SummaryEventPayload
+	events      []apitype.EngineEvent // a ring buffer of the most recent events, indexed by sequence number
+	maxEvents   int                   // the capacity of the events ring buffer
+	eventCount  int                   // the number of events received, i.e. the next event's sequence number
+	updated     chan struct{}         // closed and replaced whenever the status changes
+	done        bool
+}
+
+// stepTiming records when a resource's step started and, once it is done, when it ended.
+type stepTiming struct {
+	start time.Time
+	end   time.Time
+}
+
+// statusReport is the JSON representation of the status of an update.
+type statusReport struct {
+	Project         tokens.PackageName     `json:"project"`
+	Stack           tokens.QName           `json:"stack"`
+	Action          apitype.UpdateKind     `json:"action"`
+	Preview         bool                   `json:"preview"`
+	Done            bool                   `json:"done"`
+	StartTime       time.Time              `json:"startTime"`
+	ElapsedSeconds  float64                `json:"elapsedSeconds"`
+	Resources       []statusResource       `json:"resources"`
+	Diagnostics     []statusDiagnostic     `json:"diagnostics"`
+	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+}
+
+// statusResource is the JSON representation of a row of the progress display.
+type statusResource struct {
+	URN             resource.URN  `json:"urn"`
+	Parent          resource.URN  `json:"parent,omitempty"`
+	Type            tokens.Type   `json:"type"`
+	Name            string        `json:"name"`
+	Op              deploy.StepOp `json:"op"`
+	Status          string        `json:"status"`
+	Info            string        `json:"info,omitempty"`
+	Done            bool          `json:"done"`
+	Failed          bool          `json:"failed"`
+	StartTime       *time.Time    `json:"startTime,omitempty"`
+	EndTime         *time.Time    `json:"endTime,omitempty"`
+	DurationSeconds float64       `json:"durationSeconds,omitempty"`
+}
+
+// statusDiagnostic is the JSON representation of a diagnostic message.
+type statusDiagnostic struct {
+	Time     time.Time     `json:"time"`
+	URN      resource.URN  `json:"urn,omitempty"`
+	Severity diag.Severity `json:"severity"`
+	Message  string        `json:"message"`
+}
+
+func newStatusServer(action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName, opts Options,
+	isPreview bool) *statusServer {
+
+	// The progress display reports progress as if it was not writing to a terminal, which we simply discard.
+	progress := make(chan Progress)
+	go func() {
+		for range progress {
+		}
+	}()
+	opts.IsInteractive = false
+
+	s := &statusServer{
+		mux:       http.NewServeMux(),
+		display:   newProgressDisplay(action, stack, proj, opts, isPreview, progress, &nopSpinner{}),
+		progress:  progress,
+		startTime: time.Now(),
+		timings:   make(map[resource.URN]*stepTiming),
+		maxEvents: maxStatusEvents,
+		updated:   make(chan struct{}),
+	}
+	s.mux.HandleFunc("/", s.serveStatus)
+	s.mux.HandleFunc("/events", s.serveEvents)
+	return s
+}
+
+func (s *statusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// processEvent updates the status of the update with the given event.
+func (s *statusServer) processEvent(e engine.Event) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.done {
+		return
+	}
+	now := time.Now()
+
+	apiEvent, err := ConvertEngineEvent(e)
+	if err != nil {
+		logging.V(7).Infof("failed to convert event for the status server: %v", err)
+	} else {
+		apiEvent.Sequence, apiEvent.Timestamp = s.eventCount, int(now.Unix())
+		if len(s.events) < s.maxEvents {
+			s.events = append(s.events, apiEvent)
+		} else {
+			s.events[s.eventCount%s.maxEvents] = apiEvent
+		}
+		s.eventCount++
+	}
+
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		urn := e.Payload.(engine.ResourcePreEventPayload).Metadata.URN
+		s.timings[urn] = &stepTiming{start: now}
+	case engine.ResourceOutputsEvent, engine.ResourceOperationFailed:
+		urn, _ := getEventUrnAndMetadata(e)
+		if t, ok := s.timings[urn]; ok && t.end.IsZero() {
+			t.end = now
+		}
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		if !p.Ephemeral && (p.Severity != diag.Debug || s.display.opts.Debug) {
+			message := strings.TrimRightFunc(colors.Never.Colorize(p.Prefix+p.Message), unicode.IsSpace)
+			s.diagnostics = append(s.diagnostics, statusDiagnostic{
+				Time:     now,
+				URN:      p.URN,
+				Severity: p.Severity,
+				Message:  message,
+			})
+			if len(s.diagnostics) > maxStatusDiagnostics {
+				s.diagnostics = s.diagnostics[len(s.diagnostics)-maxStatusDiagnostics:]
+			}
+		}
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		s.summary = &p
+	}
+
+	if e.Type == engine.CancelEvent {
+		s.end()
+	} else {
+		s.display.processNormalEvent(e)
+	}
+	s.notify()
+}
+
+// finish marks the update as done if it has not already been marked as such.
+func (s *statusServer) finish() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if !s.done {
+		s.end()
+		s.notify()
+	}
+}
+
+// end marks the update and all of its rows as done. The caller must hold the server's lock.
+func (s *statusServer) end() {
+	s.done, s.endTime = true, time.Now()
+	s.display.processEndSteps()
+	close(s.progress)
+}
+
+// notify wakes up any clients that are waiting for the status to change. The caller must hold the server's lock.
+func (s *statusServer) notify() {
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// eventsSince returns the retained events whose sequence numbers are at least the given sequence number. If events
+// with such sequence numbers have already been dropped from the ring buffer, it returns false. The caller must hold
+// the server's lock.
+func (s *statusServer) eventsSince(next int) ([]apitype.EngineEvent, bool) {
+	if next < s.eventCount-len(s.events) {
+		return nil, false
+	}
+
+	var events []apitype.EngineEvent
+	for seq := next; seq < s.eventCount; seq++ {
+		events = append(events, s.events[seq%s.maxEvents])
+	}
+	return events, true
+}
+
+// report returns the current status of the update.
+func (s *statusServer) report() statusReport {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := time.Now()
+	endTime := now
+	if s.done {
+		endTime = s.endTime
+	}
+
+	report := statusReport{
+		Project:        s.display.proj,
+		Stack:          s.display.stack,
+		Action:         s.display.action,
+		Preview:        s.display.isPreview,
+		Done:           s.done,
+		StartTime:      s.startTime,
+		ElapsedSeconds: endTime.Sub(s.startTime).Seconds(),
+		Resources:      []statusResource{},
+		Diagnostics:    append([]statusDiagnostic{}, s.diagnostics...),
+	}
+	if s.summary != nil {
+		report.ResourceChanges = s.summary.ResourceChanges
+	}
+
+	for _, row := range s.display.resourceRows {
+		step := row.Step()
+		if row.HideRowIfUnnecessary() && !isRootStack(step) {
+			continue
+		}
+
+		urn := step.URN
+		if urn == "" {
+			// As in the progress display, rows without a URN represent the stack.
+			urn = resource.DefaultRootStackURN(s.display.stack, s.display.proj)
+		}
+		columns := s.display.uncolorizeColumns(row.ColorizedColumns())
+
+		res := statusResource{
+			URN:    urn,
+			Type:   urn.Type(),
+			Name:   string(urn.Name()),
+			Op:     s.display.getStepOp(step),
+			Status: columns[statusColumn],
+			Info:   columns[infoColumn],
+			Done:   row.IsDone(),
+			Failed: row.Failed() || row.DiagInfo().ErrorCount > 0,
+		}
+		if step.Res != nil {
+			res.Parent = step.Res.Parent
+		}
+		if t, ok := s.timings[step.URN]; ok {
+			start, end := t.start, t.end
+			res.StartTime = &start
+			if !end.IsZero() {
+				res.EndTime = &end
+			} else {
+				end = endTime
+			}
+			res.DurationSeconds = end.Sub(start).Seconds()
+		}
+		report.Resources = append(report.Resources, res)
+	}
+
+	return report
+}
+
+// serveStatus writes the current status of the update as JSON.
+func (s *statusServer) serveStatus(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && r.URL.Path != "/status" {
+		http.NotFound(w, r)
+		return
+	}
+
+	b, err := json.MarshalIndent(s.report(), "", "    ")
+	contract.AssertNoErrorf(err, "unexpected JSON error")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(append(b, '\n'))
+	contract.IgnoreError(err)
+}
+
+// serveEvents streams the update's engine events as server-sent events until the update is done. Each event's ID is
+// its sequence number, so clients that reconnect with a Last-Event-ID header only receive the events they missed.
+// Only the most recent events are retained, so new clients start with the oldest retained event, and clients that
+// missed events that have since been dropped receive 204 No Content, which tells them to stop reconnecting. A client
+// that falls so far behind that the events it has yet to receive are dropped is disconnected, so that it reconnects
+// and learns the same.
+func (s *statusServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	s.m.Lock()
+	next := s.eventCount - len(s.events)
+	if id, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil && id >= 0 {
+		next = id + 1
+	}
+	_, ok = s.eventsSince(next)
+	s.m.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		s.m.Lock()
+		pending, ok := s.eventsSince(next)
+		updated, done := s.updated, s.done
+		s.m.Unlock()
+		if !ok {
+			return
+		}
+
+		for _, e := range pending {
+			b, err := json.Marshal(e)
+			contract.AssertNoErrorf(err, "unexpected JSON error")
+			if _, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Sequence, b); err != nil {
+				return
+			}
+		}
+		next += len(pending)
+		flusher.Flush()
+
+		if done {
+			return
+		}
+		select {
+		case <-updated:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
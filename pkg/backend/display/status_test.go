@@ -0,0 +1,176 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func getStatusReport(t *testing.T, url string) statusReport {
+	resp, err := http.Get(url + "/status")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var report statusReport
+	if !assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report)) {
+		t.FailNow()
+	}
+	return report
+}
+
+func getStatusEvents(t *testing.T, url, lastEventID string) string {
+	req, err := http.NewRequest("GET", url+"/events", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	b, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestStatusServer(t *testing.T) {
+	status := newStatusServer(apitype.PreviewUpdate, "dev", "proj", Options{Color: colors.Never}, true)
+	server := httptest.NewServer(status)
+	defer server.Close()
+
+	// A client that follows the update receives all of its events until it is done.
+	streamed := make(chan string)
+	go func() {
+		streamed <- getStatusEvents(t, server.URL, "")
+	}()
+
+	events := testPreviewEvents(false)
+	for _, e := range events[:3] {
+		status.processEvent(e)
+	}
+
+	report := getStatusReport(t, server.URL)
+	assert.Equal(t, "proj", string(report.Project))
+	assert.Equal(t, "dev", string(report.Stack))
+	assert.True(t, report.Preview)
+	assert.False(t, report.Done)
+	if assert.Len(t, report.Resources, 2) {
+		stack, bucket := report.Resources[0], report.Resources[1]
+		assert.Equal(t, testStackURN, stack.URN)
+		assert.False(t, stack.Done)
+
+		assert.Equal(t, testBucketURN, bucket.URN)
+		assert.Equal(t, "my-bucket", bucket.Name)
+		assert.Equal(t, deploy.OpUpdate, bucket.Op)
+		assert.False(t, bucket.Done)
+		assert.NotNil(t, bucket.StartTime)
+		assert.Nil(t, bucket.EndTime)
+	}
+
+	for _, e := range events[3:] {
+		status.processEvent(e)
+	}
+	status.processEvent(engine.Event{Type: engine.CancelEvent})
+
+	report = getStatusReport(t, server.URL)
+	assert.True(t, report.Done)
+	assert.Equal(t, engine.ResourceChanges{deploy.OpUpdate: 1, deploy.OpSame: 1}, report.ResourceChanges)
+	if assert.Len(t, report.Resources, 2) {
+		stack, bucket := report.Resources[0], report.Resources[1]
+		assert.True(t, stack.Done)
+		assert.NotNil(t, stack.EndTime)
+		assert.True(t, bucket.Done)
+		assert.Equal(t, "[diff: ~acl,secret]; 1 warning", bucket.Info)
+	}
+
+	// Ephemeral diagnostics are not reported.
+	if assert.Len(t, report.Diagnostics, 1) {
+		assert.Equal(t, testBucketURN, report.Diagnostics[0].URN)
+		assert.Equal(t, diag.Warning, report.Diagnostics[0].Severity)
+		assert.Equal(t, "bucket | acl is deprecated", report.Diagnostics[0].Message)
+	}
+
+	stream := <-streamed
+	assert.Equal(t, len(events)+1, strings.Count(stream, "\ndata: "))
+	assert.True(t, strings.HasPrefix(stream, "id: 0\ndata: {"))
+	assert.Contains(t, stream, "id: 3\ndata: {")
+	assert.Contains(t, stream, `"cancelEvent":{}`)
+
+	// Clients that reconnect only receive the events they missed.
+	stream = getStatusEvents(t, server.URL, "6")
+	assert.Equal(t, 2, strings.Count(stream, "\ndata: "))
+	assert.True(t, strings.HasPrefix(stream, "id: 7\ndata: {"))
+}
+
+func TestStatusServerDropsEvents(t *testing.T) {
+	status := newStatusServer(apitype.PreviewUpdate, "dev", "proj", Options{Color: colors.Never}, true)
+	status.maxEvents = 4
+	server := httptest.NewServer(status)
+	defer server.Close()
+
+	events := testPreviewEvents(false)
+	for _, e := range events {
+		status.processEvent(e)
+	}
+	status.processEvent(engine.Event{Type: engine.CancelEvent})
+	assert.Len(t, status.events, 4)
+
+	// New clients receive the retained events.
+	last := len(events)
+	stream := getStatusEvents(t, server.URL, "")
+	assert.Equal(t, 4, strings.Count(stream, "\ndata: "))
+	assert.True(t, strings.HasPrefix(stream, fmt.Sprintf("id: %d\ndata: {", last-3)))
+	assert.Contains(t, stream, fmt.Sprintf("id: %d\ndata: {", last))
+
+	// Clients that reconnect after the events they missed were dropped are told to stop reconnecting.
+	req, err := http.NewRequest("GET", server.URL+"/events", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Clients that only missed retained events still receive them.
+	stream = getStatusEvents(t, server.URL, strconv.Itoa(last-2))
+	assert.Equal(t, 2, strings.Count(stream, "\ndata: "))
+}
@@ -462,6 +462,11 @@ func (b *localBackend) apply(
 		return nil, result.FromError(err)
 	}
 
+	// Notify any webhooks of the update's progress. Sending notifications never fails the update, and once the update
+	// is done, it only waits a bounded time for the notifications that are still pending.
+	hooks := newWebhookNotifier(stackWebhooks(op.Proj, stackName), op.Proj.Name, stackName, opts.DryRun)
+	defer hooks.Close()
+
 	// Spawn a display loop to show events on the CLI.
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
@@ -479,6 +484,10 @@ func (b *localBackend) apply(
 		for e := range engineEvents {
 			displayEvents <- e
 
+			if e.Type == engine.PolicyViolationEvent {
+				hooks.policyViolation(e)
+			}
+
 			// If the caller also wants to see the events, stream them there also.
 			if events != nil {
 				events <- e
@@ -500,6 +509,14 @@ func (b *localBackend) apply(
 
 	// Perform the update
 	start := time.Now().Unix()
+	hooks.updateStarted(backend.UpdateInfo{
+		Kind:        kind,
+		StartTime:   start,
+		Message:     op.M.Message,
+		Environment: op.M.Environment,
+		Config:      update.GetTarget().Config,
+		Result:      backend.InProgressResult,
+	})
 	var changes engine.ResourceChanges
 	var updateRes result.Result
 	switch kind {
@@ -544,6 +561,7 @@ func (b *localBackend) apply(
 		//     trivial to achieve today given the event driven nature of plan-walking, however.
 		ResourceChanges: changes,
 	}
+	hooks.updateFinished(info)
 
 	var saveErr error
 	var backupErr error
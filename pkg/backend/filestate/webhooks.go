@@ -0,0 +1,361 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/retry"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// The kinds of webhook notifications.
+const (
+	webhookUpdateStarted   = "update_started"
+	webhookUpdateSucceeded = "update_succeeded"
+	webhookUpdateFailed    = "update_failed"
+	webhookPolicyViolation = "policy_violation"
+)
+
+const (
+	// webhookSecretEnvVar is the environment variable that holds the key used to sign webhook notifications. The key
+	// is deliberately not part of the project or stack files, which are usually checked in.
+	webhookSecretEnvVar = "PULUMI_WEBHOOK_SECRET"
+
+	// webhookKindHeader is the HTTP header that holds the kind of a webhook notification.
+	webhookKindHeader = "Pulumi-Webhook-Kind"
+	// webhookSignatureHeader is the HTTP header that holds the hex-encoded HMAC-SHA256 signature of a webhook
+	// notification's body, if PULUMI_WEBHOOK_SECRET is set.
+	webhookSignatureHeader = "Pulumi-Webhook-Signature"
+
+	// maxWebhookAttempts is the number of times a notification is sent to a webhook before giving up.
+	maxWebhookAttempts = 3
+	// webhookTimeout is the timeout for each attempt to send a notification to a webhook.
+	webhookTimeout = 10 * time.Second
+	// webhookCloseTimeout is the time that a finished update waits for its pending notifications to be sent.
+	webhookCloseTimeout = 30 * time.Second
+)
+
+// webhookPayload is the JSON body of a webhook notification.
+type webhookPayload struct {
+	// Kind is the kind of notification, e.g. "update_started".
+	Kind string `json:"kind"`
+	// Project is the name of the project being updated.
+	Project tokens.PackageName `json:"project"`
+	// Stack is the name of the stack being updated.
+	Stack tokens.QName `json:"stack"`
+	// Preview is true if the update is a preview.
+	Preview bool `json:"preview"`
+	// Update describes the update. Its result is "in-progress" until the update has finished.
+	Update apitype.UpdateInfo `json:"update"`
+	// Event is the engine event the notification pertains to, if any (e.g. the policy violation).
+	Event *apitype.EngineEvent `json:"event,omitempty"`
+}
+
+// webhookNotifier sends notifications of an update to webhooks. Notifications are sent in the background, in order,
+// and failures to send them are reported as warnings rather than failing the update. A nil notifier sends nothing.
+type webhookNotifier struct {
+	hooks        []workspace.ProjectWebhook
+	client       *http.Client
+	project      tokens.PackageName
+	stack        tokens.QName
+	preview      bool
+	ctx          context.Context    // canceled once the notifier stops waiting for notifications to be sent
+	cancel       context.CancelFunc // cancels ctx
+	closeTimeout time.Duration      // the time that Close waits for pending notifications to be sent
+
+	m       sync.Mutex
+	update  apitype.UpdateInfo // the update, as described when it started
+	pending []webhookPayload   // notifications that are waiting to be sent
+	sending bool               // true if a goroutine is sending the pending notifications
+	wg      sync.WaitGroup
+}
+
+// stackWebhooks returns the webhooks configured for a stack: those of the project, followed by those of the stack's
+// configuration file, if it can be found.
+func stackWebhooks(proj *workspace.Project, stackName tokens.QName) []workspace.ProjectWebhook {
+	hooks := append([]workspace.ProjectWebhook{}, proj.Webhooks...)
+	ps, err := workspace.DetectProjectStack(stackName)
+	if err != nil {
+		logging.V(7).Infof("not loading stack webhooks: %v", err)
+		return hooks
+	}
+	return append(hooks, ps.Webhooks...)
+}
+
+// newWebhookNotifier creates a notifier that sends notifications of an update to the given webhooks, or nil if there
+// are none.
+func newWebhookNotifier(hooks []workspace.ProjectWebhook, project tokens.PackageName, stack tokens.QName,
+	preview bool) *webhookNotifier {
+
+	if len(hooks) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhookNotifier{
+		hooks:        hooks,
+		client:       &http.Client{Timeout: webhookTimeout},
+		project:      project,
+		stack:        stack,
+		preview:      preview,
+		ctx:          ctx,
+		cancel:       cancel,
+		closeTimeout: webhookCloseTimeout,
+	}
+}
+
+// updateStarted notifies the webhooks that the given update has started.
+func (n *webhookNotifier) updateStarted(info backend.UpdateInfo) {
+	if n == nil {
+		return
+	}
+
+	update := convertUpdateInfo(info)
+	n.m.Lock()
+	n.update = update
+	n.m.Unlock()
+
+	n.send(webhookPayload{Kind: webhookUpdateStarted, Update: update})
+}
+
+// updateFinished notifies the webhooks that the given update has succeeded or failed.
+func (n *webhookNotifier) updateFinished(info backend.UpdateInfo) {
+	if n == nil {
+		return
+	}
+
+	kind := webhookUpdateSucceeded
+	if info.Result != backend.SucceededResult {
+		kind = webhookUpdateFailed
+	}
+	n.send(webhookPayload{Kind: kind, Update: convertUpdateInfo(info)})
+}
+
+// policyViolation notifies the webhooks of the policy violation reported by the given event.
+func (n *webhookNotifier) policyViolation(e engine.Event) {
+	if n == nil {
+		return
+	}
+
+	apiEvent, err := display.ConvertEngineEvent(e)
+	if err != nil {
+		logging.V(7).Infof("failed to convert policy violation for webhooks: %v", err)
+		return
+	}
+	apiEvent.Timestamp = int(time.Now().Unix())
+
+	n.m.Lock()
+	update := n.update
+	n.m.Unlock()
+
+	n.send(webhookPayload{Kind: webhookPolicyViolation, Update: update, Event: &apiEvent})
+}
+
+// Close waits for all pending notifications to be sent, but for no longer than the notifier's close timeout, so that
+// unresponsive webhooks cannot hold up the end of an update. Notifications that have not been sent by then are
+// abandoned.
+func (n *webhookNotifier) Close() {
+	if n == nil {
+		return
+	}
+	defer n.cancel()
+
+	sent := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(sent)
+	}()
+
+	timer := time.NewTimer(n.closeTimeout)
+	defer timer.Stop()
+	select {
+	case <-sent:
+	case <-timer.C:
+		cmdutil.Diag().Warningf(diag.Message("", "gave up on notifying webhooks after %v"), n.closeTimeout)
+	}
+}
+
+// send queues a notification to be sent to the webhooks.
+func (n *webhookNotifier) send(payload webhookPayload) {
+	payload.Project, payload.Stack, payload.Preview = n.project, n.stack, n.preview
+
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	n.pending = append(n.pending, payload)
+	if !n.sending {
+		n.sending = true
+		n.wg.Add(1)
+		go n.sendPending()
+	}
+}
+
+// sendPending sends the pending notifications in order until there are none left.
+func (n *webhookNotifier) sendPending() {
+	defer n.wg.Done()
+
+	for {
+		n.m.Lock()
+		if len(n.pending) == 0 || n.ctx.Err() != nil {
+			n.pending, n.sending = nil, false
+			n.m.Unlock()
+			return
+		}
+		payload := n.pending[0]
+		n.pending = n.pending[1:]
+		n.m.Unlock()
+
+		body, err := json.Marshal(payload)
+		contract.AssertNoErrorf(err, "unexpected JSON error")
+
+		for _, hook := range n.hooks {
+			if !webhookWantsKind(hook, payload.Kind) {
+				continue
+			}
+			if err := n.post(hook, payload.Kind, body); err != nil && n.ctx.Err() == nil {
+				cmdutil.Diag().Warningf(diag.Message("", "could not notify webhook %s of %s: %v"),
+					hook.URL, payload.Kind, err)
+			}
+		}
+	}
+}
+
+// webhookWantsKind returns true if the given kind of notification should be sent to the given webhook.
+func webhookWantsKind(hook workspace.ProjectWebhook, kind string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, k := range hook.Events {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// post sends a notification to a webhook, retrying if the webhook cannot be reached or does not accept it. It gives up
+// once the notifier's context is canceled.
+func (n *webhookNotifier) post(hook workspace.ProjectWebhook, kind string, body []byte) error {
+	secret := os.Getenv(webhookSecretEnvVar)
+
+	accepted, _, err := retry.Until(n.ctx, retry.Acceptor{
+		Accept: func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+			err := n.postOnce(hook.URL, kind, body, secret)
+			if err == nil {
+				return true, nil, nil
+			}
+			if try >= maxWebhookAttempts-1 {
+				return false, nil, err
+			}
+			logging.V(7).Infof("failed to notify webhook %s of %s (retrying in %v): %v", hook.URL, kind, nextRetryTime, err)
+			return false, nil, nil
+		},
+	})
+	if !accepted && err == nil {
+		err = n.ctx.Err()
+	}
+	return err
+}
+
+func (n *webhookNotifier) postOnce(url, kind string, body []byte, secret string) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(n.ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookKindHeader, kind)
+	if secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	contract.IgnoreError(err)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected response: %s", resp.Status)
+	}
+	return nil
+}
+
+// signWebhookPayload returns the hex-encoded HMAC-SHA256 signature of a notification's body.
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, err := mac.Write(body)
+	contract.IgnoreError(err)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// convertUpdateInfo converts a backend update into its API representation. The values of secrets remain encrypted.
+func convertUpdateInfo(info backend.UpdateInfo) apitype.UpdateInfo {
+	cfg := make(map[string]apitype.ConfigValue, len(info.Config))
+	for k, cv := range info.Config {
+		v, err := cv.Value(config.NopDecrypter)
+		contract.AssertNoError(err)
+
+		cfg[k.String()] = apitype.ConfigValue{
+			String: v,
+			Secret: cv.Secure(),
+			Object: cv.Object(),
+		}
+	}
+
+	var changes map[apitype.OpType]int
+	if len(info.ResourceChanges) != 0 {
+		changes = make(map[apitype.OpType]int, len(info.ResourceChanges))
+		for op, count := range info.ResourceChanges {
+			changes[apitype.OpType(op)] = count
+		}
+	}
+
+	return apitype.UpdateInfo{
+		Kind:            info.Kind,
+		StartTime:       info.StartTime,
+		Message:         info.Message,
+		Environment:     info.Environment,
+		Config:          cfg,
+		Result:          apitype.UpdateResult(info.Result),
+		EndTime:         info.EndTime,
+		ResourceChanges: changes,
+	}
+}
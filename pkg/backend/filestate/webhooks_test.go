@@ -0,0 +1,215 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+type webhookRequest struct {
+	kind      string
+	signature string
+	payload   webhookPayload
+}
+
+// newWebhookServer starts a server that records the notifications it receives. The first failures requests are
+// rejected.
+func newWebhookServer(t *testing.T, secret string, failures int) (*httptest.Server, func() []webhookRequest) {
+	var m sync.Mutex
+	var requests []webhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		if secret != "" {
+			mac := hmac.New(sha256.New, []byte(secret))
+			_, err = mac.Write(body)
+			assert.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(webhookSignatureHeader))
+		}
+
+		var payload webhookPayload
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		requests = append(requests, webhookRequest{
+			kind:      r.Header.Get(webhookKindHeader),
+			signature: r.Header.Get(webhookSignatureHeader),
+			payload:   payload,
+		})
+	}))
+	return server, func() []webhookRequest {
+		m.Lock()
+		defer m.Unlock()
+		return append([]webhookRequest{}, requests...)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	os.Setenv(webhookSecretEnvVar, "shh")
+	defer os.Unsetenv(webhookSecretEnvVar)
+
+	all, allRequests := newWebhookServer(t, "shh", 1)
+	defer all.Close()
+	violations, violationRequests := newWebhookServer(t, "shh", 0)
+	defer violations.Close()
+
+	hooks := []workspace.ProjectWebhook{
+		{URL: all.URL},
+		{URL: violations.URL, Events: []string{webhookPolicyViolation}},
+	}
+	n := newWebhookNotifier(hooks, "proj", "dev", false)
+
+	cfg := config.Map{
+		config.MustMakeKey("proj", "region"): config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "token"):  config.NewSecureValue("c2VjcmV0"),
+	}
+	info := backend.UpdateInfo{
+		Kind:      apitype.UpdateUpdate,
+		StartTime: 1,
+		Message:   "Deploy",
+		Config:    cfg,
+		Result:    backend.InProgressResult,
+	}
+	n.updateStarted(info)
+	n.policyViolation(engine.Event{Type: engine.PolicyViolationEvent, Payload: engine.PolicyViolationEventPayload{
+		ResourceURN:      resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b"),
+		Message:          "Buckets must not be public.",
+		PolicyName:       "no-public-buckets",
+		PolicyPackName:   "security",
+		EnforcementLevel: apitype.Mandatory,
+	}})
+	info.Result, info.EndTime = backend.SucceededResult, 2
+	info.ResourceChanges = engine.ResourceChanges{deploy.OpCreate: 1}
+	n.updateFinished(info)
+	n.Close()
+
+	// Notifications are sent in order, and retried if they are not accepted.
+	requests := allRequests()
+	if assert.Len(t, requests, 3) {
+		started, violation, succeeded := requests[0], requests[1], requests[2]
+
+		assert.Equal(t, webhookUpdateStarted, started.kind)
+		assert.Equal(t, webhookUpdateStarted, started.payload.Kind)
+		assert.Equal(t, "proj", string(started.payload.Project))
+		assert.Equal(t, "dev", string(started.payload.Stack))
+		assert.False(t, started.payload.Preview)
+		assert.Equal(t, apitype.UpdateUpdate, started.payload.Update.Kind)
+		assert.Equal(t, "Deploy", started.payload.Update.Message)
+		assert.Equal(t, apitype.InProgressResult, started.payload.Update.Result)
+		assert.Equal(t, apitype.ConfigValue{String: "us-west-2"}, started.payload.Update.Config["proj:region"])
+		assert.Equal(t, apitype.ConfigValue{String: "c2VjcmV0", Secret: true}, started.payload.Update.Config["proj:token"])
+		assert.Nil(t, started.payload.Event)
+
+		assert.Equal(t, webhookPolicyViolation, violation.kind)
+		assert.Equal(t, apitype.InProgressResult, violation.payload.Update.Result)
+		if assert.NotNil(t, violation.payload.Event) && assert.NotNil(t, violation.payload.Event.PolicyEvent) {
+			assert.Equal(t, "no-public-buckets", violation.payload.Event.PolicyEvent.PolicyName)
+		}
+
+		assert.Equal(t, webhookUpdateSucceeded, succeeded.kind)
+		assert.Equal(t, apitype.SucceededResult, succeeded.payload.Update.Result)
+		assert.Equal(t, int64(2), succeeded.payload.Update.EndTime)
+		assert.Equal(t, map[apitype.OpType]int{apitype.OpCreate: 1}, succeeded.payload.Update.ResourceChanges)
+	}
+
+	// Webhooks only receive the kinds of notifications they ask for.
+	requests = violationRequests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, webhookPolicyViolation, requests[0].kind)
+		assert.NotEqual(t, "", requests[0].signature)
+	}
+}
+
+func TestWebhookNotifierFailures(t *testing.T) {
+	down, downRequests := newWebhookServer(t, "", maxWebhookAttempts)
+	defer down.Close()
+
+	// Notifications that cannot be sent are dropped.
+	n := newWebhookNotifier([]workspace.ProjectWebhook{{URL: down.URL}}, "proj", "dev", true)
+	n.updateStarted(backend.UpdateInfo{Kind: apitype.PreviewUpdate, Result: backend.InProgressResult})
+	n.updateFinished(backend.UpdateInfo{Kind: apitype.PreviewUpdate, Result: backend.FailedResult})
+	n.Close()
+
+	// Notifications are not signed without PULUMI_WEBHOOK_SECRET.
+	requests := downRequests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, webhookUpdateFailed, requests[0].kind)
+		assert.True(t, requests[0].payload.Preview)
+		assert.Equal(t, "", requests[0].signature)
+	}
+
+	// Without webhooks, there is nothing to notify.
+	n = newWebhookNotifier(nil, "proj", "dev", false)
+	assert.Nil(t, n)
+	n.updateStarted(backend.UpdateInfo{})
+	n.Close()
+}
+
+func TestWebhookNotifierCloseTimeout(t *testing.T) {
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	// Closing the notifier stops waiting for unresponsive webhooks once its close timeout has passed, and abandons
+	// the notifications that have yet to be sent.
+	n := newWebhookNotifier([]workspace.ProjectWebhook{{URL: hung.URL}}, "proj", "dev", false)
+	n.closeTimeout = 100 * time.Millisecond
+	n.updateStarted(backend.UpdateInfo{Kind: apitype.UpdateUpdate, Result: backend.InProgressResult})
+	n.updateFinished(backend.UpdateInfo{Kind: apitype.UpdateUpdate, Result: backend.SucceededResult})
+
+	start := time.Now()
+	n.Close()
+	assert.True(t, time.Since(start) < webhookTimeout)
+
+	n.wg.Wait()
+	n.m.Lock()
+	defer n.m.Unlock()
+	assert.Empty(t, n.pending)
+}
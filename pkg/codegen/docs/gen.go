@@ -0,0 +1,424 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint: lll
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/russross/blackfriday"
+
+	dotnetgen "github.com/pulumi/pulumi/pkg/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/codegen/go"
+	nodejsgen "github.com/pulumi/pulumi/pkg/codegen/nodejs"
+	pythongen "github.com/pulumi/pulumi/pkg/codegen/python"
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+// Format is the format of a generated documentation page.
+type Format string
+
+const (
+	// Markdown pages are plain Markdown files.
+	Markdown Format = "markdown"
+	// HTML pages are standalone static HTML files.
+	HTML Format = "html"
+)
+
+// LanguageHelper describes the members of a package as they appear in a particular language's SDK.  Each language
+// generator provides an implementation that shares that generator's naming logic.
+type LanguageHelper interface {
+	// ResourceSignature returns the signature of the function or constructor that creates the given resource.
+	ResourceSignature(r *schema.Resource) string
+	// FunctionSignature returns the signature of the given function.
+	FunctionSignature(f *schema.Function) string
+	// PropertyName returns the name of the given property.
+	PropertyName(p *schema.Property) string
+	// PropertyType returns the type of the given property as seen from the module that contains the member
+	// identified by the given token.  Plain properties are the arguments and results of functions, which are not
+	// wrapped in inputs or outputs.
+	PropertyType(token string, p *schema.Property, input, plain bool) string
+}
+
+// language is a language for which documentation is generated.
+type language struct {
+	// name is the display name of the language.
+	name string
+	// fence is the info string used for fenced code blocks in the language.
+	fence string
+	// helper describes the package as it appears in the language's SDK.
+	helper LanguageHelper
+}
+
+// languages returns the languages for which documentation is generated for the given package.
+func languages(pkg *schema.Package) ([]language, error) {
+	dotnet, err := dotnetgen.NewDocLanguageHelper(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return []language{
+		{name: "C#", fence: "csharp", helper: dotnet},
+		{name: "Go", fence: "go", helper: gogen.NewDocLanguageHelper(pkg)},
+		{name: "Node.js", fence: "typescript", helper: nodejsgen.NewDocLanguageHelper(pkg)},
+		{name: "Python", fence: "python", helper: pythongen.NewDocLanguageHelper(pkg)},
+	}, nil
+}
+
+type modContext struct {
+	pkg       *schema.Package
+	mod       string
+	format    Format
+	languages []language
+	resources []*schema.Resource
+	functions []*schema.Function
+	types     []*schema.ObjectType
+	enums     []*schema.EnumType
+	children  []*modContext
+	tool      string
+}
+
+// filename returns the path of the page for the module, relative to the root of the documentation.
+func (mod *modContext) filename() string {
+	ext := ".md"
+	if mod.format == HTML {
+		ext = ".html"
+	}
+	return path.Join(mod.mod, "index"+ext)
+}
+
+// title returns the title of the page for the module.
+func (mod *modContext) title() string {
+	if mod.mod == "" {
+		return mod.pkg.Name
+	}
+	return mod.pkg.Name + "/" + mod.mod
+}
+
+func tokenToName(tok string) string {
+	components := strings.Split(tok, ":")
+	if len(components) != 3 {
+		return tok
+	}
+	return components[2]
+}
+
+func resourceName(r *schema.Resource) string {
+	if r.IsProvider {
+		return "Provider"
+	}
+	return tokenToName(r.Token)
+}
+
+// anchor returns the anchor that the Markdown renderer generates for a heading with the given text.
+func anchor(heading string) string {
+	return blackfriday.SanitizedAnchorName(heading)
+}
+
+// tableCell escapes a string for use inside a Markdown table cell.
+func tableCell(s string) string {
+	s = strings.Replace(strings.TrimSpace(s), "\n", " ", -1)
+	return strings.Replace(s, "|", "\\|", -1)
+}
+
+// tableCode formats a string as code inside a Markdown table cell.  Renderers differ in how they treat escaped pipes
+// inside code spans, so the code is written as inline HTML with the pipes replaced by character references.
+func tableCode(s string) string {
+	return "<code>" + strings.Replace(html.EscapeString(s), "|", "&#124;", -1) + "</code>"
+}
+
+func printComment(w *bytes.Buffer, comment, deprecationMessage string) {
+	if deprecationMessage != "" {
+		fmt.Fprintf(w, "> **Deprecated:** %s\n\n", deprecationMessage)
+	}
+	if comment = strings.TrimSpace(comment); comment != "" {
+		fmt.Fprintf(w, "%s\n\n", comment)
+	}
+}
+
+func describe(p *schema.Property) string {
+	desc := p.Comment
+	if p.DeprecationMessage != "" {
+		desc = fmt.Sprintf("**Deprecated:** %s %s", p.DeprecationMessage, desc)
+	}
+	return tableCell(desc)
+}
+
+// genSignatures emits a fenced code block per language that contains the given signature.
+func (mod *modContext) genSignatures(w *bytes.Buffer, signature func(l language) string) {
+	for _, l := range mod.languages {
+		fmt.Fprintf(w, "#### %s\n\n", l.name)
+		fmt.Fprintf(w, "```%s\n%s\n```\n\n", l.fence, signature(l))
+	}
+}
+
+// genProperties emits a table that describes the given properties in each language.
+func (mod *modContext) genProperties(w *bytes.Buffer, heading, token string, props []*schema.Property, input, plain bool) {
+	if len(props) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "### %s\n\n", heading)
+	fmt.Fprintf(w, "| Property | Required |")
+	for _, l := range mod.languages {
+		fmt.Fprintf(w, " %s |", l.name)
+	}
+	fmt.Fprintf(w, " Description |\n")
+
+	fmt.Fprintf(w, "| --- | --- |")
+	for range mod.languages {
+		fmt.Fprintf(w, " --- |")
+	}
+	fmt.Fprintf(w, " --- |\n")
+
+	for _, p := range props {
+		required := "No"
+		if p.IsRequired {
+			required = "Yes"
+		}
+		fmt.Fprintf(w, "| %s | %s |", tableCell(p.Name), required)
+		for _, l := range mod.languages {
+			name, typ := l.helper.PropertyName(p), l.helper.PropertyType(token, p, input, plain)
+			fmt.Fprintf(w, " %s %s |", tableCode(name), tableCode(typ))
+		}
+		fmt.Fprintf(w, " %s |\n", describe(p))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func (mod *modContext) genResource(w *bytes.Buffer, r *schema.Resource) {
+	fmt.Fprintf(w, "## %s\n\n", resourceName(r))
+	printComment(w, r.Comment, r.DeprecationMessage)
+
+	fmt.Fprintf(w, "### Constructor\n\n")
+	mod.genSignatures(w, func(l language) string { return l.helper.ResourceSignature(r) })
+
+	mod.genProperties(w, "Inputs", r.Token, r.InputProperties, true, false)
+	mod.genProperties(w, "Outputs", r.Token, r.Properties, false, false)
+}
+
+func (mod *modContext) genFunction(w *bytes.Buffer, f *schema.Function) {
+	fmt.Fprintf(w, "## %s\n\n", tokenToName(f.Token))
+	printComment(w, f.Comment, f.DeprecationMessage)
+
+	fmt.Fprintf(w, "### Signature\n\n")
+	mod.genSignatures(w, func(l language) string { return l.helper.FunctionSignature(f) })
+
+	if f.Inputs != nil {
+		mod.genProperties(w, "Arguments", f.Token, f.Inputs.Properties, true, true)
+	}
+	if f.Outputs != nil {
+		mod.genProperties(w, "Result", f.Token, f.Outputs.Properties, false, true)
+	}
+}
+
+func (mod *modContext) genType(w *bytes.Buffer, t *schema.ObjectType) {
+	fmt.Fprintf(w, "## %s\n\n", tokenToName(t.Token))
+	printComment(w, t.Comment, "")
+
+	mod.genProperties(w, "Inputs", t.Token, t.Properties, true, false)
+	mod.genProperties(w, "Outputs", t.Token, t.Properties, false, false)
+}
+
+func (mod *modContext) genEnum(w *bytes.Buffer, e *schema.EnumType) {
+	fmt.Fprintf(w, "## %s\n\n", tokenToName(e.Token))
+	printComment(w, e.Comment, "")
+
+	fmt.Fprintf(w, "| Name | Value | Description |\n")
+	fmt.Fprintf(w, "| --- | --- | --- |\n")
+	for i, v := range e.Elements {
+		desc := v.Comment
+		if v.DeprecationMessage != "" {
+			desc = fmt.Sprintf("**Deprecated:** %s %s", v.DeprecationMessage, desc)
+		}
+		fmt.Fprintf(w, "| %s | %s | %s |\n", tableCell(v.Name), tableCode(e.ValueStrings()[i]), tableCell(desc))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// genIndex emits a bulleted list of links to the given headings.
+func genIndex(w *bytes.Buffer, heading string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "## %s\n\n", heading)
+	for _, name := range names {
+		fmt.Fprintf(w, "- [%s](#%s)\n", name, anchor(name))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// genMarkdown emits the Markdown source of the module's page.
+func (mod *modContext) genMarkdown() string {
+	w := &bytes.Buffer{}
+
+	fmt.Fprintf(w, "# %s\n\n", mod.title())
+	if mod.mod == "" {
+		printComment(w, mod.pkg.Description, "")
+	}
+
+	if len(mod.children) > 0 {
+		fmt.Fprintf(w, "## Modules\n\n")
+		for _, child := range mod.children {
+			// Child pages live in subdirectories of this page's directory.
+			link := strings.TrimPrefix(child.filename(), mod.mod+"/")
+			fmt.Fprintf(w, "- [%s](%s)\n", child.mod, link)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	var resources, functions, types []string
+	for _, r := range mod.resources {
+		resources = append(resources, resourceName(r))
+	}
+	for _, f := range mod.functions {
+		functions = append(functions, tokenToName(f.Token))
+	}
+	for _, t := range mod.types {
+		types = append(types, tokenToName(t.Token))
+	}
+	for _, e := range mod.enums {
+		types = append(types, tokenToName(e.Token))
+	}
+	genIndex(w, "Resources", resources)
+	genIndex(w, "Functions", functions)
+	genIndex(w, "Types", types)
+
+	for _, r := range mod.resources {
+		mod.genResource(w, r)
+	}
+	for _, f := range mod.functions {
+		mod.genFunction(w, f)
+	}
+	for _, t := range mod.types {
+		mod.genType(w, t)
+	}
+	for _, e := range mod.enums {
+		mod.genEnum(w, e)
+	}
+
+	return w.String()
+}
+
+// gen renders the module's page in the requested format.
+func (mod *modContext) gen() ([]byte, error) {
+	md := mod.genMarkdown()
+
+	switch mod.format {
+	case Markdown:
+		return []byte(fmt.Sprintf("<!-- *** WARNING: this file was generated by %v. *** -->\n\n%s", mod.tool, md)), nil
+	case HTML:
+		extensions := blackfriday.EXTENSION_TABLES | blackfriday.EXTENSION_FENCED_CODE |
+			blackfriday.EXTENSION_AUTO_HEADER_IDS | blackfriday.EXTENSION_NO_INTRA_EMPHASIS
+		renderer := blackfriday.HtmlRenderer(blackfriday.HTML_USE_XHTML, "", "")
+		body := blackfriday.Markdown([]byte(md), renderer, extensions)
+
+		w := &bytes.Buffer{}
+		fmt.Fprintf(w, "<!DOCTYPE html>\n")
+		fmt.Fprintf(w, "<!-- *** WARNING: this file was generated by %v. *** -->\n", html.EscapeString(mod.tool))
+		fmt.Fprintf(w, "<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		fmt.Fprintf(w, "<title>%s</title>\n", html.EscapeString(mod.title()))
+		fmt.Fprintf(w, "</head>\n<body>\n%s</body>\n</html>\n", body)
+		return w.Bytes(), nil
+	default:
+		return nil, errors.Errorf("unsupported documentation format '%s'", mod.format)
+	}
+}
+
+// GeneratePackage generates a page of API reference documentation for each module in the given package.  The
+// result maps the path of each page, relative to the root of the documentation, to its contents.
+func GeneratePackage(tool string, pkg *schema.Package, format Format) (map[string][]byte, error) {
+	if format != Markdown && format != HTML {
+		return nil, errors.Errorf("unsupported documentation format '%s'", format)
+	}
+
+	langs, err := languages(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := map[string]*modContext{}
+
+	var getMod func(token string) *modContext
+	getMod = func(token string) *modContext {
+		modName := pkg.TokenToModule(token)
+		mod, ok := modules[modName]
+		if !ok {
+			mod = &modContext{
+				pkg:       pkg,
+				mod:       modName,
+				format:    format,
+				languages: langs,
+				tool:      tool,
+			}
+
+			if modName != "" {
+				parentName := path.Dir(modName)
+				if parentName == "." || parentName == "" {
+					parentName = ":index:"
+				}
+				parent := getMod(parentName)
+				parent.children = append(parent.children, mod)
+			}
+
+			modules[modName] = mod
+		}
+		return mod
+	}
+
+	// The provider is documented alongside the package's top-level members.
+	root := getMod(":index:")
+	root.resources = append(root.resources, pkg.Provider)
+	for _, r := range pkg.Resources {
+		mod := getMod(r.Token)
+		mod.resources = append(mod.resources, r)
+	}
+	for _, f := range pkg.Functions {
+		mod := getMod(f.Token)
+		mod.functions = append(mod.functions, f)
+	}
+	for _, t := range pkg.Types {
+		switch t := t.(type) {
+		case *schema.ObjectType:
+			mod := getMod(t.Token)
+			mod.types = append(mod.types, t)
+		case *schema.EnumType:
+			mod := getMod(t.Token)
+			mod.enums = append(mod.enums, t)
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, mod := range modules {
+		sort.Slice(mod.children, func(i, j int) bool { return mod.children[i].mod < mod.children[j].mod })
+		sort.Slice(mod.resources, func(i, j int) bool { return resourceName(mod.resources[i]) < resourceName(mod.resources[j]) })
+		sort.Slice(mod.functions, func(i, j int) bool { return mod.functions[i].Token < mod.functions[j].Token })
+		sort.Slice(mod.types, func(i, j int) bool { return mod.types[i].Token < mod.types[j].Token })
+		sort.Slice(mod.enums, func(i, j int) bool { return mod.enums[i].Token < mod.enums[j].Token })
+
+		contents, err := mod.gen()
+		if err != nil {
+			return nil, err
+		}
+		files[mod.filename()] = contents
+	}
+	return files, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+const testSchema = `{
+	"name": "test",
+	"version": "1.0.0",
+	"description": "A test package.",
+	"provider": {
+		"inputProperties": {
+			"region": {"type": "string", "description": "The region."}
+		}
+	},
+	"resources": {
+		"test:storage:Bucket": {
+			"description": "A storage bucket.",
+			"properties": {
+				"arn": {"type": "string", "description": "The bucket's ARN."},
+				"tags": {"type": "object", "additionalProperties": {"type": "string"}}
+			},
+			"required": ["arn"],
+			"inputProperties": {
+				"bucketName": {"type": "string", "description": "The name of the bucket | with a pipe."},
+				"tags": {"type": "object", "additionalProperties": {"type": "string"}},
+				"rules": {"type": "array", "items": {"$ref": "#/types/test:storage:Rule"}}
+			},
+			"requiredInputs": ["bucketName"]
+		}
+	},
+	"functions": {
+		"test:storage:getBucket": {
+			"inputs": {
+				"properties": {"bucketName": {"type": "string"}},
+				"required": ["bucketName"]
+			},
+			"outputs": {
+				"properties": {"arn": {"type": "string"}},
+				"required": ["arn"]
+			}
+		}
+	},
+	"types": {
+		"test:storage:Rule": {
+			"type": "object",
+			"properties": {
+				"days": {"type": "integer", "deprecationMessage": "Use a lifecycle policy."}
+			}
+		},
+		"test:storage:StorageClass": {
+			"type": "string",
+			"enum": [{"name": "Standard", "value": "standard"}, {"value": "archive", "description": "Cold storage."}]
+		}
+	}
+}`
+
+func importTestSchema(t *testing.T) *schema.Package {
+	var spec schema.PackageSpec
+	if !assert.NoError(t, json.Unmarshal([]byte(testSchema), &spec)) {
+		t.FailNow()
+	}
+	pkg, err := schema.ImportSpec(spec)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return pkg
+}
+
+func findProperty(props []*schema.Property, name string) *schema.Property {
+	for _, p := range props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestLanguageHelpers(t *testing.T) {
+	pkg := importTestSchema(t)
+	langs, err := languages(pkg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	bucket, getBucket := pkg.Resources[0], pkg.Functions[0]
+	bucketName := findProperty(bucket.InputProperties, "bucketName")
+	tags := findProperty(bucket.Properties, "tags")
+
+	type expected struct {
+		resource, provider, function string
+		name                         string
+		input, output, plain         string
+	}
+	cases := map[string]expected{
+		"C#": {
+			resource: "new Bucket(string name, BucketArgs args, CustomResourceOptions? options = null)",
+			provider: "new Provider(string name, ProviderArgs? args = null, ResourceOptions? options = null)",
+			function: "Task<GetBucketResult> Invokes.GetBucket(GetBucketArgs args, InvokeOptions? options = null)",
+			name:     "BucketName",
+			input:    "Input<string>",
+			output:   "Output<ImmutableDictionary<string, string>?>",
+			plain:    "string",
+		},
+		"Go": {
+			resource: "func NewBucket(ctx *pulumi.Context, name string, args *BucketArgs, " +
+				"opts ...pulumi.ResourceOption) (*Bucket, error)",
+			provider: "func NewProvider(ctx *pulumi.Context, name string, args *ProviderArgs, " +
+				"opts ...pulumi.ResourceOption) (*Provider, error)",
+			function: "func LookupBucket(ctx *pulumi.Context, args *LookupBucketArgs, " +
+				"opts ...pulumi.InvokeOption) (*LookupBucketResult, error)",
+			name:   "BucketName",
+			input:  "pulumi.StringInput",
+			output: "pulumi.StringMapOutput",
+			plain:  "string",
+		},
+		"Node.js": {
+			resource: "new Bucket(name: string, args: BucketArgs, opts?: pulumi.CustomResourceOptions)",
+			provider: "new Provider(name: string, args?: ProviderArgs, opts?: pulumi.ResourceOptions)",
+			function: "function getBucket(args: GetBucketArgs, opts?: pulumi.InvokeOptions): Promise<GetBucketResult>",
+			name:     "bucketName",
+			input:    "pulumi.Input<string>",
+			output:   "pulumi.Output<{[key: string]: string} | undefined>",
+			plain:    "string",
+		},
+		"Python": {
+			resource: "Bucket(resource_name, opts=None, bucket_name=None, rules=None, tags=None)",
+			provider: "Provider(resource_name, opts=None, region=None)",
+			function: "def get_bucket(bucket_name=None, opts=None)",
+			name:     "bucket_name",
+			input:    "pulumi.Input[str]",
+			output:   "pulumi.Output[dict]",
+			plain:    "str",
+		},
+	}
+
+	var names []string
+	for _, l := range langs {
+		names = append(names, l.name)
+
+		c, ok := cases[l.name]
+		if !assert.True(t, ok, l.name) {
+			continue
+		}
+		assert.Equal(t, c.resource, l.helper.ResourceSignature(bucket), l.name)
+		assert.Equal(t, c.provider, l.helper.ResourceSignature(pkg.Provider), l.name)
+		assert.Equal(t, c.function, l.helper.FunctionSignature(getBucket), l.name)
+		assert.Equal(t, c.name, l.helper.PropertyName(bucketName), l.name)
+		assert.Equal(t, c.input, l.helper.PropertyType(bucket.Token, bucketName, true, false), l.name)
+		assert.Equal(t, c.output, l.helper.PropertyType(bucket.Token, tags, false, false), l.name)
+		assert.Equal(t, c.plain, l.helper.PropertyType(getBucket.Token, getBucket.Inputs.Properties[0], true, true),
+			l.name)
+	}
+	assert.Equal(t, []string{"C#", "Go", "Node.js", "Python"}, names)
+}
+
+func TestGeneratePackageMarkdown(t *testing.T) {
+	files, err := GeneratePackage("test-tool", importTestSchema(t), Markdown)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, files, 2)
+
+	index := string(files["index.md"])
+	assert.Contains(t, index, "<!-- *** WARNING: this file was generated by test-tool. *** -->\n\n# test\n\n"+
+		"A test package.\n\n## Modules\n\n- [storage](storage/index.md)\n\n")
+	assert.Contains(t, index, "## Resources\n\n- [Provider](#provider)\n\n")
+
+	storage := string(files["storage/index.md"])
+	assert.Contains(t, storage, "## Resources\n\n- [Bucket](#bucket)\n\n## Functions\n\n- [getBucket](#getbucket)\n\n"+
+		"## Types\n\n- [Rule](#rule)\n- [StorageClass](#storageclass)\n\n")
+
+	// The constructor has a code block per language.
+	assert.Contains(t, storage, "## Bucket\n\nA storage bucket.\n\n### Constructor\n\n"+
+		"#### C#\n\n```csharp\nnew Bucket(string name, BucketArgs args, CustomResourceOptions? options = null)\n```\n\n"+
+		"#### Go\n\n```go\nfunc NewBucket(ctx *pulumi.Context, name string, args *BucketArgs, "+
+		"opts ...pulumi.ResourceOption) (*Bucket, error)\n```\n\n"+
+		"#### Node.js\n\n```typescript\n"+
+		"new Bucket(name: string, args: BucketArgs, opts?: pulumi.CustomResourceOptions)\n```\n\n"+
+		"#### Python\n\n```python\n"+
+		"Bucket(resource_name, opts=None, bucket_name=None, rules=None, tags=None)\n```\n\n")
+
+	// The property tables have a column per language.  Pipes are escaped in both descriptions and types.
+	assert.Contains(t, storage, "### Inputs\n\n"+
+		"| Property | Required | C# | Go | Node.js | Python | Description |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| bucketName | Yes | <code>BucketName</code> <code>Input&lt;string&gt;</code> | "+
+		"<code>BucketName</code> <code>pulumi.StringInput</code> | "+
+		"<code>bucketName</code> <code>pulumi.Input&lt;string&gt;</code> | "+
+		"<code>bucket_name</code> <code>pulumi.Input[str]</code> | The name of the bucket \\| with a pipe. |\n")
+	assert.Contains(t, storage, "| tags | No | <code>Tags</code> "+
+		"<code>Output&lt;ImmutableDictionary&lt;string, string&gt;?&gt;</code> | "+
+		"<code>Tags</code> <code>pulumi.StringMapOutput</code> | "+
+		"<code>tags</code> <code>pulumi.Output&lt;{[key: string]: string} &#124; undefined&gt;</code> | "+
+		"<code>tags</code> <code>pulumi.Output[dict]</code> |  |\n")
+
+	// Functions' arguments are plain values.
+	assert.Contains(t, storage, "### Arguments\n\n")
+	assert.Contains(t, storage, "| bucketName | Yes | <code>BucketName</code> <code>string</code> |")
+
+	// Deprecated properties and enum values are described as such.
+	assert.Contains(t, storage, "**Deprecated:** Use a lifecycle policy.")
+	assert.Contains(t, storage, "## StorageClass\n\n| Name | Value | Description |\n| --- | --- | --- |\n"+
+		"| Standard | <code>&#34;standard&#34;</code> |  |\n|  | <code>&#34;archive&#34;</code> | Cold storage. |\n")
+}
+
+func TestGeneratePackageHTML(t *testing.T) {
+	files, err := GeneratePackage("test-tool", importTestSchema(t), HTML)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	storage := string(files["storage/index.html"])
+	assert.Contains(t, storage, "<!DOCTYPE html>\n<!-- *** WARNING: this file was generated by test-tool. *** -->\n")
+	assert.Contains(t, storage, "<title>test/storage</title>")
+	assert.Contains(t, storage, `<h2 id="bucket">Bucket</h2>`)
+	assert.Contains(t, storage, "<table>")
+
+	// Links to child modules refer to their HTML pages.
+	assert.Contains(t, string(files["index.html"]), `<a href="storage/index.html">storage</a>`)
+}
+
+func TestGeneratePackageUnsupportedFormat(t *testing.T) {
+	_, err := GeneratePackage("test-tool", importTestSchema(t), Format("pdf"))
+	assert.EqualError(t, err, "unsupported documentation format 'pdf'")
+}
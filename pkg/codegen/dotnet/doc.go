@@ -0,0 +1,121 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnet
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// DocLanguageHelper describes the members of a package as they appear in the package's generated .NET SDK.
+type DocLanguageHelper struct {
+	pkg           *schema.Package
+	modules       map[string]*modContext
+	propertyNames map[*schema.Property]string
+}
+
+// NewDocLanguageHelper creates a DocLanguageHelper for the given package.
+func NewDocLanguageHelper(pkg *schema.Package) (*DocLanguageHelper, error) {
+	modules, _, err := generateModuleContextMap("", pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Property names are computed for the package as a whole and shared by all of its modules.
+	propertyNames := map[*schema.Property]string{}
+	for _, mod := range modules {
+		propertyNames = mod.propertyNames
+		break
+	}
+	return &DocLanguageHelper{pkg: pkg, modules: modules, propertyNames: propertyNames}, nil
+}
+
+func (d *DocLanguageHelper) getMod(token string) *modContext {
+	mod, ok := d.modules[d.pkg.TokenToModule(token)]
+	contract.Assertf(ok, "no module for token %v", token)
+	return mod
+}
+
+// ResourceSignature returns the signature of the given resource's constructor.
+func (d *DocLanguageHelper) ResourceSignature(r *schema.Resource) string {
+	name := resourceName(r)
+
+	argsType := name + "Args"
+	var argsDefault string
+	allOptionalInputs := true
+	for _, prop := range r.InputProperties {
+		allOptionalInputs = allOptionalInputs && !prop.IsRequired
+	}
+	if allOptionalInputs {
+		argsType, argsDefault = argsType+"?", " = null"
+	}
+
+	optionsType := "CustomResourceOptions"
+	if r.IsProvider {
+		optionsType = "ResourceOptions"
+	}
+	return fmt.Sprintf("new %s(string name, %s args%s, %s? options = null)", name, argsType, argsDefault, optionsType)
+}
+
+// FunctionSignature returns the signature of the given function.
+func (d *DocLanguageHelper) FunctionSignature(f *schema.Function) string {
+	methodName := tokenToName(f.Token)
+
+	retty := "Task"
+	if f.Outputs != nil {
+		retty = fmt.Sprintf("Task<%sResult>", methodName)
+	}
+
+	var argsParamDef string
+	if f.Inputs != nil {
+		allOptionalInputs := true
+		for _, prop := range f.Inputs.Properties {
+			allOptionalInputs = allOptionalInputs && !prop.IsRequired
+		}
+
+		var argsDefault, sigil string
+		if allOptionalInputs {
+			argsDefault, sigil = " = null", "?"
+		}
+		argsParamDef = fmt.Sprintf("%sArgs%s args%s, ", methodName, sigil, argsDefault)
+	}
+	return fmt.Sprintf("%s Invokes.%s(%sInvokeOptions? options = null)", retty, methodName, argsParamDef)
+}
+
+// PropertyName returns the name of the given property.
+func (d *DocLanguageHelper) PropertyName(p *schema.Property) string {
+	if n, ok := d.propertyNames[p]; ok {
+		return n
+	}
+	return title(p.Name)
+}
+
+// PropertyType returns the type of the given property as seen from the namespace that contains the member identified
+// by the given token.  Inputs are wrapped in Input and outputs in Output unless plain is true, as it is for the
+// arguments and results of functions.
+func (d *DocLanguageHelper) PropertyType(token string, p *schema.Property, input, plain bool) string {
+	mod := d.getMod(token)
+	if input {
+		return mod.typeString(p.Type, "Inputs", true, false, !plain, false, !p.IsRequired)
+	}
+
+	typ := mod.typeString(p.Type, "Outputs", false, false, false, false, !p.IsRequired)
+	if !plain {
+		typ = fmt.Sprintf("Output<%s>", typ)
+	}
+	return typ
+}
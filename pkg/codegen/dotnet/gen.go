@@ -92,6 +92,9 @@ func csharpIdentifier(s string) string {
 }
 
 func isValueType(t schema.Type) bool {
+	if _, ok := t.(*schema.EnumType); ok {
+		return true
+	}
 	switch t {
 	case schema.BoolType, schema.IntType, schema.NumberType:
 		return true
@@ -112,6 +115,7 @@ type modContext struct {
 	mod           string
 	propertyNames map[*schema.Property]string
 	types         []*schema.ObjectType
+	enums         []*schema.EnumType
 	resources     []*schema.Resource
 	functions     []*schema.Function
 	typeDetails   map[*schema.ObjectType]*typeDetails
@@ -213,6 +217,11 @@ func (mod *modContext) typeString(t schema.Type, qualifier string, input, state,
 		case mod.details(t).functionType:
 			typ += "Result"
 		}
+	case *schema.EnumType:
+		typ = tokenToName(t.Token)
+		if ns := mod.tokenToNamespace(t.Token); ns != mod.namespaceName {
+			typ = ns + "." + typ
+		}
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -484,6 +493,19 @@ func primitiveValue(value interface{}) (string, error) {
 }
 
 func (mod *modContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (string, error) {
+	if enum, ok := t.(*schema.EnumType); ok {
+		// Enum values may only be constructed by the enum type itself, so defaults refer to its members.
+		if len(dv.Environment) != 0 {
+			return "", errors.Errorf("environment defaults are not supported for enum properties")
+		}
+		for _, e := range enum.Elements {
+			if e.Value == dv.Value {
+				return mod.typeString(enum, "", false, false, false, false, false) + "." + enumMemberName(e), nil
+			}
+		}
+		return "", errors.Errorf("invalid default value %v for enum %s", dv.Value, enum.Token)
+	}
+
 	var val string
 	if dv.Value != nil {
 		v, err := primitiveValue(dv.Value)
@@ -520,6 +542,95 @@ func (mod *modContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 	return val, nil
 }
 
+// enumMemberName returns the name of the static property for the given enum value. Values without an explicit name
+// are named after their value.
+func enumMemberName(e *schema.Enum) string {
+	name := e.Name
+	if name == "" {
+		name = fmt.Sprintf("%v", e.Value)
+	}
+
+	// Strip any characters that may not appear in identifiers, treating them as word separators.
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	name = b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return name
+}
+
+func (mod *modContext) genEnum(w io.Writer, enum *schema.EnumType) {
+	indent := "    "
+	name := tokenToName(enum.Token)
+	underlyingType := mod.typeString(enum.ElementType, "", false, false, false, false, false)
+
+	fmt.Fprintf(w, "\n")
+	printComment(w, enum.Comment, indent)
+	fmt.Fprintf(w, "%s[EnumType]\n", indent)
+	fmt.Fprintf(w, "%spublic readonly struct %[2]s : IEquatable<%[2]s>\n", indent, name)
+	fmt.Fprintf(w, "%s{\n", indent)
+	fmt.Fprintf(w, "%s    private readonly %s _value;\n\n", indent, underlyingType)
+
+	fmt.Fprintf(w, "%s    private %s(%s value)\n", indent, name, underlyingType)
+	fmt.Fprintf(w, "%s    {\n", indent)
+	if enum.ElementType == schema.StringType {
+		fmt.Fprintf(w, "%s        _value = value ?? throw new ArgumentNullException(nameof(value));\n", indent)
+	} else {
+		fmt.Fprintf(w, "%s        _value = value;\n", indent)
+	}
+	fmt.Fprintf(w, "%s    }\n\n", indent)
+
+	for _, e := range enum.Elements {
+		value, err := primitiveValue(e.Value)
+		contract.Assert(err == nil)
+
+		printComment(w, e.Comment, indent+"    ")
+		if e.DeprecationMessage != "" {
+			fmt.Fprintf(w, "%s    [Obsolete(@\"%s\")]\n", indent, strings.Replace(e.DeprecationMessage, `"`, `""`, -1))
+		}
+		fmt.Fprintf(w, "%s    public static %s %s { get; } = new %s(%s);\n", indent, name, enumMemberName(e), name, value)
+	}
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "%s    public static bool operator ==(%[2]s left, %[2]s right) => left.Equals(right);\n", indent, name)
+	fmt.Fprintf(w, "%s    public static bool operator !=(%[2]s left, %[2]s right) => !left.Equals(right);\n\n", indent, name)
+
+	fmt.Fprintf(w, "%s    public static explicit operator %s(%s value) => value._value;\n\n", indent, underlyingType, name)
+
+	fmt.Fprintf(w, "%s    [EditorBrowsable(EditorBrowsableState.Never)]\n", indent)
+	fmt.Fprintf(w, "%s    public override bool Equals(object? obj) => obj is %s other && Equals(other);\n", indent, name)
+	if enum.ElementType == schema.StringType {
+		fmt.Fprintf(w, "%s    public bool Equals(%s other) => string.Equals(_value, other._value, StringComparison.Ordinal);\n\n",
+			indent, name)
+
+		fmt.Fprintf(w, "%s    [EditorBrowsable(EditorBrowsableState.Never)]\n", indent)
+		fmt.Fprintf(w, "%s    public override int GetHashCode() => _value?.GetHashCode() ?? 0;\n\n", indent)
+
+		fmt.Fprintf(w, "%s    public override string ToString() => _value;\n", indent)
+	} else {
+		fmt.Fprintf(w, "%s    public bool Equals(%s other) => _value == other._value;\n\n", indent, name)
+
+		fmt.Fprintf(w, "%s    [EditorBrowsable(EditorBrowsableState.Never)]\n", indent)
+		fmt.Fprintf(w, "%s    public override int GetHashCode() => _value.GetHashCode();\n\n", indent)
+
+		fmt.Fprintf(w, "%s    public override string ToString() => _value.ToString();\n", indent)
+	}
+	fmt.Fprintf(w, "%s}\n", indent)
+}
+
 func genAlias(w io.Writer, alias *schema.Alias) {
 	fmt.Fprintf(w, "new Alias { ")
 
@@ -1059,6 +1170,21 @@ func (mod *modContext) gen(fs fs) error {
 		}
 	}
 
+	// Enums
+	if len(mod.enums) > 0 {
+		buffer := &bytes.Buffer{}
+		mod.genHeader(buffer, []string{"System", "System.ComponentModel", "Pulumi"})
+
+		fmt.Fprintf(buffer, "namespace %s\n", mod.namespaceName)
+		fmt.Fprintf(buffer, "{")
+		for _, e := range mod.enums {
+			mod.genEnum(buffer, e)
+		}
+		fmt.Fprintf(buffer, "}\n")
+
+		addFile("Enums.cs", buffer.String())
+	}
+
 	// Resources
 	for _, r := range mod.resources {
 		buffer := &bytes.Buffer{}
@@ -1207,45 +1333,46 @@ type csharpPackageInfo struct {
 	Namespaces        map[string]string `json:"namespaces,omitempty"`
 }
 
-func GeneratePackage(tool string, pkg *schema.Package, extraFiles map[string][]byte) (map[string][]byte, error) {
+// generateModuleContextMap groups the resources, types, and functions of the given package into namespaces.
+func generateModuleContextMap(tool string, pkg *schema.Package) (map[string]*modContext, *csharpPackageInfo, error) {
 	// Decode csharp-specific info
 	var info csharpPackageInfo
 	if csharp, ok := pkg.Language["csharp"]; ok {
 		if err := json.Unmarshal([]byte(csharp), &info); err != nil {
-			return nil, errors.Wrap(err, "decoding csharp package info")
+			return nil, nil, errors.Wrap(err, "decoding csharp package info")
 		}
 	}
 
 	propertyNames := map[*schema.Property]string{}
 	for _, r := range pkg.Resources {
 		if err := computePropertyNames(r.Properties, propertyNames); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if err := computePropertyNames(r.InputProperties, propertyNames); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if r.StateInputs != nil {
 			if err := computePropertyNames(r.StateInputs.Properties, propertyNames); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
 	for _, f := range pkg.Functions {
 		if f.Inputs != nil {
 			if err := computePropertyNames(f.Inputs.Properties, propertyNames); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 		if f.Outputs != nil {
 			if err := computePropertyNames(f.Outputs.Properties, propertyNames); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
 	for _, t := range pkg.Types {
 		if obj, ok := t.(*schema.ObjectType); ok {
 			if err := computePropertyNames(obj.Properties, propertyNames); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
@@ -1347,12 +1474,26 @@ func GeneratePackage(tool string, pkg *schema.Package, extraFiles map[string][]b
 
 	// Find nested types.
 	for _, t := range pkg.Types {
-		if obj, ok := t.(*schema.ObjectType); ok {
-			mod := getMod(obj.Token)
-			mod.types = append(mod.types, obj)
+		switch t := t.(type) {
+		case *schema.ObjectType:
+			mod := getMod(t.Token)
+			mod.types = append(mod.types, t)
+		case *schema.EnumType:
+			mod := getMod(t.Token)
+			mod.enums = append(mod.enums, t)
 		}
 	}
 
+	return modules, &info, nil
+}
+
+func GeneratePackage(tool string, pkg *schema.Package, extraFiles map[string][]byte) (map[string][]byte, error) {
+	modules, info, err := generateModuleContextMap(tool, pkg)
+	if err != nil {
+		return nil, err
+	}
+	assemblyName := "Pulumi." + namespaceName(info.Namespaces, pkg.Name)
+
 	// Generate each module.
 	files := fs{}
 	for p, f := range extraFiles {
@@ -0,0 +1,91 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+// DocLanguageHelper describes the members of a package as they appear in the package's generated Go SDK.
+type DocLanguageHelper struct {
+	pkg      *schema.Package
+	packages map[string]*pkgContext
+}
+
+// NewDocLanguageHelper creates a DocLanguageHelper for the given package.
+func NewDocLanguageHelper(pkg *schema.Package) *DocLanguageHelper {
+	return &DocLanguageHelper{pkg: pkg, packages: generatePackageContextMap("", pkg)}
+}
+
+func (d *DocLanguageHelper) getPkg(token string) *pkgContext {
+	mod := d.pkg.TokenToModule(token)
+	if p, ok := d.packages[mod]; ok {
+		return p
+	}
+	return &pkgContext{pkg: d.pkg, mod: mod, typeDetails: map[schema.Type]*typeDetails{}, names: stringSet{}}
+}
+
+// ResourceSignature returns the signature of the function that creates the given resource.
+func (d *DocLanguageHelper) ResourceSignature(r *schema.Resource) string {
+	name := resourceName(r)
+	return fmt.Sprintf("func New%[1]s(ctx *pulumi.Context, name string, args *%[1]sArgs, "+
+		"opts ...pulumi.ResourceOption) (*%[1]s, error)", name)
+}
+
+// FunctionSignature returns the signature of the given function.
+func (d *DocLanguageHelper) FunctionSignature(f *schema.Function) string {
+	name := d.FunctionName(f)
+
+	argsig := "ctx *pulumi.Context"
+	if f.Inputs != nil {
+		argsig = fmt.Sprintf("%s, args *%sArgs", argsig, name)
+	}
+	retty := "error"
+	if f.Outputs != nil {
+		retty = fmt.Sprintf("(*%sResult, error)", name)
+	}
+	return fmt.Sprintf("func %s(%s, opts ...pulumi.InvokeOption) %s", name, argsig, retty)
+}
+
+// FunctionName returns the name of the given function, which may differ from the name in its token in order to avoid
+// conflicts with the functions generated for resources.
+func (d *DocLanguageHelper) FunctionName(f *schema.Function) string {
+	if name, ok := d.getPkg(f.Token).functionNames[f]; ok {
+		return name
+	}
+	return tokenToName(f.Token)
+}
+
+// PropertyName returns the name of the given property.
+func (d *DocLanguageHelper) PropertyName(p *schema.Property) string {
+	return title(p.Name)
+}
+
+// PropertyType returns the type of the given property as seen from the package that contains the member identified
+// by the given token.  Inputs are typed as Input types and outputs as Output types unless plain is true, as it is
+// for the arguments and results of functions.
+func (d *DocLanguageHelper) PropertyType(token string, p *schema.Property, input, plain bool) string {
+	ctx := d.getPkg(token)
+	switch {
+	case plain:
+		return ctx.plainType(p.Type, !p.IsRequired)
+	case input:
+		return ctx.inputType(p.Type, !p.IsRequired)
+	default:
+		return ctx.outputType(p.Type, !p.IsRequired)
+	}
+}
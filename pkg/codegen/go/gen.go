@@ -86,8 +86,9 @@ func camel(s string) string {
 type pkgContext struct {
 	pkg           *schema.Package
 	mod           string
-	typeDetails   map[*schema.ObjectType]*typeDetails
+	typeDetails   map[schema.Type]*typeDetails
 	types         []*schema.ObjectType
+	enums         []*schema.EnumType
 	resources     []*schema.Resource
 	functions     []*schema.Function
 	names         stringSet
@@ -96,7 +97,7 @@ type pkgContext struct {
 	tool          string
 }
 
-func (pkg *pkgContext) details(t *schema.ObjectType) *typeDetails {
+func (pkg *pkgContext) details(t schema.Type) *typeDetails {
 	details, ok := pkg.typeDetails[t]
 	if !ok {
 		details = &typeDetails{}
@@ -143,8 +144,8 @@ func (pkg *pkgContext) plainType(t schema.Type, optional bool) string {
 		return "[]" + pkg.plainType(t.ElementType, false)
 	case *schema.MapType:
 		return "map[string]" + pkg.plainType(t.ElementType, false)
-	case *schema.ObjectType:
-		typ = pkg.tokenToType(t.Token)
+	case *schema.ObjectType, *schema.EnumType:
+		typ = pkg.tokenToType(t.String())
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -188,8 +189,8 @@ func (pkg *pkgContext) inputType(t schema.Type, optional bool) string {
 	case *schema.MapType:
 		en := pkg.inputType(t.ElementType, false)
 		return strings.TrimSuffix(en, "Input") + "MapInput"
-	case *schema.ObjectType:
-		typ = pkg.tokenToType(t.Token)
+	case *schema.ObjectType, *schema.EnumType:
+		typ = pkg.tokenToType(t.String())
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -239,8 +240,8 @@ func (pkg *pkgContext) outputType(t schema.Type, optional bool) string {
 			return "pulumi.MapOutput"
 		}
 		return en + "MapOutput"
-	case *schema.ObjectType:
-		typ = pkg.tokenToType(t.Token)
+	case *schema.ObjectType, *schema.EnumType:
+		typ = pkg.tokenToType(t.String())
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -509,8 +510,13 @@ func (pkg *pkgContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 	if len(dv.Environment) > 0 {
 		pkg.needsUtils = true
 
+		elementType := t
+		if enum, ok := t.(*schema.EnumType); ok {
+			elementType = enum.ElementType
+		}
+
 		parser, typDefault, typ := "nil", "\"\"", "string"
-		switch t {
+		switch elementType {
 		case schema.BoolType:
 			parser, typDefault, typ = "parseEnvBool", "false", "bool"
 		case schema.IntType:
@@ -528,6 +534,9 @@ func (pkg *pkgContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 			val += fmt.Sprintf(", %q", e)
 		}
 		val = fmt.Sprintf("%s).(%s)", val, typ)
+		if enum, ok := t.(*schema.EnumType); ok {
+			val = fmt.Sprintf("%s(%s)", pkg.tokenToType(enum.Token), val)
+		}
 	}
 
 	return val, nil
@@ -739,6 +748,167 @@ func (pkg *pkgContext) genInitFn(w io.Writer, types []*schema.ObjectType) {
 	fmt.Fprintf(w, "}\n")
 }
 
+// namedType returns the token of the given type if it is an object or enum type.
+func namedType(t schema.Type) (string, bool) {
+	switch t := t.(type) {
+	case *schema.ObjectType:
+		return t.Token, true
+	case *schema.EnumType:
+		return t.Token, true
+	default:
+		return "", false
+	}
+}
+
+// enumValueName returns the name of the Go constant for the given value of the given enum type. Values without an
+// explicit name are named after their value.
+func enumValueName(typeName string, e *schema.Enum) string {
+	name := e.Name
+	if name == "" {
+		name = fmt.Sprintf("%v", e.Value)
+	}
+
+	// Strip any characters that may not appear in identifiers, treating them as word separators.
+	var b strings.Builder
+	b.WriteString(typeName)
+	upper := true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+func (pkg *pkgContext) genEnum(w io.Writer, enum *schema.EnumType) {
+	name := pkg.tokenToType(enum.Token)
+	elementType := pkg.plainType(enum.ElementType, false)
+	details := pkg.details(enum)
+
+	printComment(w, enum.Comment, false)
+	fmt.Fprintf(w, "type %s %s\n\n", name, elementType)
+
+	fmt.Fprintf(w, "const (\n")
+	for _, e := range enum.Elements {
+		value, err := goPrimitiveValue(e.Value)
+		contract.Assert(err == nil)
+
+		printComment(w, e.Comment, true)
+		if e.DeprecationMessage != "" {
+			fmt.Fprintf(w, "\t//\n")
+			fmt.Fprintf(w, "\t// Deprecated: %s\n", e.DeprecationMessage)
+		}
+		fmt.Fprintf(w, "\t%s = %s(%s)\n", enumValueName(name, e), name, value)
+	}
+	fmt.Fprintf(w, ")\n\n")
+
+	// The enum type itself is an input.
+	genInputInterface(w, name)
+	genInputMethods(w, name, name, name, details.ptrElement)
+
+	fmt.Fprintf(w, "type %sOutput struct { *pulumi.OutputState }\n\n", name)
+
+	genOutputMethods(w, name, name)
+	if details.ptrElement {
+		fmt.Fprintf(w, "func (o %[1]sOutput) To%[2]sPtrOutput() %[1]sPtrOutput {\n", name, title(name))
+		fmt.Fprintf(w, "\treturn o.To%sPtrOutputWithContext(context.Background())\n", title(name))
+		fmt.Fprintf(w, "}\n\n")
+
+		fmt.Fprintf(w, "func (o %[1]sOutput) To%[2]sPtrOutputWithContext(ctx context.Context) %[1]sPtrOutput {\n", name, title(name))
+		fmt.Fprintf(w, "\treturn o.ApplyT(func(v %[1]s) *%[1]s {\n", name)
+		fmt.Fprintf(w, "\t\treturn &v\n")
+		fmt.Fprintf(w, "\t}).(%sPtrOutput)\n", name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	// Generate the pointer input and output.
+	if details.ptrElement {
+		genInputInterface(w, name+"Ptr")
+
+		ptrTypeName := camel(name) + "Ptr"
+
+		fmt.Fprintf(w, "type %s %s\n\n", ptrTypeName, name)
+
+		fmt.Fprintf(w, "func %[1]sPtr(v %[1]s) %[1]sPtrInput {\n", name)
+		fmt.Fprintf(w, "\treturn (*%s)(&v)\n", ptrTypeName)
+		fmt.Fprintf(w, "}\n\n")
+
+		genInputMethods(w, name+"Ptr", "*"+ptrTypeName, "*"+name, false)
+
+		fmt.Fprintf(w, "type %sPtrOutput struct { *pulumi.OutputState }\n\n", name)
+
+		genOutputMethods(w, name+"Ptr", "*"+name)
+
+		fmt.Fprintf(w, "func (o %[1]sPtrOutput) Elem() %[1]sOutput {\n", name)
+		fmt.Fprintf(w, "\treturn o.ApplyT(func (v *%[1]s) %[1]s { return *v }).(%[1]sOutput)\n", name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	// Generate the array input and output.
+	if details.arrayElement {
+		genInputInterface(w, name+"Array")
+
+		fmt.Fprintf(w, "type %[1]sArray []%[1]sInput\n\n", name)
+
+		genInputMethods(w, name+"Array", name+"Array", "[]"+name, false)
+
+		fmt.Fprintf(w, "type %sArrayOutput struct { *pulumi.OutputState }\n\n", name)
+
+		genOutputMethods(w, name+"Array", "[]"+name)
+
+		fmt.Fprintf(w, "func (o %[1]sArrayOutput) Index(i pulumi.IntInput) %[1]sOutput {\n", name)
+		fmt.Fprintf(w, "\treturn pulumi.All(o, i).ApplyT(func (vs []interface{}) %s {\n", name)
+		fmt.Fprintf(w, "\t\treturn vs[0].([]%s)[vs[1].(int)]\n", name)
+		fmt.Fprintf(w, "\t}).(%sOutput)\n", name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	// Generate the map input and output.
+	if details.mapElement {
+		genInputInterface(w, name+"Map")
+
+		fmt.Fprintf(w, "type %[1]sMap map[string]%[1]sInput\n\n", name)
+
+		genInputMethods(w, name+"Map", name+"Map", "map[string]"+name, false)
+
+		fmt.Fprintf(w, "type %sMapOutput struct { *pulumi.OutputState }\n\n", name)
+
+		genOutputMethods(w, name+"Map", "map[string]"+name)
+
+		fmt.Fprintf(w, "func (o %[1]sMapOutput) MapIndex(k pulumi.StringInput) %[1]sOutput {\n", name)
+		fmt.Fprintf(w, "\treturn pulumi.All(o, k).ApplyT(func (vs []interface{}) %s {\n", name)
+		fmt.Fprintf(w, "\t\treturn vs[0].(map[string]%s)[vs[1].(string)]\n", name)
+		fmt.Fprintf(w, "\t}).(%sOutput)\n", name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
+
+func (pkg *pkgContext) genEnumInitFn(w io.Writer, enums []*schema.EnumType) {
+	fmt.Fprintf(w, "func init() {\n")
+	for _, enum := range enums {
+		name, details := pkg.tokenToType(enum.Token), pkg.details(enum)
+
+		fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sOutput{})\n", name)
+		if details.ptrElement {
+			fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sPtrOutput{})\n", name)
+		}
+		if details.arrayElement {
+			fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sArrayOutput{})\n", name)
+		}
+		if details.mapElement {
+			fmt.Fprintf(w, "\tpulumi.RegisterOutputType(%sMapOutput{})\n", name)
+		}
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
 func (pkg *pkgContext) getTypeImports(t schema.Type, imports stringSet) {
 	switch t := t.(type) {
 	case *schema.ArrayType:
@@ -754,6 +924,11 @@ func (pkg *pkgContext) getTypeImports(t schema.Type, imports stringSet) {
 		for _, p := range t.Properties {
 			pkg.getTypeImports(p.Type, imports)
 		}
+	case *schema.EnumType:
+		mod := pkg.pkg.TokenToModule(t.Token)
+		if mod != pkg.mod {
+			imports.add(path.Join(pkg.pkg.Repository, mod))
+		}
 	case *schema.UnionType:
 		// TODO(pdg): union types
 	}
@@ -879,8 +1054,8 @@ func (pkg *pkgContext) genConfig(w io.Writer, variables []*schema.Property) erro
 	return nil
 }
 
-func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error) {
-	// group resources, types, and functions into Go packages
+// generatePackageContextMap groups the resources, types, and functions of the given package into Go packages.
+func generatePackageContextMap(tool string, pkg *schema.Package) map[string]*pkgContext {
 	packages := map[string]*pkgContext{}
 	getPkg := func(token string) *pkgContext {
 		mod := pkg.TokenToModule(token)
@@ -889,7 +1064,7 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			pack = &pkgContext{
 				pkg:           pkg,
 				mod:           mod,
-				typeDetails:   map[*schema.ObjectType]*typeDetails{},
+				typeDetails:   map[schema.Type]*typeDetails{},
 				names:         stringSet{},
 				functionNames: map[*schema.Function]string{},
 				tool:          tool,
@@ -902,22 +1077,25 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 	for _, t := range pkg.Types {
 		switch t := t.(type) {
 		case *schema.ArrayType:
-			if obj, ok := t.ElementType.(*schema.ObjectType); ok {
-				getPkg(obj.Token).details(obj).arrayElement = true
+			if tok, ok := namedType(t.ElementType); ok {
+				getPkg(tok).details(t.ElementType).arrayElement = true
 			}
 		case *schema.MapType:
-			if obj, ok := t.ElementType.(*schema.ObjectType); ok {
-				getPkg(obj.Token).details(obj).mapElement = true
+			if tok, ok := namedType(t.ElementType); ok {
+				getPkg(tok).details(t.ElementType).mapElement = true
 			}
 		case *schema.ObjectType:
 			pkg := getPkg(t.Token)
 			pkg.types = append(pkg.types, t)
 
 			for _, p := range t.Properties {
-				if obj, ok := p.Type.(*schema.ObjectType); ok && !p.IsRequired {
-					getPkg(obj.Token).details(obj).ptrElement = true
+				if tok, ok := namedType(p.Type); ok && !p.IsRequired {
+					getPkg(tok).details(p.Type).ptrElement = true
 				}
 			}
+		case *schema.EnumType:
+			pkg := getPkg(t.Token)
+			pkg.enums = append(pkg.enums, t)
 		}
 	}
 
@@ -936,13 +1114,13 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 		}
 
 		for _, p := range r.InputProperties {
-			if obj, ok := p.Type.(*schema.ObjectType); ok && (!r.IsProvider || !p.IsRequired) {
-				getPkg(obj.Token).details(obj).ptrElement = true
+			if tok, ok := namedType(p.Type); ok && (!r.IsProvider || !p.IsRequired) {
+				getPkg(tok).details(p.Type).ptrElement = true
 			}
 		}
 		for _, p := range r.Properties {
-			if obj, ok := p.Type.(*schema.ObjectType); ok && (!r.IsProvider || !p.IsRequired) {
-				getPkg(obj.Token).details(obj).ptrElement = true
+			if tok, ok := namedType(p.Type); ok && (!r.IsProvider || !p.IsRequired) {
+				getPkg(tok).details(p.Type).ptrElement = true
 			}
 		}
 	}
@@ -976,6 +1154,13 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 		}
 	}
 
+	return packages
+}
+
+func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error) {
+	// group resources, types, and functions into Go packages
+	packages := generatePackageContextMap(tool, pkg)
+
 	// emit each package
 	var pkgMods []string
 	for mod := range packages {
@@ -1069,6 +1254,20 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			setFile(path.Join(mod, "pulumiTypes.go"), buffer.String())
 		}
 
+		// Enums
+		if len(pkg.enums) > 0 {
+			buffer := &bytes.Buffer{}
+			pkg.genHeader(buffer, []string{"context", "reflect"}, newStringSet("github.com/pulumi/pulumi/sdk/go/pulumi"))
+
+			for _, e := range pkg.enums {
+				pkg.genEnum(buffer, e)
+			}
+
+			pkg.genEnumInitFn(buffer, pkg.enums)
+
+			setFile(path.Join(mod, "pulumiEnums.go"), buffer.String())
+		}
+
 		// Utilities
 		if pkg.needsUtils {
 			buffer := &bytes.Buffer{}
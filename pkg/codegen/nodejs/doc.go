@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+// DocLanguageHelper describes the members of a package as they appear in the package's generated Node.js SDK.
+type DocLanguageHelper struct {
+	mod *modContext
+}
+
+// NewDocLanguageHelper creates a DocLanguageHelper for the given package.
+func NewDocLanguageHelper(pkg *schema.Package) *DocLanguageHelper {
+	return &DocLanguageHelper{mod: &modContext{pkg: pkg}}
+}
+
+// ResourceSignature returns the signature of the given resource's constructor.
+func (d *DocLanguageHelper) ResourceSignature(r *schema.Resource) string {
+	name := resourceName(r)
+
+	argsFlags := "?"
+	for _, p := range r.InputProperties {
+		if p.IsRequired {
+			argsFlags = ""
+			break
+		}
+	}
+	optionsType := "CustomResourceOptions"
+	if r.IsProvider {
+		optionsType = "ResourceOptions"
+	}
+	return fmt.Sprintf("new %[1]s(name: string, args%[2]s: %[1]sArgs, opts?: pulumi.%[3]s)", name, argsFlags, optionsType)
+}
+
+// FunctionSignature returns the signature of the given function.
+func (d *DocLanguageHelper) FunctionSignature(f *schema.Function) string {
+	name := camel(tokenToName(f.Token))
+
+	var argsig string
+	if f.Inputs != nil {
+		optFlag := "?"
+		for _, p := range f.Inputs.Properties {
+			if p.IsRequired {
+				optFlag = ""
+				break
+			}
+		}
+		argsig = fmt.Sprintf("args%s: %sArgs, ", optFlag, title(name))
+	}
+	retty := "void"
+	if f.Outputs != nil {
+		retty = title(name) + "Result"
+	}
+	return fmt.Sprintf("function %s(%sopts?: pulumi.InvokeOptions): Promise<%s>", name, argsig, retty)
+}
+
+// PropertyName returns the name of the given property.
+func (d *DocLanguageHelper) PropertyName(p *schema.Property) string {
+	return p.Name
+}
+
+// PropertyType returns the type of the given property.  Inputs are wrapped in pulumi.Input and outputs in
+// pulumi.Output unless plain is true, as it is for the arguments and results of functions.
+func (d *DocLanguageHelper) PropertyType(token string, p *schema.Property, input, plain bool) string {
+	typ := d.mod.typeString(p.Type, input, input && !plain, false)
+	if !input && !plain {
+		typ = fmt.Sprintf("pulumi.Output<%s>", d.mod.typeString(p.Type, false, false, !p.IsRequired))
+	}
+	return typ
+}
@@ -138,11 +138,24 @@ func (mod *modContext) typeString(t schema.Type, input, wrapInput, optional bool
 	var typ string
 	switch t := t.(type) {
 	case *schema.ArrayType:
-		typ = mod.typeString(t.ElementType, input, wrapInput, false) + "[]"
+		elementType := mod.typeString(t.ElementType, input, wrapInput, false)
+		if strings.Contains(elementType, " | ") {
+			elementType = "(" + elementType + ")"
+		}
+		typ = elementType + "[]"
 	case *schema.MapType:
 		typ = fmt.Sprintf("{[key: string]: %v}", mod.typeString(t.ElementType, input, wrapInput, false))
 	case *schema.ObjectType:
 		typ = mod.tokenToType(t.Token, input)
+	case *schema.EnumType:
+		// Enums are represented as unions of their literal values.
+		var values []string
+		for _, e := range t.Elements {
+			v, err := tsPrimitiveValue(e.Value)
+			contract.Assert(err == nil)
+			values = append(values, v)
+		}
+		typ = strings.Join(values, " | ")
 	case *schema.TokenType:
 		typ = tokenToName(t.Token)
 	case *schema.UnionType:
@@ -276,6 +289,9 @@ func (mod *modContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 
 	if len(dv.Environment) != 0 {
 		getType := ""
+		if enum, ok := t.(*schema.EnumType); ok {
+			t = enum.ElementType
+		}
 		switch t {
 		case schema.BoolType:
 			getType = "Boolean"
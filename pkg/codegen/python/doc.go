@@ -0,0 +1,75 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+)
+
+// DocLanguageHelper describes the members of a package as they appear in the package's generated Python SDK.
+type DocLanguageHelper struct{}
+
+// NewDocLanguageHelper creates a DocLanguageHelper for the given package.
+func NewDocLanguageHelper(pkg *schema.Package) *DocLanguageHelper {
+	return &DocLanguageHelper{}
+}
+
+// ResourceSignature returns the signature of the given resource's initializer.
+func (d *DocLanguageHelper) ResourceSignature(r *schema.Resource) string {
+	name := pyClassName(tokenToName(r.Token))
+	if r.IsProvider {
+		name = "Provider"
+	}
+
+	params := []string{"resource_name", "opts=None"}
+	for _, p := range r.InputProperties {
+		params = append(params, PyName(p.Name)+"=None")
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(params, ", "))
+}
+
+// FunctionSignature returns the signature of the given function.
+func (d *DocLanguageHelper) FunctionSignature(f *schema.Function) string {
+	var params []string
+	if f.Inputs != nil {
+		for _, p := range f.Inputs.Properties {
+			params = append(params, PyName(p.Name)+"=None")
+		}
+	}
+	params = append(params, "opts=None")
+	return fmt.Sprintf("def %s(%s)", PyName(tokenToName(f.Token)), strings.Join(params, ", "))
+}
+
+// PropertyName returns the name of the given property.
+func (d *DocLanguageHelper) PropertyName(p *schema.Property) string {
+	return PyName(p.Name)
+}
+
+// PropertyType returns the runtime type of the given property.  Inputs are wrapped in pulumi.Input and outputs in
+// pulumi.Output unless plain is true, as it is for the arguments and results of functions.
+func (d *DocLanguageHelper) PropertyType(token string, p *schema.Property, input, plain bool) string {
+	typ := pyType(p.Type)
+	switch {
+	case plain:
+		return typ
+	case input:
+		return fmt.Sprintf("pulumi.Input[%s]", typ)
+	default:
+		return fmt.Sprintf("pulumi.Output[%s]", typ)
+	}
+}
@@ -62,6 +62,7 @@ type modContext struct {
 	mod                  string
 	resources            []*schema.Resource
 	functions            []*schema.Function
+	enums                []*schema.EnumType
 	children             []*modContext
 	snakeCaseToCamelCase map[string]string
 	camelCaseToSnakeCase map[string]string
@@ -188,6 +189,11 @@ func (mod *modContext) gen(fs fs) error {
 		}
 	}
 
+	// Enums
+	if len(mod.enums) > 0 {
+		addFile("_enums.py", mod.genEnums(mod.enums))
+	}
+
 	// Resources
 	for _, r := range mod.resources {
 		res, err := mod.genResource(r)
@@ -260,6 +266,72 @@ func (mod *modContext) genInit(exports []string) string {
 	return w.String()
 }
 
+// genEnums emits a module that defines a Python Enum class for each of the given enum types.
+func (mod *modContext) genEnums(enums []*schema.EnumType) string {
+	w := &bytes.Buffer{}
+	mod.genHeader(w, false)
+
+	fmt.Fprintf(w, "from enum import Enum\n")
+
+	for _, enum := range enums {
+		// Mix the element type into the class so that the enum's values may be used wherever a value of that type is
+		// expected. Python's bool may not be subclassed, so boolean enums are plain Enums.
+		base := "Enum"
+		switch enum.ElementType {
+		case schema.IntType:
+			base = "int, Enum"
+		case schema.NumberType:
+			base = "float, Enum"
+		case schema.StringType:
+			base = "str, Enum"
+		}
+
+		fmt.Fprintf(w, "\n\n")
+		fmt.Fprintf(w, "class %s(%s):\n", pyClassName(tokenToName(enum.Token)), base)
+		printComment(w, enum.Comment, "    ")
+		for _, e := range enum.Elements {
+			value, err := getPrimitiveValue(e.Value)
+			contract.Assert(err == nil)
+
+			fmt.Fprintf(w, "    %s = %s\n", enumMemberName(e), value)
+
+			comment := e.Comment
+			if e.DeprecationMessage != "" {
+				if comment != "" {
+					comment += "\n\n"
+				}
+				comment += "Deprecated: " + e.DeprecationMessage
+			}
+			printComment(w, comment, "    ")
+		}
+	}
+
+	return w.String()
+}
+
+// enumMemberName returns the name of the Python Enum member for the given enum value. Values without an explicit name
+// are named after their value.
+func enumMemberName(e *schema.Enum) string {
+	name := e.Name
+	if name == "" {
+		name = fmt.Sprintf("%v", e.Value)
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	name = b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return EnsureKeywordSafe(name)
+}
+
 // emitConfigVariables emits all config vaiables in the given module, returning the resulting file.
 func (mod *modContext) genConfig(variables []*schema.Property) (string, error) {
 	w := &bytes.Buffer{}
@@ -915,12 +987,14 @@ func (mod *modContext) genGetDocstring(w io.Writer, res *schema.Resource) {
 	fmt.Fprintln(b, ":param str resource_name: The unique name of the resulting resource.")
 	fmt.Fprintln(b, ":param str id: The unique provider ID of the resource to lookup.")
 	fmt.Fprintln(b, ":param pulumi.ResourceOptions opts: Options for the resource.")
-	for _, prop := range res.StateInputs.Properties {
-		mod.genPropDocstring(b, prop, true /*wrapInput*/)
-	}
+	if res.StateInputs != nil {
+		for _, prop := range res.StateInputs.Properties {
+			mod.genPropDocstring(b, prop, true /*wrapInput*/)
+		}
 
-	// Nested structures are typed as `dict` so we include some extra documentation for these structures.
-	mod.genNestedStructuresDocstring(b, res.StateInputs.Properties, true /*wrapInput*/)
+		// Nested structures are typed as `dict` so we include some extra documentation for these structures.
+		mod.genNestedStructuresDocstring(b, res.StateInputs.Properties, true /*wrapInput*/)
+	}
 
 	// printComment handles the prefix and triple quotes.
 	printComment(w, b.String(), "        ")
@@ -1054,6 +1128,8 @@ func pyType(typ schema.Type) string {
 			return pyType(typ.UnderlyingType)
 		}
 		return "dict"
+	case *schema.EnumType:
+		return pyType(typ.ElementType)
 	default:
 		switch typ {
 		case schema.BoolType:
@@ -1126,6 +1202,10 @@ func getDefaultValue(dv *schema.DefaultValue, t schema.Type) (string, error) {
 	}
 
 	if len(dv.Environment) > 0 {
+		if enum, ok := t.(*schema.EnumType); ok {
+			t = enum.ElementType
+		}
+
 		envFunc := "utilities.get_env"
 		switch t {
 		case schema.BoolType:
@@ -1214,6 +1294,13 @@ func GeneratePackage(tool string, pkg *schema.Package, extraFiles map[string][]b
 		mod.functions = append(mod.functions, f)
 	}
 
+	for _, t := range pkg.Types {
+		if enum, ok := t.(*schema.EnumType); ok {
+			mod := getMod(enum.Token)
+			mod.enums = append(mod.enums, enum)
+		}
+	}
+
 	if _, ok := modules["types"]; ok {
 		return nil, errors.New("this provider has a `types` module which is reserved for input/output types")
 	}
@@ -0,0 +1,344 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes the kind of a change between two versions of a package schema.
+type ChangeKind string
+
+const (
+	// ChangeAdded indicates that a member was added.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved indicates that a member was removed.
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeTypeChanged indicates that the type of a member changed.
+	ChangeTypeChanged ChangeKind = "type-changed"
+	// ChangeMadeRequired indicates that an optional property became required.
+	ChangeMadeRequired ChangeKind = "made-required"
+	// ChangeMadeOptional indicates that a required property became optional.
+	ChangeMadeOptional ChangeKind = "made-optional"
+)
+
+// Change describes a single difference between two versions of a package schema.
+type Change struct {
+	// Path identifies the member that changed, e.g. `resources["aws:s3/bucket:Bucket"].inputProperties["acl"]`.
+	Path string `json:"path"`
+	// Kind is the kind of the change.
+	Kind ChangeKind `json:"kind"`
+	// Breaking is true if the change may break programs that were written against the old schema.
+	Breaking bool `json:"breaking"`
+	// Message is a human-readable description of the change.
+	Message string `json:"message"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Path, c.Message)
+}
+
+// HasBreakingChanges returns true if any of the given changes is breaking.
+func HasBreakingChanges(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyRole describes how the values of a set of properties flow between programs and providers, which decides
+// whether a change to the properties is breaking.
+type propertyRole int
+
+const (
+	// roleInput properties are set by programs.
+	roleInput propertyRole = iota
+	// roleOutput properties are read by programs.
+	roleOutput
+	// roleInputOutput properties may be both set and read by programs, as is the case for object types.
+	roleInputOutput
+)
+
+// comparer accumulates the changes between two package schemas.
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) add(path string, kind ChangeKind, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Path:     path,
+		Kind:     kind,
+		Breaking: breaking,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ComparePackageSpecs compares two versions of a package schema and returns the changes from the old version to the
+// new version, sorted by path.  Removed resources, functions, types and properties, changes to the types of
+// properties, and new requirements on inputs are breaking; additions are not.  Descriptions, deprecation messages
+// and language-specific data are not compared.
+func ComparePackageSpecs(old, new PackageSpec) []Change {
+	c := &comparer{}
+
+	c.compareProperties("config", old.Config.Variables, new.Config.Variables, old.Config.Required,
+		new.Config.Required, roleInput)
+
+	c.compareProperties("provider.inputProperties", old.Provider.InputProperties, new.Provider.InputProperties,
+		old.Provider.RequiredInputs, new.Provider.RequiredInputs, roleInput)
+	c.compareProperties("provider.properties", old.Provider.Properties, new.Provider.Properties,
+		old.Provider.Required, new.Provider.Required, roleOutput)
+
+	for _, token := range resourceKeys(old.Resources, new.Resources) {
+		path := memberPath("resources", token)
+		oldRes, inOld := old.Resources[token]
+		newRes, inNew := new.Resources[token]
+		switch {
+		case !inNew:
+			c.add(path, ChangeRemoved, true, "resource was removed")
+		case !inOld:
+			c.add(path, ChangeAdded, false, "resource was added")
+		default:
+			c.compareProperties(path+".inputProperties", oldRes.InputProperties, newRes.InputProperties,
+				oldRes.RequiredInputs, newRes.RequiredInputs, roleInput)
+			c.compareProperties(path+".properties", oldRes.Properties, newRes.Properties,
+				oldRes.Required, newRes.Required, roleOutput)
+		}
+	}
+
+	for _, token := range functionKeys(old.Functions, new.Functions) {
+		path := memberPath("functions", token)
+		oldFn, inOld := old.Functions[token]
+		newFn, inNew := new.Functions[token]
+		switch {
+		case !inNew:
+			c.add(path, ChangeRemoved, true, "function was removed")
+		case !inOld:
+			c.add(path, ChangeAdded, false, "function was added")
+		default:
+			c.compareObjects(path+".inputs", oldFn.Inputs, newFn.Inputs, roleInput)
+			c.compareObjects(path+".outputs", oldFn.Outputs, newFn.Outputs, roleOutput)
+		}
+	}
+
+	for _, token := range typeKeys(old.Types, new.Types) {
+		path := memberPath("types", token)
+		oldType, inOld := old.Types[token]
+		newType, inNew := new.Types[token]
+		switch {
+		case !inNew:
+			c.add(path, ChangeRemoved, true, "type was removed")
+		case !inOld:
+			c.add(path, ChangeAdded, false, "type was added")
+		case len(oldType.Enum) != 0 || len(newType.Enum) != 0:
+			c.compareEnums(path, oldType, newType)
+		default:
+			c.compareProperties(path+".properties", oldType.Properties, newType.Properties,
+				oldType.Required, newType.Required, roleInputOutput)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool { return c.changes[i].Path < c.changes[j].Path })
+	return c.changes
+}
+
+// compareObjects compares the properties of two optional objects, such as the inputs or outputs of a function.
+func (c *comparer) compareObjects(path string, old, new *ObjectTypeSpec, role propertyRole) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case new == nil:
+		c.add(path, ChangeRemoved, true, "%s were removed", roleNoun(role))
+	case old == nil:
+		// Requiring arguments of a function that had none is breaking.
+		breaking := role != roleOutput && len(new.Required) != 0
+		c.add(path, ChangeAdded, breaking, "%s were added", roleNoun(role))
+	default:
+		c.compareProperties(path+".properties", old.Properties, new.Properties, old.Required, new.Required, role)
+	}
+}
+
+// compareProperties compares two sets of properties that play the given role.
+func (c *comparer) compareProperties(path string, old, new map[string]PropertySpec, oldRequired, newRequired []string,
+	role propertyRole) {
+
+	oldReq, newReq := stringSet(oldRequired), stringSet(newRequired)
+	for _, name := range propertyKeys(old, new) {
+		propPath := memberPath(path, name)
+		oldProp, inOld := old[name]
+		newProp, inNew := new[name]
+		switch {
+		case !inNew:
+			c.add(propPath, ChangeRemoved, true, "property was removed")
+		case !inOld:
+			if newReq[name] && role != roleOutput {
+				c.add(propPath, ChangeAdded, true, "required property was added")
+			} else {
+				c.add(propPath, ChangeAdded, false, "property was added")
+			}
+		default:
+			if oldType, newType := typeSpecString(oldProp.TypeSpec), typeSpecString(newProp.TypeSpec); oldType != newType {
+				c.add(propPath, ChangeTypeChanged, true, "type changed from %s to %s", oldType, newType)
+			}
+			switch {
+			case !oldReq[name] && newReq[name]:
+				c.add(propPath, ChangeMadeRequired, role != roleOutput, "property is now required")
+			case oldReq[name] && !newReq[name]:
+				c.add(propPath, ChangeMadeOptional, role != roleInput, "property is now optional")
+			}
+		}
+	}
+}
+
+// compareEnums compares two versions of a type, at least one of which is an enum.
+func (c *comparer) compareEnums(path string, old, new ComplexTypeSpec) {
+	if len(old.Enum) == 0 || len(new.Enum) == 0 || old.Type != new.Type {
+		c.add(path, ChangeTypeChanged, true, "type changed from %s to %s", complexTypeString(old),
+			complexTypeString(new))
+		return
+	}
+
+	oldValues, newValues := map[string]bool{}, map[string]bool{}
+	for _, v := range old.Enum {
+		oldValues[fmt.Sprintf("%#v", v.Value)] = true
+	}
+	for _, v := range new.Enum {
+		newValues[fmt.Sprintf("%#v", v.Value)] = true
+	}
+	for _, v := range old.Enum {
+		if !newValues[fmt.Sprintf("%#v", v.Value)] {
+			c.add(memberPath(path+".enum", fmt.Sprintf("%v", v.Value)), ChangeRemoved, true, "enum value was removed")
+		}
+	}
+	for _, v := range new.Enum {
+		if !oldValues[fmt.Sprintf("%#v", v.Value)] {
+			c.add(memberPath(path+".enum", fmt.Sprintf("%v", v.Value)), ChangeAdded, false, "enum value was added")
+		}
+	}
+}
+
+func roleNoun(role propertyRole) string {
+	switch role {
+	case roleInput:
+		return "inputs"
+	case roleOutput:
+		return "outputs"
+	default:
+		return "properties"
+	}
+}
+
+// memberPath appends the given key to a change path.
+func memberPath(path, key string) string {
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+// typeSpecString returns a readable string that uniquely describes the given type reference.
+func typeSpecString(t TypeSpec) string {
+	switch {
+	case t.Ref != "":
+		return strings.TrimPrefix(t.Ref, "#/types/")
+	case len(t.OneOf) != 0:
+		elements := make([]string, len(t.OneOf))
+		for i, e := range t.OneOf {
+			elements[i] = typeSpecString(e)
+		}
+		return "oneOf<" + strings.Join(elements, ", ") + ">"
+	case t.Type == "array" && t.Items != nil:
+		return "array<" + typeSpecString(*t.Items) + ">"
+	case t.Type == "object" && t.AdditionalProperties != nil:
+		return "map<" + typeSpecString(*t.AdditionalProperties) + ">"
+	default:
+		return t.Type
+	}
+}
+
+// complexTypeString returns a readable string that describes the kind of the given type.
+func complexTypeString(t ComplexTypeSpec) string {
+	if len(t.Enum) != 0 {
+		return "enum<" + t.Type + ">"
+	}
+	return t.Type
+}
+
+// stringSet returns a set that contains the given strings.
+func stringSet(strs []string) map[string]bool {
+	set := map[string]bool{}
+	for _, s := range strs {
+		set[s] = true
+	}
+	return set
+}
+
+// propertyKeys returns the union of the names of the given properties in sorted order.
+func propertyKeys(old, new map[string]PropertySpec) []string {
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+	return sortedSet(keys)
+}
+
+// resourceKeys returns the union of the tokens of the given resources in sorted order.
+func resourceKeys(old, new map[string]ResourceSpec) []string {
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+	return sortedSet(keys)
+}
+
+// functionKeys returns the union of the tokens of the given functions in sorted order.
+func functionKeys(old, new map[string]FunctionSpec) []string {
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+	return sortedSet(keys)
+}
+
+// typeKeys returns the union of the tokens of the given types in sorted order.
+func typeKeys(old, new map[string]ComplexTypeSpec) []string {
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+	return sortedSet(keys)
+}
+
+// sortedSet returns the members of the given set in sorted order.
+func sortedSet(set map[string]bool) []string {
+	sorted := make([]string, 0, len(set))
+	for k := range set {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,242 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	stringProperty  = PropertySpec{TypeSpec: TypeSpec{Type: "string"}}
+	integerProperty = PropertySpec{TypeSpec: TypeSpec{Type: "integer"}}
+)
+
+// testCompareSpec returns a package with a provider, a resource, a function and an object type.
+func testCompareSpec() PackageSpec {
+	return PackageSpec{
+		Name: "test",
+		Provider: ResourceSpec{
+			ObjectTypeSpec: ObjectTypeSpec{
+				Properties: map[string]PropertySpec{"region": stringProperty},
+			},
+			InputProperties: map[string]PropertySpec{"region": stringProperty},
+		},
+		Resources: map[string]ResourceSpec{
+			"test:index:Bucket": {
+				ObjectTypeSpec: ObjectTypeSpec{
+					Properties: map[string]PropertySpec{"arn": stringProperty, "size": integerProperty},
+					Required:   []string{"arn"},
+				},
+				InputProperties: map[string]PropertySpec{"size": integerProperty, "tags": {TypeSpec: TypeSpec{
+					Type:                 "object",
+					AdditionalProperties: &TypeSpec{Type: "string"},
+				}}},
+			},
+			"test:index:Queue": {
+				InputProperties: map[string]PropertySpec{"name": stringProperty},
+				RequiredInputs:  []string{"name"},
+			},
+		},
+		Functions: map[string]FunctionSpec{
+			"test:index:getBucket": {
+				Outputs: &ObjectTypeSpec{Properties: map[string]PropertySpec{"arn": stringProperty}},
+			},
+		},
+		Types: map[string]ComplexTypeSpec{
+			"test:index:Rule": {
+				ObjectTypeSpec: ObjectTypeSpec{
+					Type:       "object",
+					Properties: map[string]PropertySpec{"days": integerProperty},
+				},
+			},
+			"test:index:StorageClass": {
+				ObjectTypeSpec: ObjectTypeSpec{Type: "string"},
+				Enum:           []EnumValueSpec{{Value: "standard"}, {Value: "archive"}},
+			},
+		},
+	}
+}
+
+func TestCompareNoChanges(t *testing.T) {
+	assert.Empty(t, ComparePackageSpecs(testCompareSpec(), testCompareSpec()))
+}
+
+func TestCompareRemovals(t *testing.T) {
+	old, new := testCompareSpec(), testCompareSpec()
+	delete(new.Resources, "test:index:Queue")
+	delete(new.Provider.Properties, "region")
+	delete(new.Provider.InputProperties, "region")
+	delete(new.Resources["test:index:Bucket"].InputProperties, "tags")
+	delete(new.Resources["test:index:Bucket"].Properties, "size")
+	new.Functions["test:index:getBucket"] = FunctionSpec{}
+	delete(new.Types, "test:index:Rule")
+
+	changes := ComparePackageSpecs(old, new)
+	assert.Equal(t, []Change{
+		{
+			Path:     `functions["test:index:getBucket"].outputs`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "outputs were removed",
+		},
+		{
+			Path:     `provider.inputProperties["region"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "property was removed",
+		},
+		{
+			Path:     `provider.properties["region"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "property was removed",
+		},
+		{
+			Path:     `resources["test:index:Bucket"].inputProperties["tags"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "property was removed",
+		},
+		{
+			Path:     `resources["test:index:Bucket"].properties["size"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "property was removed",
+		},
+		{
+			Path:     `resources["test:index:Queue"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "resource was removed",
+		},
+		{
+			Path:     `types["test:index:Rule"]`,
+			Kind:     ChangeRemoved,
+			Breaking: true,
+			Message:  "type was removed",
+		},
+	}, changes)
+	assert.True(t, HasBreakingChanges(changes))
+
+	// The reverse changes are additions, which are not breaking.
+	changes = ComparePackageSpecs(new, old)
+	assert.Len(t, changes, 7)
+	for _, c := range changes {
+		assert.Equal(t, ChangeAdded, c.Kind, c.Path)
+	}
+	assert.False(t, HasBreakingChanges(changes))
+}
+
+func TestCompareTypeChanges(t *testing.T) {
+	old, new := testCompareSpec(), testCompareSpec()
+	new.Provider.Properties["region"] = integerProperty
+	new.Resources["test:index:Bucket"].InputProperties["tags"] = PropertySpec{TypeSpec: TypeSpec{
+		Type:                 "object",
+		AdditionalProperties: &TypeSpec{Type: "integer"},
+	}}
+	new.Types["test:index:Rule"].Properties["days"] = PropertySpec{TypeSpec: TypeSpec{
+		Type:  "array",
+		Items: &TypeSpec{Ref: "#/types/test:index:StorageClass"},
+	}}
+	new.Types["test:index:StorageClass"] = ComplexTypeSpec{ObjectTypeSpec: ObjectTypeSpec{Type: "string"}}
+
+	changes := ComparePackageSpecs(old, new)
+	assert.Equal(t, []Change{
+		{
+			Path:     `provider.properties["region"]`,
+			Kind:     ChangeTypeChanged,
+			Breaking: true,
+			Message:  "type changed from string to integer",
+		},
+		{
+			Path:     `resources["test:index:Bucket"].inputProperties["tags"]`,
+			Kind:     ChangeTypeChanged,
+			Breaking: true,
+			Message:  "type changed from map<string> to map<integer>",
+		},
+		{
+			Path:     `types["test:index:Rule"].properties["days"]`,
+			Kind:     ChangeTypeChanged,
+			Breaking: true,
+			Message:  "type changed from integer to array<test:index:StorageClass>",
+		},
+		{
+			Path:     `types["test:index:StorageClass"]`,
+			Kind:     ChangeTypeChanged,
+			Breaking: true,
+			Message:  "type changed from enum<string> to string",
+		},
+	}, changes)
+}
+
+func TestCompareRequirements(t *testing.T) {
+	old, new := testCompareSpec(), testCompareSpec()
+
+	// Requiring an input is breaking, but requiring an output only strengthens its guarantees.
+	bucket := new.Resources["test:index:Bucket"]
+	bucket.RequiredInputs = []string{"size"}
+	bucket.Required = []string{"arn", "size"}
+	new.Resources["test:index:Bucket"] = bucket
+	new.Provider.Required = []string{"region"}
+
+	// Properties of object types are both inputs and outputs.
+	rule := new.Types["test:index:Rule"]
+	rule.Required = []string{"days"}
+	new.Types["test:index:Rule"] = rule
+
+	changes := ComparePackageSpecs(old, new)
+	assert.Equal(t, []Change{
+		{
+			Path:     `provider.properties["region"]`,
+			Kind:     ChangeMadeRequired,
+			Breaking: false,
+			Message:  "property is now required",
+		},
+		{
+			Path:     `resources["test:index:Bucket"].inputProperties["size"]`,
+			Kind:     ChangeMadeRequired,
+			Breaking: true,
+			Message:  "property is now required",
+		},
+		{
+			Path:     `resources["test:index:Bucket"].properties["size"]`,
+			Kind:     ChangeMadeRequired,
+			Breaking: false,
+			Message:  "property is now required",
+		},
+		{
+			Path:     `types["test:index:Rule"].properties["days"]`,
+			Kind:     ChangeMadeRequired,
+			Breaking: true,
+			Message:  "property is now required",
+		},
+	}, changes)
+
+	// Making an input optional is not breaking, but making an output optional is.
+	changes = ComparePackageSpecs(new, old)
+	breaking := map[string]bool{}
+	for _, c := range changes {
+		assert.Equal(t, ChangeMadeOptional, c.Kind, c.Path)
+		breaking[c.Path] = c.Breaking
+	}
+	assert.Equal(t, map[string]bool{
+		`provider.properties["region"]`:                          true,
+		`resources["test:index:Bucket"].inputProperties["size"]`: false,
+		`resources["test:index:Bucket"].properties["size"]`:      true,
+		`types["test:index:Rule"].properties["days"]`:            true,
+	}, breaking)
+}
@@ -20,6 +20,7 @@ import (
 	"math"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/blang/semver"
@@ -156,6 +157,51 @@ func (t *ObjectType) String() string {
 
 func (*ObjectType) isType() {}
 
+// EnumType represents an enumeration of values of a particular primitive type.
+type EnumType struct {
+	// Token is the type's Pulumi type token.
+	Token string
+	// Comment is the description of the type, if any.
+	Comment string
+	// Elements is the list of the enum's values.
+	Elements []*Enum
+	// ElementType is the primitive type of the enum's values.
+	ElementType Type
+	// Language specifies additional language-specific data about the enum type.
+	Language map[string]json.RawMessage
+}
+
+// Enum describes a single value of an enum type.
+type Enum struct {
+	// Value is the value of the enum. Its type matches the element type of the enum.
+	Value interface{}
+	// Name is the name of the value, if any. Language generators derive a name from the value if this is empty.
+	Name string
+	// Comment is the description of the value, if any.
+	Comment string
+	// DeprecationMessage indicates whether or not the value is deprecated.
+	DeprecationMessage string
+}
+
+func (t *EnumType) String() string {
+	return t.Token
+}
+
+func (*EnumType) isType() {}
+
+// ValueStrings returns the values of the enum formatted as they would appear in a schema document.
+func (t *EnumType) ValueStrings() []string {
+	values := make([]string, len(t.Elements))
+	for i, e := range t.Elements {
+		if s, ok := e.Value.(string); ok {
+			values[i] = strconv.Quote(s)
+		} else {
+			values[i] = fmt.Sprintf("%v", e.Value)
+		}
+	}
+	return values
+}
+
 // TokenType represents an opaque type that is referred to only by its token. A TokenType may have an underlying type
 // that can be used in place of the token.
 type TokenType struct {
@@ -301,7 +347,7 @@ func (pkg *Package) TokenToModule(tok string) string {
 // TypeSpec is the serializable form of a reference to a type.
 type TypeSpec struct {
 	// Type is the primitive or composite type, if any. May be "bool", "integer", "number", "string", "array", or
-	// "object".
+	// "object". When used alongside a Ref to an enum type, it must match the enum's element type.
 	Type string `json:"type,omitempty"`
 	// Ref is a reference to a type in this or another document. For example, the built-in Archive, Asset, and Any
 	// types are referenced as "pulumi.json#/Archive", "pulumi.json#/Asset", and "pulumi.json#/Any", respectively.
@@ -357,6 +403,27 @@ type ObjectTypeSpec struct {
 	Language map[string]json.RawMessage `json:"language,omitempty"`
 }
 
+// ComplexTypeSpec is the serializable form of an object or enum type.
+type ComplexTypeSpec struct {
+	ObjectTypeSpec
+
+	// Enum, if present, is the list of values of an enum type. The type of an enum must be "boolean", "integer",
+	// "number", or "string".
+	Enum []EnumValueSpec `json:"enum,omitempty"`
+}
+
+// EnumValueSpec is the serializable form of the values metadata associated with an enum type.
+type EnumValueSpec struct {
+	// Name, if present, overrides the name of the enum value that would usually be derived from the value.
+	Name string `json:"name,omitempty"`
+	// Description of the enum value.
+	Description string `json:"description,omitempty"`
+	// Value is the enum value itself.
+	Value interface{} `json:"value"`
+	// DeprecationMessage indicates whether or not the value is deprecated.
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+}
+
 // AliasSpec is the serializable form of an alias description.
 type AliasSpec struct {
 	// Name is the name portion of the alias, if any.
@@ -441,8 +508,9 @@ type PackageSpec struct {
 
 	// Config describes the set of configuration variables defined by this package.
 	Config ConfigSpec `json:"config"`
-	// Types is a map from type token to ObjectTypeSpec that describes the set of object types defined by this package.
-	Types map[string]ObjectTypeSpec `json:"types,omitempty"`
+	// Types is a map from type token to ComplexTypeSpec that describes the set of object and enum types defined by
+	// this package.
+	Types map[string]ComplexTypeSpec `json:"types,omitempty"`
 	// Provider describes the provider type for this package.
 	Provider ResourceSpec `json:"provider"`
 	// Resources is a map from type token to ResourceSpec that describes the set of resources defined by this package.
@@ -505,6 +573,9 @@ func ImportSpec(spec PackageSpec) (*Package, error) {
 	for _, t := range types.objects {
 		typeList = append(typeList, t)
 	}
+	for _, t := range types.enums {
+		typeList = append(typeList, t)
+	}
 	for _, t := range types.arrays {
 		typeList = append(typeList, t)
 	}
@@ -542,6 +613,7 @@ func ImportSpec(spec PackageSpec) (*Package, error) {
 
 type types struct {
 	objects map[string]*ObjectType
+	enums   map[string]*EnumType
 	arrays  map[Type]*ArrayType
 	maps    map[Type]*MapType
 	unions  map[string]*UnionType
@@ -568,6 +640,13 @@ func (t *types) bindType(spec TypeSpec) (Type, error) {
 		if typ, ok := t.objects[token]; ok {
 			return typ, nil
 		}
+		if typ, ok := t.enums[token]; ok {
+			if spec.Type != "" && spec.Type != typ.ElementType.String() {
+				return nil, errors.Errorf("type %s does not match the element type %v of enum %s", spec.Type,
+					typ.ElementType, token)
+			}
+			return typ, nil
+		}
 		typ, ok := t.tokens[token]
 		if !ok {
 			typ = &TokenType{Token: token}
@@ -657,30 +736,22 @@ func bindDefaultValue(value interface{}, spec *DefaultSpec, typ Type) (*DefaultV
 	}
 
 	if value != nil {
-		switch typ {
-		case BoolType:
-			if _, ok := value.(bool); !ok {
-				return nil, errors.Errorf("invalid default of type %T for boolean property", value)
-			}
-		case IntType:
-			v, ok := value.(float64)
-			if !ok {
-				return nil, errors.Errorf("invalid default of type %T for integer property", value)
-			}
-			if math.Trunc(v) != v || v < math.MinInt32 || v > math.MaxInt32 {
-				return nil, errors.Errorf("invalid default of type number for integer property")
+		if enum, ok := typ.(*EnumType); ok {
+			v, err := bindPrimitiveValue(value, enum.ElementType)
+			if err != nil {
+				return nil, err
 			}
-			value = int32(v)
-		case NumberType:
-			if _, ok := value.(float64); !ok {
-				return nil, errors.Errorf("invalid default of type %T for number property", value)
+			if !enum.hasValue(v) {
+				return nil, errors.Errorf("invalid default %v for enum %s; allowed values are %s", value, enum.Token,
+					strings.Join(enum.ValueStrings(), ", "))
 			}
-		case StringType:
-			if _, ok := value.(string); !ok {
-				return nil, errors.Errorf("invalid default of type %T for string property", value)
+			value = v
+		} else {
+			v, err := bindPrimitiveValue(value, typ)
+			if err != nil {
+				return nil, err
 			}
-		default:
-			return nil, errors.Errorf("default values may only be provided for boolean, integer, number, and string properties")
+			value = v
 		}
 	}
 
@@ -691,6 +762,48 @@ func bindDefaultValue(value interface{}, spec *DefaultSpec, typ Type) (*DefaultV
 	return dv, nil
 }
 
+// bindPrimitiveValue checks that the given JSON value is assignable to the given primitive type and converts it to
+// the type's Go representation.
+func bindPrimitiveValue(value interface{}, typ Type) (interface{}, error) {
+	switch typ {
+	case BoolType:
+		if _, ok := value.(bool); !ok {
+			return nil, errors.Errorf("invalid value of type %T for boolean", value)
+		}
+	case IntType:
+		v, ok := value.(float64)
+		if !ok {
+			return nil, errors.Errorf("invalid value of type %T for integer", value)
+		}
+		if math.Trunc(v) != v || v < math.MinInt32 || v > math.MaxInt32 {
+			return nil, errors.Errorf("invalid value %v for integer", v)
+		}
+		return int32(v), nil
+	case NumberType:
+		if _, ok := value.(float64); !ok {
+			return nil, errors.Errorf("invalid value of type %T for number", value)
+		}
+	case StringType:
+		if _, ok := value.(string); !ok {
+			return nil, errors.Errorf("invalid value of type %T for string", value)
+		}
+	default:
+		return nil, errors.Errorf(
+			"default values may only be provided for boolean, integer, number, string, and enum properties")
+	}
+	return value, nil
+}
+
+// hasValue returns true if the given value is one of the enum's values.
+func (t *EnumType) hasValue(value interface{}) bool {
+	for _, e := range t.Elements {
+		if e.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *types) bindProperties(properties map[string]PropertySpec, required []string) ([]*Property, error) {
 	// Bind property types and default values.
 	propertyMap := map[string]*Property{}
@@ -748,9 +861,71 @@ func (t *types) bindObjectType(token string, spec ObjectTypeSpec) (*ObjectType,
 	}, nil
 }
 
-func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
+// isEnum returns true if the spec describes an enum type, either because it has an enum field (even if that field is
+// empty) or because it declares a primitive type.
+func (spec ComplexTypeSpec) isEnum() bool {
+	switch spec.Type {
+	case "boolean", "integer", "number", "string":
+		return true
+	default:
+		return spec.Enum != nil
+	}
+}
+
+// bindEnumType binds the values of an enum type.
+func bindEnumType(token string, spec ComplexTypeSpec) (*EnumType, error) {
+	var elementType Type
+	switch spec.Type {
+	case "boolean":
+		elementType = BoolType
+	case "integer":
+		elementType = IntType
+	case "number":
+		elementType = NumberType
+	case "string":
+		elementType = StringType
+	default:
+		return nil, errors.Errorf("enum %s must be a boolean, integer, number, or string, not a %s", token, spec.Type)
+	}
+
+	enum := &EnumType{
+		Token:       token,
+		Comment:     spec.Description,
+		ElementType: elementType,
+		Language:    spec.Language,
+	}
+	names := map[string]bool{}
+	for _, v := range spec.Enum {
+		value, err := bindPrimitiveValue(v.Value, elementType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for enum %s", token)
+		}
+		if enum.hasValue(value) {
+			return nil, errors.Errorf("duplicate value %v for enum %s", v.Value, token)
+		}
+		if v.Name != "" {
+			if names[v.Name] {
+				return nil, errors.Errorf("duplicate name %s for enum %s", v.Name, token)
+			}
+			names[v.Name] = true
+		}
+		enum.Elements = append(enum.Elements, &Enum{
+			Value:              value,
+			Name:               v.Name,
+			Comment:            v.Description,
+			DeprecationMessage: v.DeprecationMessage,
+		})
+	}
+	if len(enum.Elements) == 0 {
+		return nil, errors.Errorf("enum %s must have at least one value", token)
+	}
+	return enum, nil
+}
+
+func bindTypes(complexTypes map[string]ComplexTypeSpec) (*types, error) {
 	typs := &types{
 		objects: map[string]*ObjectType{},
+		enums:   map[string]*EnumType{},
 		arrays:  map[Type]*ArrayType{},
 		maps:    map[Type]*MapType{},
 		unions:  map[string]*UnionType{},
@@ -758,7 +933,16 @@ func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
 	}
 
 	// Declare object types before processing properties.
-	for token, spec := range objects {
+	for token, spec := range complexTypes {
+		if spec.isEnum() {
+			enum, err := bindEnumType(token, spec)
+			if err != nil {
+				return nil, err
+			}
+			typs.enums[token] = enum
+			continue
+		}
+
 		if spec.Type != "object" {
 			return nil, errors.Errorf("type %s must be an object, not a %s", token, spec.Type)
 		}
@@ -770,7 +954,11 @@ func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
 	}
 
 	// Process properties.
-	for token, spec := range objects {
+	for token, spec := range complexTypes {
+		if spec.isEnum() {
+			continue
+		}
+
 		properties, err := typs.bindProperties(spec.Properties, spec.Required)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to bind type %s", token)
@@ -0,0 +1,128 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// importTypes imports a package whose types are described by the given JSON.
+func importTypes(t *testing.T, types string) (*Package, error) {
+	spec := PackageSpec{Name: "test"}
+	if !assert.NoError(t, json.Unmarshal([]byte(types), &spec.Types)) {
+		t.FailNow()
+	}
+	return ImportSpec(spec)
+}
+
+func TestImportEnums(t *testing.T) {
+	pkg, err := importTypes(t, `{
+		"test:index:Protocol": {
+			"type": "string",
+			"description": "A network protocol.",
+			"enum": [
+				{"name": "TCP", "value": "tcp"},
+				{"value": "udp", "description": "Datagrams."}
+			]
+		},
+		"test:index:Port": {
+			"type": "integer",
+			"enum": [{"value": 22}, {"value": 443}]
+		}
+	}`)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	enums := map[string]*EnumType{}
+	for _, typ := range pkg.Types {
+		if enum, ok := typ.(*EnumType); ok {
+			enums[enum.Token] = enum
+		}
+	}
+	if assert.Contains(t, enums, "test:index:Protocol") {
+		protocol := enums["test:index:Protocol"]
+		assert.Equal(t, StringType, protocol.ElementType)
+		assert.Equal(t, "A network protocol.", protocol.Comment)
+		assert.Equal(t, []*Enum{
+			{Value: "tcp", Name: "TCP"},
+			{Value: "udp", Comment: "Datagrams."},
+		}, protocol.Elements)
+		assert.Equal(t, []string{`"tcp"`, `"udp"`}, protocol.ValueStrings())
+	}
+	if assert.Contains(t, enums, "test:index:Port") {
+		port := enums["test:index:Port"]
+		assert.Equal(t, IntType, port.ElementType)
+		assert.Equal(t, []*Enum{{Value: int32(22)}, {Value: int32(443)}}, port.Elements)
+	}
+}
+
+func TestImportInvalidEnums(t *testing.T) {
+	cases := []struct {
+		name  string
+		types string
+		err   string
+	}{
+		{
+			name:  "empty",
+			types: `{"test:index:Protocol": {"type": "string", "enum": []}}`,
+			err:   "enum test:index:Protocol must have at least one value",
+		},
+		{
+			name:  "missing values",
+			types: `{"test:index:Protocol": {"type": "string"}}`,
+			err:   "enum test:index:Protocol must have at least one value",
+		},
+		{
+			name:  "object",
+			types: `{"test:index:Protocol": {"type": "object", "enum": [{"value": "tcp"}]}}`,
+			err:   "enum test:index:Protocol must be a boolean, integer, number, or string, not a object",
+		},
+		{
+			name:  "mismatched value",
+			types: `{"test:index:Port": {"type": "integer", "enum": [{"value": 22}, {"value": "https"}]}}`,
+			err:   "invalid value for enum test:index:Port: invalid value of type string for integer",
+		},
+		{
+			name:  "fractional integer",
+			types: `{"test:index:Port": {"type": "integer", "enum": [{"value": 22.5}]}}`,
+			err:   "invalid value for enum test:index:Port: invalid value 22.5 for integer",
+		},
+		{
+			name:  "duplicate value",
+			types: `{"test:index:Protocol": {"type": "string", "enum": [{"value": "tcp"}, {"value": "tcp"}]}}`,
+			err:   "duplicate value tcp for enum test:index:Protocol",
+		},
+		{
+			name: "duplicate name",
+			types: `{"test:index:Protocol": {"type": "string", "enum": [
+				{"name": "Stream", "value": "tcp"},
+				{"name": "Stream", "value": "sctp"}
+			]}}`,
+			err: "duplicate name Stream for enum test:index:Protocol",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := importTypes(t, c.types)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), c.err)
+			}
+		})
+	}
+}
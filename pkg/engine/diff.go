@@ -16,6 +16,7 @@ package engine
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -24,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -182,7 +184,7 @@ func GetResourcePropertiesSummary(step StepEventMetadata, indent int) string {
 }
 
 func GetResourcePropertiesDetails(
-	step StepEventMetadata, indent int, planning bool, summary bool, debug bool) string {
+	step StepEventMetadata, indent int, planning bool, summary bool, debug bool, decodeStrings bool) string {
 	var b bytes.Buffer
 
 	// indent everything an additional level, like other properties.
@@ -203,9 +205,10 @@ func GetResourcePropertiesDetails(
 			PrintObject(&b, old.Inputs, planning, indent, step.Op, false, debug)
 		}
 	} else if len(new.Outputs) > 0 && step.Op != deploy.OpImport && step.Op != deploy.OpImportReplacement {
-		printOldNewDiffs(&b, old.Outputs, new.Outputs, nil, planning, indent, step.Op, summary, debug)
+		printOldNewDiffs(&b, old.Outputs, new.Outputs, nil, planning, indent, step.Op, summary, debug, decodeStrings)
 	} else {
-		printOldNewDiffs(&b, old.Inputs, new.Inputs, step.Diffs, planning, indent, step.Op, summary, debug)
+		printOldNewDiffs(&b, old.Inputs, new.Inputs, step.Diffs, planning, indent, step.Op, summary, debug,
+			decodeStrings)
 	}
 
 	return b.String()
@@ -299,7 +302,7 @@ func massageStackPreviewOutputDiff(diff *resource.ObjectDiff, inResource bool) {
 // GetResourceOutputsPropertiesString prints only those properties that either differ from the input properties or, if
 // there is an old snapshot of the resource, differ from the prior old snapshot's output properties.
 func GetResourceOutputsPropertiesString(
-	step StepEventMetadata, indent int, planning, debug, refresh, showSames bool) string {
+	step StepEventMetadata, indent int, planning, debug, refresh, showSames, decodeStrings bool) string {
 
 	// During the actual update we always show all the outputs for the stack, even if they are unchanged.
 	if !showSames && !planning && step.URN.Type() == resource.RootStackType {
@@ -401,7 +404,7 @@ func GetResourceOutputsPropertiesString(
 			}
 
 			if outputDiff != nil {
-				printObjectPropertyDiff(b, k, maxkey, *outputDiff, planning, indent, false, debug)
+				printObjectPropertyDiff(b, k, maxkey, *outputDiff, planning, indent, false, debug, decodeStrings)
 			} else {
 				printPropertyTitle(b, string(k), maxkey, indent, op, false)
 				printPropertyValue(b, out, planning, indent, op, false, debug)
@@ -548,11 +551,11 @@ func shortHash(hash string) string {
 
 func printOldNewDiffs(
 	b *bytes.Buffer, olds resource.PropertyMap, news resource.PropertyMap, include []resource.PropertyKey,
-	planning bool, indent int, op deploy.StepOp, summary bool, debug bool) {
+	planning bool, indent int, op deploy.StepOp, summary bool, debug bool, decodeStrings bool) {
 
 	// Get the full diff structure between the two, and print it (recursively).
 	if diff := olds.Diff(news, IsInternalPropertyKey); diff != nil {
-		PrintObjectDiff(b, *diff, include, planning, indent, summary, debug)
+		PrintObjectDiff(b, *diff, include, planning, indent, summary, debug, decodeStrings)
 	} else {
 		// If there's no diff, report the op as Same - there's no diff to render
 		// so it should be rendered as if nothing changed.
@@ -560,8 +563,10 @@ func printOldNewDiffs(
 	}
 }
 
+// PrintObjectDiff prints the given object diff. If decodeStrings is true, updates to strings that contain JSON or YAML
+// documents are printed as diffs of the decoded documents.
 func PrintObjectDiff(b *bytes.Buffer, diff resource.ObjectDiff, include []resource.PropertyKey,
-	planning bool, indent int, summary bool, debug bool) {
+	planning bool, indent int, summary bool, debug bool, decodeStrings bool) {
 
 	contract.Assert(indent > 0)
 
@@ -585,12 +590,12 @@ func PrintObjectDiff(b *bytes.Buffer, diff resource.ObjectDiff, include []resour
 
 	// To print an object diff, enumerate the keys in stable order, and print each property independently.
 	for _, k := range keys {
-		printObjectPropertyDiff(b, k, maxkey, diff, planning, indent, summary, debug)
+		printObjectPropertyDiff(b, k, maxkey, diff, planning, indent, summary, debug, decodeStrings)
 	}
 }
 
 func printObjectPropertyDiff(b *bytes.Buffer, key resource.PropertyKey, maxkey int, diff resource.ObjectDiff,
-	planning bool, indent int, summary bool, debug bool) {
+	planning bool, indent int, summary bool, debug bool, decodeStrings bool) {
 
 	titleFunc := func(top deploy.StepOp, prefix bool) {
 		printPropertyTitle(b, string(key), maxkey, indent, top, prefix)
@@ -601,7 +606,7 @@ func printObjectPropertyDiff(b *bytes.Buffer, key resource.PropertyKey, maxkey i
 		printDelete(b, delete, titleFunc, planning, indent, debug)
 	} else if update, isupdate := diff.Updates[key]; isupdate {
 		printPropertyValueDiff(
-			b, titleFunc, update, planning, indent, summary, debug)
+			b, titleFunc, update, planning, indent, summary, debug, decodeStrings)
 	} else if same := diff.Sames[key]; !summary && shouldPrintPropertyValue(same, planning) {
 		titleFunc(deploy.OpSame, false)
 		printPropertyValue(b, diff.Sames[key], planning, indent, deploy.OpSame, false, debug)
@@ -611,7 +616,7 @@ func printObjectPropertyDiff(b *bytes.Buffer, key resource.PropertyKey, maxkey i
 func printPropertyValueDiff(
 	b *bytes.Buffer, titleFunc func(deploy.StepOp, bool),
 	diff resource.ValueDiff, planning bool,
-	indent int, summary bool, debug bool) {
+	indent int, summary bool, debug bool, decodeStrings bool) {
 
 	op := deploy.OpUpdate
 	contract.Assert(indent > 0)
@@ -632,7 +637,7 @@ func printPropertyValueDiff(
 			} else if update, isupdate := a.Updates[i]; isupdate {
 				printPropertyValueDiff(
 					b, elemTitleFunc, update, planning,
-					indent+2, summary, debug)
+					indent+2, summary, debug, decodeStrings)
 			} else if !summary {
 				elemTitleFunc(deploy.OpSame, false)
 				printPropertyValue(b, a.Sames[i], planning, indent+2, deploy.OpSame, false, debug)
@@ -642,7 +647,7 @@ func printPropertyValueDiff(
 	} else if diff.Object != nil {
 		titleFunc(op, true)
 		writeVerbatim(b, op, "{\n")
-		PrintObjectDiff(b, *diff.Object, nil, planning, indent+1, summary, debug)
+		PrintObjectDiff(b, *diff.Object, nil, planning, indent+1, summary, debug, decodeStrings)
 		writeWithIndentNoPrefix(b, indent, op, "}\n")
 	} else {
 		shouldPrintOld := shouldPrintPropertyValue(diff.Old, false)
@@ -658,6 +663,14 @@ func printPropertyValueDiff(
 				return
 			}
 
+			// If both values are strings that contain JSON or YAML documents, print the diff of the documents.
+			if decodeStrings {
+				if docDiff, ok := decodeStringDiff(diff.Old, diff.New); ok {
+					printPropertyValueDiff(b, titleFunc, docDiff, planning, indent, summary, debug, decodeStrings)
+					return
+				}
+			}
+
 			if isPrimitive(diff.Old) && isPrimitive(diff.New) {
 				titleFunc(deploy.OpUpdate, true /*indent*/)
 				printPrimitivePropertyValue(b, diff.Old, planning, deploy.OpDelete)
@@ -679,6 +692,87 @@ func printPropertyValueDiff(
 	}
 }
 
+// decodeStringDiff returns the diff between the documents encoded by two strings. It returns false unless both strings
+// contain JSON or YAML documents whose top-level values are objects or arrays and the documents differ.
+func decodeStringDiff(old, new resource.PropertyValue) (resource.ValueDiff, bool) {
+	if !old.IsString() || !new.IsString() {
+		return resource.ValueDiff{}, false
+	}
+
+	oldDoc, ok := decodeStringDocument(old.StringValue())
+	if !ok {
+		return resource.ValueDiff{}, false
+	}
+	newDoc, ok := decodeStringDocument(new.StringValue())
+	if !ok {
+		return resource.ValueDiff{}, false
+	}
+
+	// If the documents are equivalent, or if their top-level values are of different kinds, the diff of the strings
+	// is more informative.
+	diff := oldDoc.Diff(newDoc)
+	if diff == nil || (diff.Object == nil && diff.Array == nil) {
+		return resource.ValueDiff{}, false
+	}
+	return *diff, true
+}
+
+// decodeStringDocument decodes a string that contains a JSON or YAML document whose top-level value is an object or
+// an array. Nearly any string is a valid YAML scalar, so only multi-line strings are decoded as YAML, and only if
+// they contain a single document.
+func decodeStringDocument(s string) (resource.PropertyValue, bool) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return resource.PropertyValue{}, false
+	}
+
+	var doc interface{}
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		if err := json.Unmarshal([]byte(trimmed), &doc); err == nil {
+			v := resource.NewPropertyValue(doc)
+			return v, v.IsObject() || v.IsArray()
+		}
+	}
+
+	if !strings.Contains(trimmed, "\n") {
+		return resource.PropertyValue{}, false
+	}
+	dec := yaml.NewDecoder(strings.NewReader(s))
+	if err := dec.Decode(&doc); err != nil {
+		return resource.PropertyValue{}, false
+	}
+	var next interface{}
+	if err := dec.Decode(&next); err != io.EOF {
+		return resource.PropertyValue{}, false
+	}
+
+	v := resource.NewPropertyValue(normalizeYAMLValue(doc))
+	return v, v.IsObject() || v.IsArray()
+}
+
+// normalizeYAMLValue converts the maps in a decoded YAML document, whose keys may be of any type, into maps with
+// string keys.
+func normalizeYAMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprintf("%v", k)] = normalizeYAMLValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = normalizeYAMLValue(e)
+		}
+		return a
+	case nil, bool, int, int64, uint64, float64, string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 func isPrimitive(value resource.PropertyValue) bool {
 	return value.IsNull() || value.IsString() || value.IsNumber() ||
 		value.IsBool() || value.IsComputed() || value.IsOutput()
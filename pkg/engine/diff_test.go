@@ -0,0 +1,99 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+func TestDecodeStringDocument(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected interface{}
+	}{
+		{`{"a": 1, "b": [true, null]}`, map[string]interface{}{"a": 1.0, "b": []interface{}{true, nil}}},
+		{` [1, "two"] `, []interface{}{1.0, "two"}},
+		{"a: 1\nb:\n  - x\n  - z\n", map[string]interface{}{"a": 1, "b": []interface{}{"x", "z"}}},
+		{"- 1\n- 2\n", []interface{}{1, 2}},
+		{"1: one\ntrue: two\n", map[string]interface{}{"1": "one", "true": "two"}},
+	}
+	for _, c := range cases {
+		v, ok := decodeStringDocument(c.s)
+		if assert.True(t, ok, c.s) {
+			assert.Equal(t, resource.NewPropertyValue(c.expected), v, c.s)
+		}
+	}
+
+	// Strings that do not contain objects or arrays are not decoded.
+	for _, s := range []string{
+		"", "hello", "42", `"quoted"`, "{not json", "a: 1", "plain\ntext\n", "a: 1\n---\nb: 2\n", "a: [1\nb: 2",
+	} {
+		_, ok := decodeStringDocument(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func printTestDiff(old, new resource.PropertyValue, decodeStrings bool) string {
+	olds, news := resource.PropertyMap{"policy": old}, resource.PropertyMap{"policy": new}
+
+	var b bytes.Buffer
+	PrintObjectDiff(&b, *olds.Diff(news), nil, false /*planning*/, 1, false /*summary*/, false /*debug*/, decodeStrings)
+	return colors.Never.Colorize(b.String())
+}
+
+func TestPrintStringDocumentDiff(t *testing.T) {
+	old := resource.NewStringProperty(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:*"}]}`)
+	new := resource.NewStringProperty(`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny"}], "Id": "p"}`)
+
+	assert.Equal(t, ""+
+		"  ~ policy: {\n"+
+		"      + Id       : \"p\"\n"+
+		"      ~ Statement: [\n"+
+		"          ~ [0]: {\n"+
+		"                  - Action: \"s3:*\"\n"+
+		"                  ~ Effect: \"Allow\" => \"Deny\"\n"+
+		"                }\n"+
+		"        ]\n"+
+		"        Version  : \"2012-10-17\"\n"+
+		"    }\n",
+		printTestDiff(old, new, true))
+
+	// YAML documents are decoded too.
+	oldYAML := resource.NewStringProperty("kind: Deployment\nspec:\n  replicas: 1\n")
+	newYAML := resource.NewStringProperty("kind: Deployment\nspec:\n  replicas: 3\n")
+	assert.Equal(t, ""+
+		"  ~ policy: {\n"+
+		"        kind: \"Deployment\"\n"+
+		"      ~ spec: {\n"+
+		"          ~ replicas: 1 => 3\n"+
+		"        }\n"+
+		"    }\n",
+		printTestDiff(oldYAML, newYAML, true))
+
+	// Decoding can be disabled, and is not done if only one side is a document or if the documents are equivalent.
+	textDiff := "  ~ policy: " + `"{\"a\": 1}" => "{\"a\": 2}"` + "\n"
+	assert.Equal(t, textDiff,
+		printTestDiff(resource.NewStringProperty(`{"a": 1}`), resource.NewStringProperty(`{"a": 2}`), false))
+	assert.Equal(t, "  ~ policy: \"not json\" => \"{\\\"a\\\": 2}\"\n",
+		printTestDiff(resource.NewStringProperty("not json"), resource.NewStringProperty(`{"a": 2}`), true))
+	assert.Equal(t, "  ~ policy: \"{\\\"a\\\": 1}\" => \"{\\\"a\\\":1}\"\n",
+		printTestDiff(resource.NewStringProperty(`{"a": 1}`), resource.NewStringProperty(`{"a":1}`), true))
+}
@@ -78,6 +78,8 @@ type PolicyViolationEventPayload struct {
 	PolicyPackVersion string
 	EnforcementLevel  apitype.EnforcementLevel
 	Prefix            string
+	Description       string
+	Tags              []string
 }
 
 type StdoutEventPayload struct {
@@ -91,11 +93,13 @@ type PreludeEventPayload struct {
 }
 
 type SummaryEventPayload struct {
-	IsPreview       bool              // true if this summary is for a plan operation
-	MaybeCorrupt    bool              // true if one or more resources may be corrupt
-	Duration        time.Duration     // the duration of the entire update operation (zero values for previews)
-	ResourceChanges ResourceChanges   // count of changed resources, useful for reporting
-	PolicyPacks     map[string]string // {policy-pack: version} for each policy pack applied
+	IsPreview       bool                 // true if this summary is for a plan operation
+	MaybeCorrupt    bool                 // true if one or more resources may be corrupt
+	Duration        time.Duration        // the duration of the entire update operation (zero values for previews)
+	ResourceChanges ResourceChanges      // count of changed resources, useful for reporting
+	ChangeGroups    ResourceChangeGroups // count of changed resources by type, module and top-level component
+	HiddenSteps     int                  // count of steps hidden by the display's filters (set by the display)
+	PolicyPacks     map[string]string    // {policy-pack: version} for each policy pack applied
 }
 
 type ResourceOperationFailedPayload struct {
@@ -487,7 +491,8 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	}
 }
 
-func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, policyPacks map[string]string) {
+func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, changeGroups ResourceChangeGroups,
+	policyPacks map[string]string) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- Event{
@@ -497,13 +502,15 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, poli
 			MaybeCorrupt:    false,
 			Duration:        0,
 			ResourceChanges: resourceChanges,
+			ChangeGroups:    changeGroups,
 			PolicyPacks:     policyPacks,
 		},
 	}
 }
 
 func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string) {
+	duration time.Duration, resourceChanges ResourceChanges, changeGroups ResourceChangeGroups,
+	policyPacks map[string]string) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- Event{
@@ -513,6 +520,7 @@ func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
 			MaybeCorrupt:    maybeCorrupt,
 			Duration:        duration,
 			ResourceChanges: resourceChanges,
+			ChangeGroups:    changeGroups,
 			PolicyPacks:     policyPacks,
 		},
 	}
@@ -557,6 +565,8 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 			PolicyPackVersion: d.PolicyPackVersion,
 			EnforcementLevel:  d.EnforcementLevel,
 			Prefix:            logging.FilterString(prefix.String()),
+			Description:       logging.FilterString(d.Description),
+			Tags:              d.Tags,
 		},
 	}
 }
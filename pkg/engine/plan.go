@@ -225,12 +225,12 @@ func printPlan(ctx *Context, planResult *planResult, dryRun bool, policies map[s
 	planResult.Options.Events.preludeEvent(dryRun, planResult.Ctx.Update.GetTarget().Config)
 
 	// Walk the plan's steps and and pretty-print them out.
-	actions := newPlanActions(planResult.Options)
+	actions := newPlanActions(planResult.Options, planResult.Plan.Olds())
 	res := planResult.Walk(ctx, actions, true)
 
 	// Emit an event with a summary of operation counts.
 	changes := ResourceChanges(actions.Ops)
-	planResult.Options.Events.previewSummaryEvent(changes, policies)
+	planResult.Options.Events.previewSummaryEvent(changes, actions.Groups, policies)
 
 	if res != nil {
 
@@ -246,8 +246,10 @@ func printPlan(ctx *Context, planResult *planResult, dryRun bool, policies map[s
 
 type planActions struct {
 	Ops     map[deploy.StepOp]int
+	Groups  ResourceChangeGroups
 	Opts    planOptions
 	Seen    map[resource.URN]deploy.Step
+	Olds    map[resource.URN]*resource.State
 	MapLock sync.Mutex
 }
 
@@ -256,11 +258,13 @@ func shouldReportStep(step deploy.Step, opts planOptions) bool {
 		(opts.reportDefaultProviderSteps || !isDefaultProviderStep(step))
 }
 
-func newPlanActions(opts planOptions) *planActions {
+func newPlanActions(opts planOptions, olds map[resource.URN]*resource.State) *planActions {
 	return &planActions{
-		Ops:  make(map[deploy.StepOp]int),
-		Opts: opts,
-		Seen: make(map[resource.URN]deploy.Step),
+		Ops:    make(map[deploy.StepOp]int),
+		Groups: newResourceChangeGroups(),
+		Opts:   opts,
+		Seen:   make(map[resource.URN]deploy.Step),
+		Olds:   olds,
 	}
 }
 
@@ -311,6 +315,7 @@ func (acts *planActions) OnResourceStepPost(ctx interface{},
 		if record {
 			acts.MapLock.Lock()
 			acts.Ops[op]++
+			acts.Groups.record(op, step, acts.Seen, acts.Olds)
 			acts.MapLock.Unlock()
 		}
 
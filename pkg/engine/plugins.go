@@ -19,6 +19,7 @@ import (
 	"sort"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
@@ -120,6 +121,68 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 	return set, nil
 }
 
+// applyPluginLock pins each plugin in the given set to the version recorded in the project's plugin lockfile. It
+// returns an error if the set contains a plugin the lockfile doesn't cover, or requires a different version than the
+// one that is locked. Language plugins ship with the CLI and so are never locked.
+func applyPluginLock(lock *workspace.PluginLock, lockPath string, plugins pluginSet) (pluginSet, error) {
+	logging.V(preparePluginLog).Infof("applyPluginLock(): pinning plugins to %s", lockPath)
+	set := newPluginSet()
+	for _, plug := range plugins.Values() {
+		if plug.Kind == workspace.LanguagePlugin {
+			set.Add(plug)
+			continue
+		}
+
+		locked, ok := lock.Find(plug.Kind, plug.Name)
+		if !ok {
+			return nil, errors.Errorf("%s plugin %s is required but is not in the plugin lockfile %s; "+
+				"run `pulumi plugin lock` to update it", plug.Kind, plug, lockPath)
+		}
+		pinned, err := locked.Info()
+		if err != nil {
+			return nil, err
+		}
+		if plug.Version != nil && !plug.Version.Equals(*pinned.Version) {
+			return nil, errors.Errorf("%s plugin %s v%s is required but the plugin lockfile %s pins v%s; "+
+				"run `pulumi plugin lock` to update it", plug.Kind, plug.Name, plug.Version, lockPath, pinned.Version)
+		}
+		if pinned.ServerURL == "" {
+			pinned.ServerURL = plug.ServerURL
+		}
+
+		logging.V(preparePluginLog).Infof(
+			"applyPluginLock(): plugin %s %s pinned to %s", plug.Name, plug.Version, pinned.Version)
+		set.Add(pinned)
+	}
+	return set, nil
+}
+
+// verifyLockedPlugins checks that every plugin in the given set that is covered by the lockfile is installed and
+// matches the checksum recorded in the lockfile.
+func verifyLockedPlugins(lock *workspace.PluginLock, lockPath string, plugins pluginSet) error {
+	for _, plug := range plugins.Values() {
+		locked, ok := lock.Find(plug.Kind, plug.Name)
+		if !ok {
+			continue
+		}
+
+		_, path, err := workspace.GetPluginInfo(plug.Kind, plug.Name, plug.Version)
+		if err != nil {
+			return errors.Wrapf(err, "locked %s plugin %s is not installed", plug.Kind, plug)
+		}
+		checksum, err := workspace.PluginChecksum(path)
+		if err != nil {
+			return err
+		}
+		if checksum != locked.Checksum {
+			return errors.Errorf("%s plugin %s at %s does not match the checksum in the plugin lockfile %s; "+
+				"reinstall it with `pulumi plugin install --reinstall %s %s v%s`",
+				plug.Kind, plug, path, lockPath, plug.Kind, plug.Name, plug.Version)
+		}
+	}
+	return nil
+}
+
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
 // uses the given backend client to install them. Installations are processed in parallel, though
 // ensurePluginsAreInstalled does not return until all installations are completed.
@@ -127,3 +127,39 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestApplyPluginLock(t *testing.T) {
+	lock := &workspace.PluginLock{
+		Plugins: []workspace.LockedPlugin{
+			{Name: "aws", Kind: workspace.ResourcePlugin, Version: "1.2.3", Checksum: "abc"},
+			{Name: "kubernetes", Kind: workspace.ResourcePlugin, Version: "0.22.0", Checksum: "def",
+				ServerURL: "https://example.com/plugins"},
+		},
+	}
+
+	plugins := newPluginSet()
+	plugins.Add(workspace.PluginInfo{Name: "aws", Kind: workspace.ResourcePlugin})
+	plugins.Add(workspace.PluginInfo{Name: "kubernetes", Kind: workspace.ResourcePlugin,
+		Version: mustMakeVersion("0.22.0")})
+	plugins.Add(workspace.PluginInfo{Name: "nodejs", Kind: workspace.LanguagePlugin})
+
+	pinned, err := applyPluginLock(lock, "PulumiPlugins.lock.yaml", plugins)
+	assert.NoError(t, err)
+	assert.Len(t, pinned, 3)
+	assert.Contains(t, pinned, "aws-1.2.3")
+	assert.Contains(t, pinned, "kubernetes-0.22.0")
+	assert.Equal(t, "https://example.com/plugins", pinned["kubernetes-0.22.0"].ServerURL)
+	assert.Contains(t, pinned, "nodejs")
+
+	// A version that differs from the locked version is an error.
+	mismatch := newPluginSet()
+	mismatch.Add(workspace.PluginInfo{Name: "aws", Kind: workspace.ResourcePlugin, Version: mustMakeVersion("1.3.0")})
+	_, err = applyPluginLock(lock, "PulumiPlugins.lock.yaml", mismatch)
+	assert.Error(t, err)
+
+	// So is a plugin that isn't locked at all.
+	unlocked := newPluginSet()
+	unlocked.Add(workspace.PluginInfo{Name: "gcp", Kind: workspace.ResourcePlugin, Version: mustMakeVersion("2.0.0")})
+	_, err = applyPluginLock(lock, "PulumiPlugins.lock.yaml", unlocked)
+	assert.Error(t, err)
+}
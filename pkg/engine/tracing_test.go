@@ -0,0 +1,105 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestStepSpans(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, inputs resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if urn.Name() == "resB" {
+						return "", nil, resource.StatusOK, errors.New("oh no")
+					}
+					return "id", resource.PropertyMap{}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.Error(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   []TestStep{{Op: Update, ExpectFailure: true, SkipPreview: true}},
+	}
+	p.Run(t, nil)
+
+	// Each step is traced within the plan's span.
+	var planSpan *mocktracer.MockSpan
+	stepSpans := map[string]*mocktracer.MockSpan{}
+	for _, span := range tracer.FinishedSpans() {
+		switch span.OperationName {
+		case "pulumi-plan":
+			planSpan = span
+		case "pulumi-step":
+			stepSpans[span.Tag("pulumi.urn").(string)] = span
+		}
+	}
+	if !assert.NotNil(t, planSpan) || !assert.Len(t, stepSpans, 3) {
+		t.FailNow()
+	}
+
+	provURN := p.NewProviderURN("pkgA", "default", "")
+	provSpan := stepSpans[string(provURN)]
+	if assert.NotNil(t, provSpan) {
+		assert.Equal(t, planSpan.SpanContext.SpanID, provSpan.ParentID)
+		assert.Equal(t, "create", provSpan.Tag("pulumi.op"))
+		assert.Equal(t, "pulumi:providers:pkgA", provSpan.Tag("pulumi.type"))
+	}
+
+	resASpan := stepSpans[string(p.NewURN("pkgA:m:typA", "resA", ""))]
+	if assert.NotNil(t, resASpan) {
+		assert.Equal(t, planSpan.SpanContext.SpanID, resASpan.ParentID)
+		assert.Equal(t, "create", resASpan.Tag("pulumi.op"))
+		assert.Equal(t, "pkgA:m:typA", resASpan.Tag("pulumi.type"))
+		assert.Contains(t, resASpan.Tag("pulumi.provider"), string(provURN)+"::")
+		assert.Equal(t, false, resASpan.Tag("pulumi.preview"))
+		assert.Nil(t, resASpan.Tag("error"))
+	}
+
+	// Failed steps are marked as such.
+	resBSpan := stepSpans[string(p.NewURN("pkgA:m:typA", "resB", ""))]
+	if assert.NotNil(t, resBSpan) {
+		assert.Equal(t, true, resBSpan.Tag("error"))
+		assert.Len(t, resBSpan.Logs(), 1)
+	}
+}
@@ -140,6 +140,72 @@ func (changes ResourceChanges) HasChanges() bool {
 	return c > 0
 }
 
+// ResourceChangeGroups contains the aggregate resource changes by operation type, grouped by the resources' types,
+// modules and top-level component parents.
+type ResourceChangeGroups struct {
+	Types      map[tokens.Type]ResourceChanges
+	Modules    map[tokens.Module]ResourceChanges
+	Components map[resource.URN]ResourceChanges
+}
+
+func newResourceChangeGroups() ResourceChangeGroups {
+	return ResourceChangeGroups{
+		Types:      make(map[tokens.Type]ResourceChanges),
+		Modules:    make(map[tokens.Module]ResourceChanges),
+		Components: make(map[resource.URN]ResourceChanges),
+	}
+}
+
+// record adds a step's operation to the groups of the step's resource. The resource's ancestors are looked up in the
+// steps that have been seen so far or, for ancestors that have not been seen (e.g. because they are deleted after
+// their children), in the old states of the plan.
+func (groups ResourceChangeGroups) record(op deploy.StepOp, step deploy.Step,
+	seen map[resource.URN]deploy.Step, olds map[resource.URN]*resource.State) {
+
+	record := func(changes ResourceChanges) ResourceChanges {
+		if changes == nil {
+			changes = ResourceChanges{}
+		}
+		changes[op]++
+		return changes
+	}
+
+	typ := step.Type()
+	groups.Types[typ] = record(groups.Types[typ])
+	if module := ResourceModule(typ); module != "" {
+		groups.Modules[module] = record(groups.Modules[module])
+	}
+
+	// Find the ancestor of the resource (or the resource itself) whose parent is the root stack. If that resource is a
+	// component, it is the resource's top-level component.
+	urn, res := step.URN(), step.Res()
+	for res != nil && res.Parent != "" && res.Parent.Type() != resource.RootStackType {
+		urn, res = res.Parent, nil
+		if parent, ok := seen[urn]; ok {
+			res = parent.Res()
+		} else if old, ok := olds[urn]; ok {
+			res = old
+		}
+	}
+	if res != nil && !res.Custom && urn.Type() != resource.RootStackType {
+		groups.Components[urn] = record(groups.Components[urn])
+	}
+}
+
+// ResourceModule returns the module of a resource type for the purposes of grouping resources, which is the type's
+// package and the first component of its module name: e.g. "aws:ec2" for the type "aws:ec2/instance:Instance".
+func ResourceModule(typ tokens.Type) tokens.Module {
+	if !tokens.Token(typ).HasModuleMember() {
+		return ""
+	}
+	module := typ.Module()
+	name := string(module.Name())
+	if i := strings.Index(name, "/"); i != -1 {
+		name = name[:i]
+	}
+	return tokens.Module(string(module.Package()) + ":" + name)
+}
+
 func Update(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (ResourceChanges, result.Result) {
 	contract.Require(u != nil, "update")
 	contract.Require(ctx != nil, "ctx")
@@ -198,6 +264,21 @@ func installPlugins(
 	if err != nil {
 		return nil, nil, err
 	}
+
+	// If the project has a plugin lockfile, the program must use exactly the plugins it records.
+	var lock *workspace.PluginLock
+	var lockPath string
+	if pwd != "" {
+		if lock, lockPath, err = workspace.DetectPluginLockFrom(pwd); err != nil {
+			return nil, nil, err
+		}
+		if lock != nil {
+			if languagePlugins, err = applyPluginLock(lock, lockPath, languagePlugins); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	snapshotPlugins, err := gatherPluginsFromSnapshot(plugctx, target)
 	if err != nil {
 		return nil, nil, err
@@ -213,6 +294,13 @@ func installPlugins(
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
 	}
 
+	// Locked plugins, on the other hand, must be present and match what was locked.
+	if lock != nil {
+		if err := verifyLockedPlugins(lock, lockPath, languagePlugins); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Collect the version information for default providers.
 	defaultProviderVersions := computeDefaultProviderPlugins(languagePlugins, allPlugins)
 
@@ -348,7 +436,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 			// Walk the plan, reporting progress and executing the actual operations as we go.
 			start := time.Now()
-			actions := newUpdateActions(ctx, info.Update, opts)
+			actions := newUpdateActions(ctx, info.Update, opts, planResult.Plan.Olds())
 
 			res = planResult.Walk(ctx, actions, false)
 			resourceChanges = ResourceChanges(actions.Ops)
@@ -357,7 +445,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
 				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start),
-					resourceChanges, policies)
+					resourceChanges, actions.Groups, policies)
 			}
 		}
 	}
@@ -395,18 +483,24 @@ type updateActions struct {
 	Context      *Context
 	Steps        int
 	Ops          map[deploy.StepOp]int
+	Groups       ResourceChangeGroups
 	Seen         map[resource.URN]deploy.Step
+	Olds         map[resource.URN]*resource.State
 	MapLock      sync.Mutex
 	MaybeCorrupt bool
 	Update       UpdateInfo
 	Opts         planOptions
 }
 
-func newUpdateActions(context *Context, u UpdateInfo, opts planOptions) *updateActions {
+func newUpdateActions(context *Context, u UpdateInfo, opts planOptions,
+	olds map[resource.URN]*resource.State) *updateActions {
+
 	return &updateActions{
 		Context: context,
 		Ops:     make(map[deploy.StepOp]int),
+		Groups:  newResourceChangeGroups(),
 		Seen:    make(map[resource.URN]deploy.Step),
+		Olds:    olds,
 		Update:  u,
 		Opts:    opts,
 	}
@@ -475,6 +569,7 @@ func (acts *updateActions) OnResourceStepPost(
 			acts.MapLock.Lock()
 			acts.Steps++
 			acts.Ops[op]++
+			acts.Groups.record(op, step, acts.Seen, acts.Olds)
 			acts.MapLock.Unlock()
 		}
 
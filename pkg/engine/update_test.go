@@ -3,7 +3,16 @@ package engine
 import (
 	"testing"
 
+	"github.com/blang/semver"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 func TestAbbreviateFilePath(t *testing.T) {
@@ -46,3 +55,86 @@ func TestAbbreviateFilePath(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+func TestResourceModule(t *testing.T) {
+	assert.Equal(t, tokens.Module("aws:ec2"), ResourceModule("aws:ec2/instance:Instance"))
+	assert.Equal(t, tokens.Module("aws:s3"), ResourceModule("aws:s3/bucket:Bucket"))
+	assert.Equal(t, tokens.Module("pkgA:m"), ResourceModule("pkgA:m:typA"))
+	assert.Equal(t, tokens.Module("pulumi:providers"), ResourceModule("pulumi:providers:aws"))
+	assert.Equal(t, tokens.Module(""), ResourceModule("typA"))
+}
+
+func TestResourceChangeGroups(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	destroy := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if destroy {
+			return nil
+		}
+
+		compA, _, _, err := monitor.RegisterResource("my:index:Component", "compA", false)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m/sub:typA", "resA", true, deploytest.ResourceOptions{
+			Parent: compA,
+		})
+		assert.NoError(t, err)
+		compB, _, _, err := monitor.RegisterResource("my:index:Component", "compB", false, deploytest.ResourceOptions{
+			Parent: compA,
+		})
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m/sub:typA", "resB", true, deploytest.ResourceOptions{
+			Parent: compB,
+		})
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:n:typB", "resC", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{Options: UpdateOptions{host: host}}
+	compA := p.NewURN("my:index:Component", "compA", "")
+
+	validate := func(op deploy.StepOp) ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, j *Journal, events []Event,
+			res result.Result) result.Result {
+
+			var summaries []SummaryEventPayload
+			for _, e := range events {
+				if e.Type == SummaryEvent {
+					summaries = append(summaries, e.Payload.(SummaryEventPayload))
+				}
+			}
+			assert.NotEmpty(t, summaries)
+
+			for _, summary := range summaries {
+				groups := summary.ChangeGroups
+				assert.Equal(t, ResourceChanges{op: 2}, groups.Types["pkgA:m/sub:typA"])
+				assert.Equal(t, ResourceChanges{op: 1}, groups.Types["pkgA:n:typB"])
+				assert.Equal(t, ResourceChanges{op: 2}, groups.Types["my:index:Component"])
+
+				assert.Equal(t, ResourceChanges{op: 2}, groups.Modules["pkgA:m"])
+				assert.Equal(t, ResourceChanges{op: 1}, groups.Modules["pkgA:n"])
+				assert.Equal(t, ResourceChanges{op: 2}, groups.Modules["my:index"])
+
+				// Resources are grouped by their top-level component, which includes the component itself. Custom
+				// resources that are children of the stack are not part of any component.
+				assert.Equal(t, map[resource.URN]ResourceChanges{compA: {op: 4}}, groups.Components)
+			}
+			return res
+		}
+	}
+
+	p.Steps = []TestStep{{Op: Update, Validate: validate(deploy.OpCreate)}}
+	snap := p.Run(t, nil)
+
+	// When the resources are deleted, their children are deleted before their parents.
+	destroy = true
+	p.Steps = []TestStep{{Op: Update, Validate: validate(deploy.OpDelete)}}
+	p.Run(t, snap)
+}
@@ -55,6 +55,55 @@ func (m Map) HasSecureValue() bool {
 	return false
 }
 
+// Copy returns a copy of the config map with every secure value decrypted using decrypter and re-encrypted using
+// encrypter.
+func (m Map) Copy(decrypter Decrypter, encrypter Encrypter) (Map, error) {
+	newConfig := make(Map)
+	for k, c := range m {
+		val, err := c.Copy(decrypter, encrypter)
+		if err != nil {
+			return nil, err
+		}
+		newConfig[k] = val
+	}
+	return newConfig, nil
+}
+
+// Merge merges the values in overlay into the map. Values in overlay take precedence over existing values, except
+// that when both values are objects, the objects are merged recursively so that an overlay may change a single path
+// (such as one set with `Set(k, v, true)`) without repeating its siblings. Arrays and secure values are replaced
+// wholesale.
+func (m Map) Merge(overlay Map) error {
+	for k, v := range overlay {
+		existing, ok := m[k]
+		if !ok || !existing.Object() || !v.Object() {
+			m[k] = v
+			continue
+		}
+
+		base, err := existing.ToObject()
+		if err != nil {
+			return err
+		}
+		top, err := v.ToObject()
+		if err != nil {
+			return err
+		}
+
+		merged := mergeObjects(base, top)
+		json, err := json.Marshal(merged)
+		if err != nil {
+			return err
+		}
+		if hasSecureValue(merged) {
+			m[k] = NewSecureObjectValue(string(json))
+		} else {
+			m[k] = NewObjectValue(string(json))
+		}
+	}
+	return nil
+}
+
 // Get gets the value for a given key. If path is true, the key's name portion is treated as a path.
 func (m Map) Get(k Key, path bool) (Value, bool, error) {
 	// If the key isn't a path, go ahead and lookup the value.
@@ -521,3 +570,32 @@ func adjustObjectValue(v Value, path bool) interface{} {
 	// Otherwise, just return the string value.
 	return v.value
 }
+
+// mergeObjects returns the result of recursively merging top into base. Maps are merged key by key; any other value
+// in top, including secure values, replaces the corresponding value in base.
+func mergeObjects(base, top interface{}) interface{} {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	topMap, topIsMap := top.(map[string]interface{})
+	if !baseIsMap || !topIsMap {
+		return top
+	}
+	if isSecure, _ := isSecureValue(baseMap); isSecure {
+		return top
+	}
+	if isSecure, _ := isSecureValue(topMap); isSecure {
+		return top
+	}
+
+	merged := make(map[string]interface{})
+	for key, val := range baseMap {
+		merged[key] = val
+	}
+	for key, val := range topMap {
+		if existing, has := merged[key]; has {
+			merged[key] = mergeObjects(existing, val)
+		} else {
+			merged[key] = val
+		}
+	}
+	return merged
+}
@@ -256,6 +256,85 @@ func TestDecrypt(t *testing.T) {
 	}
 }
 
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		Base     Map
+		Overlay  Map
+		Expected Map
+	}{
+		{
+			Base: Map{
+				MustMakeKey("my", "a"): NewValue("base"),
+				MustMakeKey("my", "b"): NewValue("base"),
+			},
+			Overlay: Map{
+				MustMakeKey("my", "b"): NewValue("overlay"),
+				MustMakeKey("my", "c"): NewSecureValue("overlay"),
+			},
+			Expected: Map{
+				MustMakeKey("my", "a"): NewValue("base"),
+				MustMakeKey("my", "b"): NewValue("overlay"),
+				MustMakeKey("my", "c"): NewSecureValue("overlay"),
+			},
+		},
+		{
+			Base: Map{
+				MustMakeKey("my", "tags"): NewObjectValue(`{"env":"dev","owner":{"team":"a","pager":"x"}}`),
+			},
+			Overlay: Map{
+				MustMakeKey("my", "tags"): NewObjectValue(`{"owner":{"team":"b"},"region":"us"}`),
+			},
+			Expected: Map{
+				MustMakeKey("my", "tags"): NewObjectValue(`{"env":"dev","owner":{"pager":"x","team":"b"},"region":"us"}`),
+			},
+		},
+		{
+			Base: Map{
+				MustMakeKey("my", "names"): NewObjectValue(`["a","b"]`),
+				MustMakeKey("my", "obj"):   NewObjectValue(`{"inner":"value"}`),
+			},
+			Overlay: Map{
+				MustMakeKey("my", "names"): NewObjectValue(`["c"]`),
+				MustMakeKey("my", "obj"):   NewValue("replaced"),
+			},
+			Expected: Map{
+				MustMakeKey("my", "names"): NewObjectValue(`["c"]`),
+				MustMakeKey("my", "obj"):   NewValue("replaced"),
+			},
+		},
+		{
+			Base: Map{
+				MustMakeKey("my", "obj"): NewObjectValue(`{"a":"value","b":{"inner":"value"}}`),
+			},
+			Overlay: Map{
+				MustMakeKey("my", "obj"): NewSecureObjectValue(`{"b":{"secure":"securevalue"}}`),
+			},
+			Expected: Map{
+				MustMakeKey("my", "obj"): NewSecureObjectValue(`{"a":"value","b":{"secure":"securevalue"}}`),
+			},
+		},
+		{
+			Base: Map{
+				MustMakeKey("my", "obj"): NewSecureObjectValue(`{"a":{"secure":"securevalue"}}`),
+			},
+			Overlay: Map{
+				MustMakeKey("my", "obj"): NewObjectValue(`{"a":"value"}`),
+			},
+			Expected: Map{
+				MustMakeKey("my", "obj"): NewObjectValue(`{"a":"value"}`),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test), func(t *testing.T) {
+			err := test.Base.Merge(test.Overlay)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, test.Base)
+		})
+	}
+}
+
 func TestGetSuccess(t *testing.T) {
 	tests := []struct {
 		Key            string
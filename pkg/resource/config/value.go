@@ -273,3 +273,77 @@ func decryptObject(v interface{}, decrypter Decrypter) (interface{}, error) {
 	}
 	return v, nil
 }
+
+// Copy returns a copy of this value whose secure portions have been decrypted using decrypter and re-encrypted using
+// encrypter. This is used to move secrets between configuration files protected by different secrets managers.
+func (c Value) Copy(decrypter Decrypter, encrypter Encrypter) (Value, error) {
+	if !c.secure {
+		return c, nil
+	}
+
+	if !c.object {
+		plaintext, err := decrypter.DecryptValue(c.value)
+		if err != nil {
+			return Value{}, err
+		}
+		ciphertext, err := encrypter.EncryptValue(plaintext)
+		if err != nil {
+			return Value{}, err
+		}
+		return NewSecureValue(ciphertext), nil
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal([]byte(c.value), &obj); err != nil {
+		return Value{}, err
+	}
+	copied, err := reencryptObject(obj, decrypter, encrypter)
+	if err != nil {
+		return Value{}, err
+	}
+	json, err := json.Marshal(copied)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewSecureObjectValue(string(json)), nil
+}
+
+// reencryptObject returns a new object with all secure values in the object decrypted using decrypter and then
+// encrypted again using encrypter.
+func reencryptObject(v interface{}, decrypter Decrypter, encrypter Encrypter) (interface{}, error) {
+	if isSecure, secureVal := isSecureValue(v); isSecure {
+		plaintext, err := decrypter.DecryptValue(secureVal)
+		if err != nil {
+			return nil, err
+		}
+		ciphertext, err := encrypter.EncryptValue(plaintext)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"secure": ciphertext}, nil
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for key, val := range t {
+			copied, err := reencryptObject(val, decrypter, encrypter)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = copied
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, val := range t {
+			copied, err := reencryptObject(val, decrypter, encrypter)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = copied
+		}
+		return a, nil
+	}
+	return v, nil
+}
@@ -17,6 +17,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -264,3 +265,53 @@ func roundtripValue(v Value, marshal func(v interface{}) ([]byte, error),
 	err = unmarshal(b, &newV)
 	return newV, err
 }
+
+type prefixCrypter struct {
+	prefix string
+}
+
+func (c prefixCrypter) EncryptValue(plaintext string) (string, error) {
+	return c.prefix + plaintext, nil
+}
+
+func (c prefixCrypter) DecryptValue(ciphertext string) (string, error) {
+	return strings.TrimPrefix(ciphertext, c.prefix), nil
+}
+
+func TestCopyValue(t *testing.T) {
+	tests := []struct {
+		Value    Value
+		Expected Value
+	}{
+		{
+			Value:    NewValue("value"),
+			Expected: NewValue("value"),
+		},
+		{
+			Value:    NewObjectValue(`{"foo":"bar"}`),
+			Expected: NewObjectValue(`{"foo":"bar"}`),
+		},
+		{
+			Value:    NewSecureValue("old:securevalue"),
+			Expected: NewSecureValue("new:securevalue"),
+		},
+		{
+			Value:    NewSecureObjectValue(`{"foo":{"secure":"old:securevalue"}}`),
+			Expected: NewSecureObjectValue(`{"foo":{"secure":"new:securevalue"}}`),
+		},
+		{
+			Value:    NewSecureObjectValue(`["a",{"secure":"old:alpha"},{"test":{"secure":"old:beta"}}]`),
+			Expected: NewSecureObjectValue(`["a",{"secure":"new:alpha"},{"test":{"secure":"new:beta"}}]`),
+		},
+	}
+
+	decrypter, encrypter := prefixCrypter{prefix: "old:"}, prefixCrypter{prefix: "new:"}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.Value), func(t *testing.T) {
+			actual, err := test.Value.Copy(decrypter, encrypter)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, actual)
+		})
+	}
+}
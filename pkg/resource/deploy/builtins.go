@@ -178,6 +178,11 @@ func (p *builtinProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{}, errors.New("the builtin provider does not report plugin info")
 }
 
+func (p *builtinProvider) GetSchema(version int) ([]byte, error) {
+	// return an error: this should not be called for the builtin provider
+	return nil, errors.New("the builtin provider does not report a schema")
+}
+
 func (p *builtinProvider) SignalCancellation() error {
 	p.cancel()
 	return nil
@@ -57,6 +57,8 @@ type Provider struct {
 		inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
 
 	CancelF func() error
+
+	GetSchemaF func(version int) ([]byte, error)
 }
 
 func (prov *Provider) SignalCancellation() error {
@@ -81,6 +83,13 @@ func (prov *Provider) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+func (prov *Provider) GetSchema(version int) ([]byte, error) {
+	if prov.GetSchemaF == nil {
+		return []byte("{}"), nil
+	}
+	return prov.GetSchemaF(version)
+}
+
 func (prov *Provider) CheckConfig(urn resource.URN, olds,
 	news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
 	if prov.CheckConfigF == nil {
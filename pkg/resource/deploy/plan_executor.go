@@ -362,7 +362,16 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
 	switch e := event.(type) {
 	case RegisterResourceEvent:
 		logging.V(4).Infof("planExecutor.handleSingleEvent(...): received RegisterResourceEvent")
+
+		// Provider requests made while generating the resource's steps, such as Check and Diff, are traced within the
+		// span of the resource's first step.
+		urn := pe.plan.generateEventURN(e)
+		pe.plan.ctx.SetResourceSpan(urn, pe.stepExec.StartStepSpan(urn))
 		steps, res = pe.stepGen.GenerateSteps(e)
+		pe.plan.ctx.SetResourceSpan(urn, nil)
+		if res != nil || len(steps) == 0 {
+			pe.stepExec.AbandonStepSpan(urn, res)
+		}
 	case ReadResourceEvent:
 		logging.V(4).Infof("planExecutor.handleSingleEvent(...): received ReadResourceEvent")
 		steps, res = pe.stepGen.GenerateReadSteps(e)
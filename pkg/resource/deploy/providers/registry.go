@@ -396,6 +396,11 @@ func (r *Registry) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{}, errors.New("the provider registry does not report plugin info")
 }
 
+func (r *Registry) GetSchema(version int) ([]byte, error) {
+	// return an error: this should not be called for the provider registry
+	return nil, errors.New("the provider registry does not report a schema")
+}
+
 func (r *Registry) SignalCancellation() error {
 	// At the moment there isn't anything reasonable we can do here. In the future, it might be nice to plumb
 	// cancellation through the plugin loader and cancel any outstanding load requests here.
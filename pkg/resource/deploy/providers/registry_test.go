@@ -157,6 +157,10 @@ func (prov *testProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+func (prov *testProvider) GetSchema(version int) ([]byte, error) {
+	return []byte("{}"), nil
+}
+
 type providerLoader struct {
 	pkg     tokens.Package
 	version semver.Version
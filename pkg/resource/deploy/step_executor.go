@@ -20,11 +20,15 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/result"
 )
 
 const (
@@ -97,6 +101,9 @@ type stepExecutor struct {
 	ctx      context.Context    // cancellation context for the current plan.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
+
+	stepSpansLock sync.Mutex                        // lock protecting stepSpans.
+	stepSpans     map[resource.URN]opentracing.Span // spans started while generating steps that no step has adopted.
 }
 
 //
@@ -199,6 +206,67 @@ func (se *stepExecutor) WaitForCompletion() {
 	se.log(synchronousWorkerID, "StepExecutor.waitForCompletion(): waiting for worker threads to exit")
 	se.workers.Wait()
 	se.log(synchronousWorkerID, "StepExecutor.waitForCompletion(): worker threads all exited")
+
+	// Finish the spans of any steps that were generated but never executed, e.g. due to cancellation.
+	se.stepSpansLock.Lock()
+	defer se.stepSpansLock.Unlock()
+	for urn, span := range se.stepSpans {
+		span.Finish()
+		delete(se.stepSpans, urn)
+	}
+}
+
+// StartStepSpan starts the span for the next step of the given resource. Provider requests that are made on behalf
+// of the resource while its steps are generated (e.g. Check and Diff) are parented within this span, which the first
+// of the resource's steps then adopts when it is executed.
+func (se *stepExecutor) StartStepSpan(urn resource.URN) opentracing.Span {
+	span, _ := opentracing.StartSpanFromContext(se.ctx, "pulumi-step", opentracing.Tags{"pulumi.urn": string(urn)})
+
+	se.stepSpansLock.Lock()
+	defer se.stepSpansLock.Unlock()
+	if prior, has := se.stepSpans[urn]; has {
+		prior.Finish()
+	}
+	se.stepSpans[urn] = span
+	return span
+}
+
+// AbandonStepSpan finishes the span started for the given resource's steps if no steps were generated, marking it as
+// failed if step generation failed.
+func (se *stepExecutor) AbandonStepSpan(urn resource.URN, res result.Result) {
+	se.stepSpansLock.Lock()
+	span, has := se.stepSpans[urn]
+	delete(se.stepSpans, urn)
+	se.stepSpansLock.Unlock()
+
+	if has {
+		if res != nil {
+			ext.Error.Set(span, true)
+			if err := res.Error(); err != nil {
+				span.LogFields(log.Error(err))
+			}
+		}
+		span.Finish()
+	}
+}
+
+// stepSpan returns the span for the given step, adopting the span that was started while the step was generated if
+// there is one.
+func (se *stepExecutor) stepSpan(step Step) opentracing.Span {
+	se.stepSpansLock.Lock()
+	span, has := se.stepSpans[step.URN()]
+	delete(se.stepSpans, step.URN())
+	se.stepSpansLock.Unlock()
+
+	if !has {
+		span, _ = opentracing.StartSpanFromContext(se.ctx, "pulumi-step",
+			opentracing.Tags{"pulumi.urn": string(step.URN())})
+	}
+	span.SetTag("pulumi.type", string(step.Type()))
+	span.SetTag("pulumi.op", string(step.Op()))
+	span.SetTag("pulumi.provider", step.Provider())
+	span.SetTag("pulumi.preview", se.preview)
+	return span
 }
 
 //
@@ -256,6 +324,9 @@ func (se *stepExecutor) cancelDueToError() {
 // executeStep executes a single step, returning true if the step execution was successful and
 // false if it was not.
 func (se *stepExecutor) executeStep(workerID int, step Step) error {
+	span := se.stepSpan(step)
+	defer span.Finish()
+
 	var payload interface{}
 	events := se.opts.Events
 	if events != nil {
@@ -267,8 +338,15 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 		}
 	}
 
+	// Parent any provider requests made while the step is applied within the step's span.
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
+	se.plan.ctx.SetResourceSpan(step.URN(), span)
 	status, stepComplete, err := step.Apply(se.preview)
+	se.plan.ctx.SetResourceSpan(step.URN(), nil)
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -390,6 +468,7 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, plan *Plan,
 		incomingChains:  make(chan incomingChain),
 		ctx:             ctx,
 		cancel:          cancel,
+		stepSpans:       map[resource.URN]opentracing.Span{},
 	}
 
 	exec.sawError.Store(false)
@@ -0,0 +1,111 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
+	"github.com/pulumi/pulumi/pkg/resource/provider/providertest"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+type tracedResource struct {
+	Name string `pulumi:"name"`
+}
+
+func (r *tracedResource) Create(ctx context.Context) (resource.ID, error) {
+	return resource.ID(r.Name), nil
+}
+
+func (r *tracedResource) Delete(ctx context.Context, id resource.ID) error { return nil }
+
+func TestProviderRequestSpans(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	prov := provider.NewProvider("pkgA", semver.MustParse("1.0.0"))
+	assert.NoError(t, prov.RegisterResource("pkgA:m:typA", &tracedResource{}))
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"name": resource.NewStringProperty("a")},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	// Serve the provider through the plan's context, as the default plugin host does.
+	var ctx *plugin.Context
+	loader := deploytest.NewProviderLoaderWithHost("pkgA", semver.MustParse("1.0.0"),
+		func(plugin.Host) (plugin.Provider, error) {
+			return providertest.ServeContext(ctx, prov)
+		})
+	sink := cmdutil.Diag()
+	ctx, err := plugin.NewContext(sink, sink, deploytest.NewPluginHost(sink, sink, program, loader), nil, "", nil, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer contract.IgnoreClose(ctx)
+
+	target := &Target{Name: "test"}
+	runInfo := &EvalRunInfo{Proj: &workspace.Project{Name: "test"}, Target: target}
+	plan, err := NewPlan(ctx, target, nil, NewEvalSource(ctx, runInfo, nil, false), nil, false, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	planSpan := tracer.StartSpan("pulumi-plan")
+	res := plan.Execute(opentracing.ContextWithSpan(context.Background(), planSpan), Options{}, false)
+	planSpan.Finish()
+	assert.Nil(t, res)
+
+	urn := string(resource.NewURN("test", "test", "", "pkgA:m:typA", "resA"))
+	var stepSpan *mocktracer.MockSpan
+	rpcSpans := map[string]*mocktracer.MockSpan{}
+	for _, span := range tracer.FinishedSpans() {
+		switch {
+		case span.OperationName == "pulumi-step" && span.Tag("pulumi.urn") == urn:
+			stepSpan = span
+		case span.Tag("span.kind") == ext.SpanKindRPCClientEnum && span.Tag("pulumi.urn") == urn:
+			rpcSpans[span.OperationName] = span
+		}
+	}
+	if !assert.NotNil(t, stepSpan) {
+		t.FailNow()
+	}
+	assert.Equal(t, "create", stepSpan.Tag("pulumi.op"))
+
+	// The Check request is made while the step is generated, and the Create request while it is applied. Both are
+	// traced within the step's span.
+	for _, method := range []string{"/pulumirpc.ResourceProvider/Check", "/pulumirpc.ResourceProvider/Create"} {
+		if span, ok := rpcSpans[method]; assert.True(t, ok, method) {
+			assert.Equal(t, stepSpan.SpanContext.SpanID, span.ParentID, method)
+		}
+	}
+}
@@ -16,10 +16,12 @@ package plugin
 
 import (
 	"context"
+	"sync"
 
 	"github.com/opentracing/opentracing-go"
 
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 )
 
@@ -31,7 +33,9 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
-	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
+	tracingSpan   opentracing.Span                  // the OpenTracing span to parent requests within.
+	resourceSpans map[resource.URN]opentracing.Span // the OpenTracing spans to parent resource requests within.
+	spanLock      sync.RWMutex                      // a lock protecting resourceSpans.
 }
 
 // NewContext allocates a new context with a given sink and host.  Note that the host is "owned" by this context from
@@ -61,6 +65,34 @@ func (ctx *Context) Request() context.Context {
 	return opentracing.ContextWithSpan(context.Background(), ctx.tracingSpan)
 }
 
+// SetResourceSpan sets the OpenTracing span to parent this context's requests for the given resource within. Passing a
+// nil span clears the resource's span, after which its requests are parented within the context's span.
+func (ctx *Context) SetResourceSpan(urn resource.URN, span opentracing.Span) {
+	ctx.spanLock.Lock()
+	defer ctx.spanLock.Unlock()
+
+	if span == nil {
+		delete(ctx.resourceSpans, urn)
+		return
+	}
+	if ctx.resourceSpans == nil {
+		ctx.resourceSpans = make(map[resource.URN]opentracing.Span)
+	}
+	ctx.resourceSpans[urn] = span
+}
+
+// ResourceRequest allocates a request sub-context for an operation on the given resource.
+func (ctx *Context) ResourceRequest(urn resource.URN) context.Context {
+	ctx.spanLock.RLock()
+	span, ok := ctx.resourceSpans[urn]
+	ctx.spanLock.RUnlock()
+
+	if ok {
+		return opentracing.ContextWithSpan(context.Background(), span)
+	}
+	return ctx.Request()
+}
+
 // Close reclaims all resources associated with this context.
 func (ctx *Context) Close() error {
 	if ctx.tracingSpan != nil {
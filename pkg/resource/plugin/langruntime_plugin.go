@@ -55,9 +55,10 @@ func NewLanguageRuntime(host Host, ctx *Context, runtime string,
 		})
 	}
 
+	// Pass the runtime options to the plugin as flags. Options may be booleans or strings.
 	var args []string
 	for k, v := range options {
-		args = append(args, fmt.Sprintf("-%s=%t", k, v))
+		args = append(args, fmt.Sprintf("-%s=%v", k, v))
 	}
 	args = append(args, host.ServerAddr())
 
@@ -245,8 +245,9 @@ func execPlugin(bin string, pluginArgs []string, pwd string, env []string) (*plu
 			args = append(args, "-v="+strconv.Itoa(logging.Verbose))
 		}
 	}
-	// Flow tracing settings if we are using a remote collector.
-	if cmdutil.TracingEndpoint != "" && !cmdutil.TracingToFile {
+	// Flow tracing settings if we are using a remote collector. Plugins may not understand OTLP endpoints, so those
+	// are not flowed; the CLI traces its requests to plugins instead.
+	if cmdutil.TracingEndpoint != "" && !cmdutil.TracingToFile && !cmdutil.TracingToOTLP {
 		args = append(args, "--tracing", cmdutil.TracingEndpoint)
 	}
 	args = append(args, pluginArgs...)
@@ -79,6 +79,8 @@ type Provider interface {
 		onNext func(resource.PropertyMap) error) ([]CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
+	// GetSchema returns the schema for the provider's package.
+	GetSchema(version int) ([]byte, error)
 
 	// SignalCancellation asks all resource providers to gracefully shut down and abort any ongoing
 	// operations. Operation aborted in this way will return an error (e.g., `Update` and `Create`
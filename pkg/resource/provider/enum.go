@@ -0,0 +1,194 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+// Enum may be implemented by named boolean, integer, number or string types whose values are restricted to a fixed
+// set.  Properties of such types are described as enum types in the package schema, and Check rejects values that
+// are not in the set.  EnumValues is called on the zero value of the type.
+type Enum interface {
+	EnumValues() []EnumValue
+}
+
+// EnumValue describes a single value of an Enum type.
+type EnumValue struct {
+	// Name is the name of the value, if any.  Language generators derive a name from the value if this is empty.
+	Name string
+	// Description is the description of the value, if any.
+	Description string
+	// Value is the value itself.  Its type must be the Enum type or the Enum type's underlying type.
+	Value interface{}
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// enumValues returns the values of the given type, both as declared and as property values, if it implements Enum.
+// If the type does not implement Enum, enumValues returns nil.
+func enumValues(typ reflect.Type) ([]EnumValue, []resource.PropertyValue, error) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if !typ.Implements(enumType) {
+		return nil, nil, nil
+	}
+
+	enumValues := reflect.Zero(typ).Interface().(Enum).EnumValues()
+	if len(enumValues) == 0 {
+		return nil, nil, errors.Errorf("enum %v must have at least one value", typ)
+	}
+
+	values := make([]resource.PropertyValue, len(enumValues))
+	for i, ev := range enumValues {
+		v := reflect.ValueOf(ev.Value)
+		if !v.IsValid() || v.Kind() != typ.Kind() {
+			return nil, nil, errors.Errorf("value %v of enum %v must be of kind %v", ev.Value, typ, typ.Kind())
+		}
+
+		switch v.Kind() {
+		case reflect.Bool:
+			values[i] = resource.NewBoolProperty(v.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values[i] = resource.NewNumberProperty(float64(v.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values[i] = resource.NewNumberProperty(float64(v.Uint()))
+		case reflect.Float32, reflect.Float64:
+			values[i] = resource.NewNumberProperty(v.Float())
+		case reflect.String:
+			values[i] = resource.NewStringProperty(v.String())
+		default:
+			return nil, nil, errors.Errorf("enum %v must be a boolean, integer, number or string type", typ)
+		}
+	}
+	return enumValues, values, nil
+}
+
+// validateEnums returns an error if the given type, or any type it contains, implements Enum incorrectly.
+func validateEnums(typ reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	if _, _, err := enumValues(typ); err != nil {
+		return err
+	}
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return validateEnums(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			info := typ.Field(i)
+			if _, ok := objectFieldName(info); ok {
+				if err := validateEnums(info.Type, seen); err != nil {
+					return errors.Wrapf(err, "%v.%v", typ.Name(), info.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// objectFieldName returns the name of the property that corresponds to the given field of a struct type that is not
+// itself a resource, if any.
+func objectFieldName(info reflect.StructField) (string, bool) {
+	tag := info.Tag.Get("pulumi")
+	if tag == "" {
+		tag = info.Tag.Get("json")
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" || name == "-" || info.PkgPath != "" {
+		return "", false
+	}
+	return name, true
+}
+
+// checkEnums returns a check failure for each known value within the given value that is of a type that implements
+// Enum but is not one of the enum's values.  Objects, arrays and maps are checked recursively; path names the value
+// within the resource's inputs.
+func checkEnums(path string, typ reflect.Type, v resource.PropertyValue) []plugin.CheckFailure {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	v = unwrapSecrets(v)
+
+	if _, values, err := enumValues(typ); err != nil || values != nil {
+		if err == nil {
+			err = checkEnum(path, v, values)
+		}
+		if err != nil {
+			return []plugin.CheckFailure{{Property: resource.PropertyKey(path), Reason: err.Error()}}
+		}
+		return nil
+	}
+
+	var failures []plugin.CheckFailure
+	switch {
+	case typ.Kind() == reflect.Struct && v.IsObject():
+		obj := v.ObjectValue()
+		for i := 0; i < typ.NumField(); i++ {
+			info := typ.Field(i)
+			if name, ok := objectFieldName(info); ok {
+				if e, has := obj[resource.PropertyKey(name)]; has {
+					failures = append(failures, checkEnums(path+"."+name, info.Type, e)...)
+				}
+			}
+		}
+	case typ.Kind() == reflect.Map && v.IsObject():
+		obj := v.ObjectValue()
+		for _, k := range obj.StableKeys() {
+			failures = append(failures, checkEnums(fmt.Sprintf("%s[%q]", path, k), typ.Elem(), obj[k])...)
+		}
+	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && v.IsArray():
+		for i, e := range v.ArrayValue() {
+			failures = append(failures, checkEnums(fmt.Sprintf("%s[%d]", path, i), typ.Elem(), e)...)
+		}
+	}
+	return failures
+}
+
+// checkEnum returns an error if the given value is known but is not one of the given enum values.
+func checkEnum(path string, v resource.PropertyValue, values []resource.PropertyValue) error {
+	if v.ContainsUnknowns() || v.IsNull() {
+		return nil
+	}
+
+	allowed := make([]string, len(values))
+	for i, ev := range values {
+		if v.DeepEquals(ev) {
+			return nil
+		}
+		allowed[i] = formatEnumValue(ev)
+	}
+	return errors.Errorf("invalid value %s for property '%s'; allowed values are %s", formatEnumValue(v), path,
+		strings.Join(allowed, ", "))
+}
+
+// formatEnumValue formats a primitive property value for display in a Check failure.
+func formatEnumValue(v resource.PropertyValue) string {
+	if v.IsString() {
+		return fmt.Sprintf("%q", v.StringValue())
+	}
+	return fmt.Sprintf("%v", v.V)
+}
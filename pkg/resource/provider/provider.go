@@ -0,0 +1,531 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	pbstruct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Provider is a resource provider whose resource types are modeled by Go structs.  Rather than implementing each of
+// the resource provider RPCs by hand, authors register a Resource implementation per resource type and the provider
+// derives Check, Diff, and the provider's schema from the registered types.
+type Provider struct {
+	name    string
+	version semver.Version
+	types   map[tokens.Type]*resourceType
+
+	host   *HostClient
+	config resource.PropertyMap
+}
+
+var _ pulumirpc.ResourceProviderServer = (*Provider)(nil)
+
+// replaceKinds maps each kind of property diff to the kind that is reported when the change requires a replacement.
+var replaceKinds = map[pulumirpc.PropertyDiff_Kind]pulumirpc.PropertyDiff_Kind{
+	pulumirpc.PropertyDiff_ADD:    pulumirpc.PropertyDiff_ADD_REPLACE,
+	pulumirpc.PropertyDiff_DELETE: pulumirpc.PropertyDiff_DELETE_REPLACE,
+	pulumirpc.PropertyDiff_UPDATE: pulumirpc.PropertyDiff_UPDATE_REPLACE,
+}
+
+// NewProvider creates a new provider with the given package name and version.
+func NewProvider(name string, version semver.Version) *Provider {
+	return &Provider{
+		name:    name,
+		version: version,
+		types:   make(map[tokens.Type]*resourceType),
+	}
+}
+
+// Name returns the name of the package served by this provider.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Version returns the version of this provider.
+func (p *Provider) Version() semver.Version {
+	return p.version
+}
+
+// Host returns the client for the engine that loaded this provider.  This is nil if the provider is not being served
+// by Main.
+func (p *Provider) Host() *HostClient {
+	return p.host
+}
+
+// Config returns the configuration passed to this provider by the engine.
+func (p *Provider) Config() resource.PropertyMap {
+	return p.config
+}
+
+// RegisterResource registers the Go type of the given prototype as the implementation of the resource type with the
+// given token.  The prototype must be a pointer to a struct; see Resource for a description of its fields.
+func (p *Provider) RegisterResource(token tokens.Type, prototype Resource) error {
+	if token.Package() != tokens.Package(p.name) {
+		return errors.Errorf("resource type %s does not belong to package %s", token, p.name)
+	}
+	if _, has := p.types[token]; has {
+		return errors.Errorf("resource type %s is already registered", token)
+	}
+
+	rt, err := newResourceType(token, prototype)
+	if err != nil {
+		return err
+	}
+	p.types[token] = rt
+	return nil
+}
+
+// Schema returns the schema for this provider's package, derived from its registered resource types.
+func (p *Provider) Schema() (schema.PackageSpec, error) {
+	st := &schemaTypes{
+		pkg:     tokens.Package(p.name),
+		types:   map[string]schema.ComplexTypeSpec{},
+		goTypes: map[string]reflect.Type{},
+	}
+
+	spec := schema.PackageSpec{
+		Name:      p.name,
+		Version:   p.version.String(),
+		Resources: map[string]schema.ResourceSpec{},
+	}
+	for token, rt := range p.types {
+		rs, err := st.resourceSpec(rt)
+		if err != nil {
+			return schema.PackageSpec{}, err
+		}
+		spec.Resources[string(token)] = rs
+	}
+	if len(st.types) != 0 {
+		spec.Types = st.types
+	}
+	return spec, nil
+}
+
+// Main serves this provider as a resource provider plugin.  See the package-level Main function for details.
+func (p *Provider) Main() error {
+	return Main(p.name, func(host *HostClient) (pulumirpc.ResourceProviderServer, error) {
+		p.host = host
+		return p, nil
+	})
+}
+
+type providerKey struct{}
+
+// GetProvider returns the provider that is invoking a resource's methods.
+func GetProvider(ctx context.Context) *Provider {
+	p, _ := ctx.Value(providerKey{}).(*Provider)
+	return p
+}
+
+// context returns a context for calls into a resource's methods.
+func (p *Provider) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, providerKey{}, p)
+}
+
+// resourceType returns the registered resource type for the given URN.
+func (p *Provider) resourceType(urn resource.URN) (*resourceType, error) {
+	rt, has := p.types[urn.Type()]
+	if !has {
+		return nil, errors.Errorf("unknown resource type '%s'", urn.Type())
+	}
+	return rt, nil
+}
+
+// unmarshal decodes a set of properties sent by the engine.
+func (p *Provider) unmarshal(urn resource.URN, label string, props *pbstruct.Struct) (resource.PropertyMap, error) {
+	return plugin.UnmarshalProperties(props, plugin.MarshalOptions{
+		Label:        fmt.Sprintf("%s.%s", urn, label),
+		KeepUnknowns: true,
+		SkipNulls:    true,
+		KeepSecrets:  true,
+	})
+}
+
+// marshal encodes a set of properties to be sent to the engine.
+func (p *Provider) marshal(urn resource.URN, label string, props resource.PropertyMap) (*pbstruct.Struct, error) {
+	return plugin.MarshalProperties(props, plugin.MarshalOptions{
+		Label:        fmt.Sprintf("%s.%s", urn, label),
+		KeepUnknowns: true,
+		SkipNulls:    true,
+		KeepSecrets:  true,
+	})
+}
+
+// GetSchema returns the JSON-encoded schema for this provider's package.
+func (p *Provider) GetSchema(ctx context.Context,
+	req *pulumirpc.GetSchemaRequest) (*pulumirpc.GetSchemaResponse, error) {
+
+	if v := req.GetVersion(); v != 0 {
+		return nil, errors.Errorf("unsupported schema version %d", v)
+	}
+
+	spec, err := p.Schema()
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GetSchemaResponse{Schema: string(bytes)}, nil
+}
+
+// CheckConfig validates the configuration for this resource provider.
+func (p *Provider) CheckConfig(ctx context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+// DiffConfig checks the impact a hypothetical change to this provider's configuration will have on the provider.
+func (p *Provider) DiffConfig(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	return &pulumirpc.DiffResponse{}, nil
+}
+
+// Configure configures the resource provider with "globals" that control its behavior.
+func (p *Provider) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+
+	config, err := p.unmarshal("", "config", req.GetArgs())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetArgs() == nil {
+		config = resource.PropertyMap{}
+		for k, v := range req.GetVariables() {
+			// Variables use the older "pkg:config:name" spelling of configuration keys.
+			k = strings.TrimPrefix(k, p.name+":config:")
+			config[resource.PropertyKey(k)] = resource.NewStringProperty(v)
+		}
+	}
+	p.config = config
+
+	return &pulumirpc.ConfigureResponse{AcceptSecrets: true}, nil
+}
+
+// Invoke dynamically executes a built-in function in the provider.
+func (p *Provider) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	return nil, errors.Errorf("unknown Invoke token '%s'", req.GetTok())
+}
+
+// StreamInvoke dynamically executes a built-in function in the provider. The result is streamed back as a series of
+// messages.
+func (p *Provider) StreamInvoke(req *pulumirpc.InvokeRequest,
+	server pulumirpc.ResourceProvider_StreamInvokeServer) error {
+
+	return errors.Errorf("unknown StreamInvoke token '%s'", req.GetTok())
+}
+
+// Check validates that the given property bag is valid for a resource of the given type and returns the inputs
+// that should be passed to successive calls to Diff, Create, or Update for this resource.
+func (p *Provider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	news, err := p.unmarshal(urn, "news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, failures := rt.check(news)
+	rpcInputs, err := p.marshal(urn, "inputs", inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcFailures []*pulumirpc.CheckFailure
+	for _, f := range failures {
+		rpcFailures = append(rpcFailures, &pulumirpc.CheckFailure{Property: string(f.Property), Reason: f.Reason})
+	}
+	return &pulumirpc.CheckResponse{Inputs: rpcInputs, Failures: rpcFailures}, nil
+}
+
+// Diff checks what impacts a hypothetical update will have on the resource's properties.
+func (p *Provider) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := p.unmarshal(urn, "olds", req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, err := p.unmarshal(urn, "news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreChanges := map[resource.PropertyKey]bool{}
+	for _, k := range req.GetIgnoreChanges() {
+		ignoreChanges[resource.PropertyKey(k)] = true
+	}
+
+	// Inputs that the provider filled in itself have not been removed by the program just because it still does not
+	// set them.
+	oldInputs := rt.inputs(olds)
+	for _, k := range providerInputs(olds) {
+		if _, has := news[k]; !has {
+			delete(oldInputs, k)
+		}
+	}
+	diff := oldInputs.Diff(news, func(k resource.PropertyKey) bool {
+		return ignoreChanges[k]
+	})
+	if diff == nil {
+		return &pulumirpc.DiffResponse{
+			Changes:         pulumirpc.DiffResponse_DIFF_NONE,
+			HasDetailedDiff: true,
+		}, nil
+	}
+
+	_, canUpdate := rt.new().(Updater)
+
+	var diffs, replaces []string
+	detailedDiff := map[string]*pulumirpc.PropertyDiff{}
+	for _, k := range diff.Keys() {
+		if diff.Same(k) {
+			continue
+		}
+
+		fld, _ := rt.field(k)
+		replace := !canUpdate || fld.replace
+
+		var kind pulumirpc.PropertyDiff_Kind
+		switch {
+		case diff.Added(k):
+			kind = pulumirpc.PropertyDiff_ADD
+		case diff.Deleted(k):
+			kind = pulumirpc.PropertyDiff_DELETE
+		default:
+			kind = pulumirpc.PropertyDiff_UPDATE
+		}
+		if replace {
+			kind = replaceKinds[kind]
+			replaces = append(replaces, string(k))
+		}
+
+		diffs = append(diffs, string(k))
+		detailedDiff[string(k)] = &pulumirpc.PropertyDiff{Kind: kind}
+	}
+	sort.Strings(diffs)
+	sort.Strings(replaces)
+
+	changes := pulumirpc.DiffResponse_DIFF_NONE
+	if len(diffs) > 0 {
+		changes = pulumirpc.DiffResponse_DIFF_SOME
+	}
+	return &pulumirpc.DiffResponse{
+		Changes:         changes,
+		Diffs:           diffs,
+		Replaces:        replaces,
+		DetailedDiff:    detailedDiff,
+		HasDetailedDiff: true,
+	}, nil
+}
+
+// Create allocates a new instance of the provided resource and returns its unique ID afterwards.
+func (p *Provider) Create(ctx context.Context, req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := p.unmarshal(urn, "properties", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	res, err := rt.decode(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.Create(p.context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, errors.Errorf("provider did not return an ID for %s", urn)
+	}
+
+	state, err := rt.state(res, inputs)
+	if err != nil {
+		return nil, err
+	}
+	rpcState, err := p.marshal(urn, "state", state)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: string(id), Properties: rpcState}, nil
+}
+
+// Read the current live state associated with a resource.
+func (p *Provider) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := p.unmarshal(urn, "properties", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	res, err := rt.decode(olds)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the resource cannot be read, simply return its last known state.
+	id := resource.ID(req.GetId())
+	if reader, ok := res.(Reader); ok {
+		if err = reader.Read(p.context(ctx), id); err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := rt.encode(res, olds)
+	if err != nil {
+		return nil, err
+	}
+	inputs := rt.inputs(state)
+	if filled, has := olds[providerInputsKey]; has {
+		state[providerInputsKey] = filled
+		for _, k := range providerInputs(olds) {
+			delete(inputs, k)
+		}
+	}
+	rpcState, err := p.marshal(urn, "state", state)
+	if err != nil {
+		return nil, err
+	}
+	rpcInputs, err := p.marshal(urn, "inputs", inputs)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResponse{Id: string(id), Properties: rpcState, Inputs: rpcInputs}, nil
+}
+
+// Update updates an existing resource with new values.
+func (p *Provider) Update(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := p.unmarshal(urn, "olds", req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, err := p.unmarshal(urn, "news", req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := rt.decode(olds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the old state so that outputs are preserved, then replace all of the inputs with the new inputs. The
+	// inputs that the provider filled in itself keep their old values unless the program now sets them.
+	res, err := rt.decode(olds)
+	if err != nil {
+		return nil, err
+	}
+	rt.resetInputs(res)
+	inputs := news.Copy()
+	for _, k := range providerInputs(olds) {
+		if _, has := inputs[k]; !has {
+			if v, has := olds[k]; has {
+				inputs[k] = v
+			}
+		}
+	}
+	if err = rt.decodeInto(res, inputs); err != nil {
+		return nil, err
+	}
+
+	updater, ok := res.(Updater)
+	if !ok {
+		return nil, errors.Errorf("resource type %s does not support updates", rt.token)
+	}
+	if err = updater.Update(p.context(ctx), resource.ID(req.GetId()), old); err != nil {
+		return nil, err
+	}
+
+	state, err := rt.state(res, news)
+	if err != nil {
+		return nil, err
+	}
+	rpcState, err := p.marshal(urn, "state", state)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: rpcState}, nil
+}
+
+// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
+func (p *Provider) Delete(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	urn := resource.URN(req.GetUrn())
+	rt, err := p.resourceType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	olds, err := p.unmarshal(urn, "properties", req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	res, err := rt.decode(olds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = res.Delete(p.context(ctx), resource.ID(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+// Cancel signals the provider to abort all outstanding resource operations.
+func (p *Provider) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
+
+// GetPluginInfo returns generic information about this plugin, like its version.
+func (p *Provider) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: p.version.String()}, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+type protocol string
+
+func (protocol) EnumValues() []EnumValue {
+	return []EnumValue{
+		{Name: "TCP", Value: protocol("tcp")},
+		{Name: "UDP", Description: "Datagrams.", Value: protocol("udp")},
+	}
+}
+
+type rule struct {
+	Port     int      `pulumi:"port"`
+	Protocol protocol `pulumi:"protocol,optional"`
+}
+
+type firewall struct {
+	Name  string  `pulumi:"name"`
+	Rules []rule  `pulumi:"rules,optional"`
+	Ratio float64 `pulumi:"ratio,optional" default:"0.5"`
+	URL   string  `pulumi:"url" provider:"output"`
+}
+
+func (f *firewall) Create(ctx context.Context) (resource.ID, error) { return "id", nil }
+
+func (f *firewall) Delete(ctx context.Context, id resource.ID) error { return nil }
+
+type badTag struct {
+	Name string `pulumi:"name" provider:"computed"`
+}
+
+func (b *badTag) Create(ctx context.Context) (resource.ID, error) { return "id", nil }
+
+func (b *badTag) Delete(ctx context.Context, id resource.ID) error { return nil }
+
+// URL has the same name as url.URL.
+type URL struct {
+	Host string `pulumi:"host"`
+}
+
+type endpoint struct {
+	Primary   URL     `pulumi:"primary"`
+	Secondary url.URL `pulumi:"secondary"`
+}
+
+func (e *endpoint) Create(ctx context.Context) (resource.ID, error) { return "id", nil }
+
+func (e *endpoint) Delete(ctx context.Context, id resource.ID) error { return nil }
+
+func TestRegisterResource(t *testing.T) {
+	p := NewProvider("test", semver.MustParse("0.1.0"))
+
+	assert.NoError(t, p.RegisterResource("test:net:Firewall", &firewall{}))
+	assert.Error(t, p.RegisterResource("test:net:Firewall", &firewall{}))
+	assert.Error(t, p.RegisterResource("other:net:Firewall", &firewall{}))
+	assert.Error(t, p.RegisterResource("test:net:Bad", &badTag{}))
+}
+
+type emptyEnum string
+
+func (emptyEnum) EnumValues() []EnumValue { return nil }
+
+type badEnum struct {
+	Name   string               `pulumi:"name"`
+	Values map[string]emptyEnum `pulumi:"values"`
+}
+
+func (b *badEnum) Create(ctx context.Context) (resource.ID, error) { return "id", nil }
+
+func (b *badEnum) Delete(ctx context.Context, id resource.ID) error { return nil }
+
+func TestRegisterNestedEnum(t *testing.T) {
+	p := NewProvider("test", semver.MustParse("0.1.0"))
+
+	err := p.RegisterResource("test:net:Bad", &badEnum{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must have at least one value")
+	}
+}
+
+func TestCheckNestedEnums(t *testing.T) {
+	rt, err := newResourceType("test:net:Firewall", &firewall{})
+	assert.NoError(t, err)
+
+	rule := func(port float64, protocol resource.PropertyValue) resource.PropertyValue {
+		return resource.NewObjectProperty(resource.PropertyMap{
+			"port":     resource.NewNumberProperty(port),
+			"protocol": protocol,
+		})
+	}
+	_, failures := rt.check(resource.PropertyMap{
+		"name": resource.NewStringProperty("fw"),
+		"rules": resource.NewArrayProperty([]resource.PropertyValue{
+			rule(22, resource.NewStringProperty("tcp")),
+			rule(53, resource.NewStringProperty("icmp")),
+			rule(80, resource.MakeSecret(resource.NewStringProperty("http"))),
+			rule(443, resource.MakeComputed(resource.NewStringProperty(""))),
+		}),
+	})
+	assert.Equal(t, []plugin.CheckFailure{
+		{
+			Property: "rules[1].protocol",
+			Reason:   `invalid value "icmp" for property 'rules[1].protocol'; allowed values are "tcp", "udp"`,
+		},
+		{
+			Property: "rules[2].protocol",
+			Reason:   `invalid value "http" for property 'rules[2].protocol'; allowed values are "tcp", "udp"`,
+		},
+	}, failures)
+}
+
+func TestSchema(t *testing.T) {
+	p := NewProvider("test", semver.MustParse("0.1.0"))
+	assert.NoError(t, p.RegisterResource("test:net:Firewall", &firewall{}))
+
+	spec, err := p.Schema()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1.0", spec.Version)
+
+	rules := schema.PropertySpec{TypeSpec: schema.TypeSpec{
+		Type:  "array",
+		Items: &schema.TypeSpec{Ref: "#/types/test:net:rule"},
+	}}
+	assert.Equal(t, map[string]schema.ResourceSpec{
+		"test:net:Firewall": {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Type: "object",
+				Properties: map[string]schema.PropertySpec{
+					"name":  {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"rules": rules,
+					"ratio": {TypeSpec: schema.TypeSpec{Type: "number"}},
+					"url":   {TypeSpec: schema.TypeSpec{Type: "string"}},
+				},
+				Required: []string{"name", "ratio", "url"},
+			},
+			InputProperties: map[string]schema.PropertySpec{
+				"name":  {TypeSpec: schema.TypeSpec{Type: "string"}},
+				"rules": rules,
+				"ratio": {TypeSpec: schema.TypeSpec{Type: "number"}, Default: 0.5},
+			},
+			RequiredInputs: []string{"name"},
+		},
+	}, spec.Resources)
+	assert.Equal(t, map[string]schema.ComplexTypeSpec{
+		"test:net:rule": {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Type: "object",
+				Properties: map[string]schema.PropertySpec{
+					"port":     {TypeSpec: schema.TypeSpec{Type: "integer"}},
+					"protocol": {TypeSpec: schema.TypeSpec{Type: "string", Ref: "#/types/test:net:protocol"}},
+				},
+				Required: []string{"port"},
+			},
+		},
+		"test:net:protocol": {
+			ObjectTypeSpec: schema.ObjectTypeSpec{Type: "string"},
+			Enum: []schema.EnumValueSpec{
+				{Name: "TCP", Value: "tcp"},
+				{Name: "UDP", Description: "Datagrams.", Value: "udp"},
+			},
+		},
+	}, spec.Types)
+
+	// The derived schema must be importable.
+	_, err = schema.ImportSpec(spec)
+	assert.NoError(t, err)
+}
+
+func TestSchemaDuplicateTypes(t *testing.T) {
+	p := NewProvider("test", semver.MustParse("0.1.0"))
+	assert.NoError(t, p.RegisterResource("test:net:Endpoint", &endpoint{}))
+
+	// Both URL types would be described by the schema type test:net:URL.
+	_, err := p.Schema()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "both map to the schema type test:net:URL")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package providertest serves providers built with the provider package in-process so that they can be exercised by
+// the engine's deploytest harness without building and installing a plugin binary.
+package providertest
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// NewProviderLoader returns a deploytest provider loader that serves the given provider in-process.
+func NewProviderLoader(prov *provider.Provider) *deploytest.ProviderLoader {
+	return deploytest.NewProviderLoaderWithHost(tokens.Package(prov.Name()), prov.Version(),
+		func(host plugin.Host) (plugin.Provider, error) {
+			return Serve(host, prov)
+		})
+}
+
+// Serve starts a gRPC server for the given provider on a local port and returns a plugin.Provider connected to it. The
+// server is stopped when the returned provider is closed.
+func Serve(host plugin.Host, prov *provider.Provider) (plugin.Provider, error) {
+	ctx, err := plugin.NewContext(nil, nil, host, nil, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ServeContext(ctx, prov)
+}
+
+// ServeContext is like Serve, but the returned plugin.Provider makes its requests through the given plugin context.
+func ServeContext(ctx *plugin.Context, prov *provider.Provider) (plugin.Provider, error) {
+	cancel := make(chan bool)
+	port, done, err := rpcutil.Serve(0, cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, prov)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(rpcutil.OpenTracingClientInterceptor()))
+	if err != nil {
+		close(cancel)
+		return nil, errors.Wrap(err, "could not connect to provider")
+	}
+
+	return &server{
+		Provider: plugin.NewProviderWithClient(ctx, tokens.Package(prov.Name()), pulumirpc.NewResourceProviderClient(conn)),
+		conn:     conn,
+		cancel:   cancel,
+		done:     done,
+	}, nil
+}
+
+// server is a plugin.Provider that stops its in-process gRPC server when it is closed.
+type server struct {
+	plugin.Provider
+
+	conn   *grpc.ClientConn
+	cancel chan bool
+	done   chan error
+}
+
+func (s *server) Close() error {
+	if err := s.Provider.Close(); err != nil {
+		return err
+	}
+	if err := s.conn.Close(); err != nil {
+		return err
+	}
+	close(s.cancel)
+	return <-s.done
+}
@@ -0,0 +1,225 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providertest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
+)
+
+var deleted []resource.ID
+
+type storageClass string
+
+func (storageClass) EnumValues() []provider.EnumValue {
+	return []provider.EnumValue{{Value: storageClass("standard")}, {Value: storageClass("archive")}}
+}
+
+type bucket struct {
+	Name     string            `pulumi:"name" provider:"replace"`
+	Size     int               `pulumi:"size,optional" default:"10"`
+	Password string            `pulumi:"password,optional" provider:"secret"`
+	Tags     map[string]string `pulumi:"tags,optional"`
+	Region   string            `pulumi:"region,optional"`
+	Class    storageClass      `pulumi:"class,optional"`
+	ARN      string            `pulumi:"arn" provider:"output"`
+	Version  int               `pulumi:"version" provider:"output"`
+}
+
+func (b *bucket) Create(ctx context.Context) (resource.ID, error) {
+	region := provider.GetProvider(ctx).Config()["region"]
+	if region.IsString() {
+		b.Region = region.StringValue()
+	}
+	b.ARN, b.Version = "arn:"+b.Name, 1
+	return resource.ID(b.Name), nil
+}
+
+func (b *bucket) Update(ctx context.Context, id resource.ID, olds provider.Resource) error {
+	b.Version = olds.(*bucket).Version + 1
+	return nil
+}
+
+func (b *bucket) Read(ctx context.Context, id resource.ID) error {
+	b.Size = 42
+	return nil
+}
+
+func (b *bucket) Delete(ctx context.Context, id resource.ID) error {
+	deleted = append(deleted, id)
+	return nil
+}
+
+func newTestProvider(t *testing.T) (plugin.Provider, func()) {
+	prov := provider.NewProvider("test", semver.MustParse("1.0.0"))
+	err := prov.RegisterResource("test:index:Bucket", &bucket{})
+	assert.NoError(t, err)
+
+	host := deploytest.NewPluginHost(nil, nil, nil, NewProviderLoader(prov))
+	p, err := host.Provider("test", nil)
+	assert.NoError(t, err)
+	err = p.Configure(resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")})
+	assert.NoError(t, err)
+
+	return p, func() {
+		assert.NoError(t, host.Close())
+	}
+}
+
+func TestProviderLifecycle(t *testing.T) {
+	p, done := newTestProvider(t)
+	defer done()
+
+	info, err := p.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", info.Version.String())
+
+	urn := resource.NewURN("stack", "proj", "", "test:index:Bucket", "b")
+
+	// Missing required properties and unknown properties are reported as check failures.
+	_, failures, err := p.Check(urn, nil, resource.PropertyMap{"color": resource.NewStringProperty("red")}, false)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []plugin.CheckFailure{
+		{Property: "color", Reason: "unknown property 'color' for resource type test:index:Bucket"},
+		{Property: "name", Reason: "missing required property 'name'"},
+	}, failures)
+
+	// Enum properties must be set to one of the enum's values.
+	_, failures, err = p.Check(urn, nil, resource.PropertyMap{
+		"name":  resource.NewStringProperty("bucket"),
+		"class": resource.NewStringProperty("glacier"),
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []plugin.CheckFailure{{
+		Property: "class",
+		Reason:   `invalid value "glacier" for property 'class'; allowed values are "standard", "archive"`,
+	}}, failures)
+
+	// Defaults are applied by Check.
+	inputs, failures, err := p.Check(urn, nil, resource.PropertyMap{
+		"name":     resource.NewStringProperty("bucket"),
+		"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		"class":    resource.NewStringProperty("archive"),
+	}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Equal(t, resource.NewNumberProperty(10), inputs["size"])
+
+	// The inputs that the provider fills in itself are recorded in the state.
+	providerInputs := resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("region")})
+	id, state, _, err := p.Create(urn, inputs, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.ID("bucket"), id)
+	assert.Equal(t, resource.PropertyMap{
+		"name":             resource.NewStringProperty("bucket"),
+		"size":             resource.NewNumberProperty(10),
+		"password":         resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		"region":           resource.NewStringProperty("us-west-2"),
+		"class":            resource.NewStringProperty("archive"),
+		"arn":              resource.NewStringProperty("arn:bucket"),
+		"version":          resource.NewNumberProperty(1),
+		"__providerInputs": providerInputs,
+	}, state)
+
+	// Changing an updatable property results in an update. Inputs that the provider filled in are not changes.
+	news := inputs.Copy()
+	news["size"] = resource.NewNumberProperty(20)
+	delete(news, "password")
+	diff, err := p.Diff(urn, id, state, news, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffSome, diff.Changes)
+	assert.False(t, diff.Replace())
+	assert.ElementsMatch(t, []resource.PropertyKey{"password", "size"}, diff.ChangedKeys)
+
+	// Ignored changes are not reported.
+	diff, err = p.Diff(urn, id, state, news, false, []string{"password", "size"})
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffNone, diff.Changes)
+
+	// Setting an input that the provider filled in is a change.
+	withRegion := news.Copy()
+	withRegion["region"] = resource.NewStringProperty("us-east-1")
+	diff, err = p.Diff(urn, id, state, withRegion, false, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []resource.PropertyKey{"password", "region", "size"}, diff.ChangedKeys)
+
+	// Updates keep the inputs that the provider filled in, so there is nothing left to change afterwards.
+	updated, _, err := p.Update(urn, id, state, news, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.PropertyMap{
+		"name":             resource.NewStringProperty("bucket"),
+		"size":             resource.NewNumberProperty(20),
+		"region":           resource.NewStringProperty("us-west-2"),
+		"class":            resource.NewStringProperty("archive"),
+		"arn":              resource.NewStringProperty("arn:bucket"),
+		"version":          resource.NewNumberProperty(2),
+		"__providerInputs": providerInputs,
+	}, updated)
+	diff, err = p.Diff(urn, id, updated, news, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffNone, diff.Changes)
+
+	// Changing a replace property results in a replacement.
+	news = news.Copy()
+	news["name"] = resource.NewStringProperty("other")
+	diff, err = p.Diff(urn, id, updated, news, false, nil)
+	assert.NoError(t, err)
+	assert.True(t, diff.Replace())
+	assert.Equal(t, []resource.PropertyKey{"name"}, diff.ReplaceKeys)
+	assert.Equal(t, plugin.DiffUpdateReplace, diff.DetailedDiff["name"].Kind)
+
+	read, _, err := p.Read(urn, id, nil, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewNumberProperty(42), read.Outputs["size"])
+	assert.Equal(t, providerInputs, read.Outputs["__providerInputs"])
+	assert.Equal(t, resource.PropertyMap{
+		"name":  resource.NewStringProperty("bucket"),
+		"size":  resource.NewNumberProperty(42),
+		"class": resource.NewStringProperty("archive"),
+	}, read.Inputs)
+
+	deleted = nil
+	_, err = p.Delete(urn, id, updated, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []resource.ID{"bucket"}, deleted)
+}
+
+func TestProviderGetSchema(t *testing.T) {
+	p, done := newTestProvider(t)
+	defer done()
+
+	bytes, err := p.GetSchema(0)
+	assert.NoError(t, err)
+
+	var spec schema.PackageSpec
+	assert.NoError(t, json.Unmarshal(bytes, &spec))
+	assert.Equal(t, "test", spec.Name)
+	assert.Contains(t, spec.Resources, "test:index:Bucket")
+	_, err = schema.ImportSpec(spec)
+	assert.NoError(t, err)
+
+	_, err = p.GetSchema(1)
+	assert.Error(t, err)
+}
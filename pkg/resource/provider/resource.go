@@ -0,0 +1,385 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/mapper"
+)
+
+// Resource is implemented by the Go structs that model a provider's resource types.  Each exported field tagged with
+// `pulumi:"name"` is a property of the resource.  Fields are required inputs unless the tag carries `optional`; the
+// additional `provider` tag may list `output` (the field is computed by the provider rather than supplied by the
+// program), `secret` (the field's value is always treated as a secret) and `replace` (changing the field forces a
+// replacement).  An input field may supply a default value for Check with a `default:"value"` tag.  Values whose
+// types implement Enum may only be set to one of the enum's values, including those nested within objects, arrays and
+// maps.
+//
+// Create is called on a value whose inputs have been populated; it must fill in any outputs and return the ID of the
+// newly created resource.  Create may also fill in optional inputs that the program did not set (e.g. from the
+// provider's configuration); those values are kept by later updates until the program sets them.  Delete is called on
+// a value populated from the resource's last known state.
+type Resource interface {
+	Create(ctx context.Context) (resource.ID, error)
+	Delete(ctx context.Context, id resource.ID) error
+}
+
+// Updater may be implemented by a Resource that can be updated in place.  Update is called on a value holding the new
+// inputs and the prior outputs; olds holds the prior state.  Resources that do not implement Updater are replaced
+// whenever any of their inputs change.
+type Updater interface {
+	Update(ctx context.Context, id resource.ID, olds Resource) error
+}
+
+// Reader may be implemented by a Resource that can refresh its state from the live resource.  Read is called on a
+// value populated from the resource's last known state, if any, and must update it to reflect the resource's current
+// state.
+type Reader interface {
+	Read(ctx context.Context, id resource.ID) error
+}
+
+// resourceField describes a single property of a resource type.
+type resourceField struct {
+	name     resource.PropertyKey    // the property's name.
+	field    string                  // the name of the Go struct field.
+	typ      reflect.Type            // the type of the Go struct field.
+	optional bool                    // true if the property may be omitted.
+	output   bool                    // true if the property is computed by the provider.
+	secret   bool                    // true if the property is always a secret.
+	replace  bool                    // true if changing the property requires a replacement.
+	def      *resource.PropertyValue // the property's default value, if any.
+}
+
+// resourceType describes a resource type registered with a provider.
+type resourceType struct {
+	token  tokens.Type     // the resource's type token.
+	typ    reflect.Type    // the Go struct type that models the resource.
+	fields []resourceField // the resource's properties, in declaration order.
+}
+
+// newResourceType reflects over the Go struct type of the given prototype to describe a resource type.
+func newResourceType(token tokens.Type, prototype Resource) (*resourceType, error) {
+	typ := reflect.TypeOf(prototype)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("resource type %s must be implemented by a pointer to a struct; got %v", token, typ)
+	}
+	typ = typ.Elem()
+
+	rt := &resourceType{token: token, typ: typ}
+	for i := 0; i < typ.NumField(); i++ {
+		info := typ.Field(i)
+		tag, has := info.Tag.Lookup("pulumi")
+		if !has || info.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		if parts[0] == "" || parts[0] == "-" {
+			continue
+		}
+		fld := resourceField{name: resource.PropertyKey(parts[0]), field: info.Name, typ: info.Type}
+		for _, part := range parts[1:] {
+			switch part {
+			case "optional", "omitempty":
+				fld.optional = true
+			default:
+				return nil, errors.Errorf("%s.%s: unrecognized pulumi tag option '%s'", token, info.Name, part)
+			}
+		}
+
+		if opts := info.Tag.Get("provider"); opts != "" {
+			for _, opt := range strings.Split(opts, ",") {
+				switch opt {
+				case "output":
+					fld.output = true
+				case "secret":
+					fld.secret = true
+				case "replace":
+					fld.replace = true
+				default:
+					return nil, errors.Errorf("%s.%s: unrecognized provider tag option '%s'", token, info.Name, opt)
+				}
+			}
+		}
+
+		if def, has := info.Tag.Lookup("default"); has {
+			if fld.output {
+				return nil, errors.Errorf("%s.%s: output properties may not have defaults", token, info.Name)
+			}
+			v, err := parseDefault(info.Type, def)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s.%s: invalid default", token, info.Name)
+			}
+			fld.def = &v
+		}
+
+		if err := validateEnums(info.Type, map[reflect.Type]bool{}); err != nil {
+			return nil, errors.Wrapf(err, "%s.%s", token, info.Name)
+		}
+
+		rt.fields = append(rt.fields, fld)
+	}
+
+	return rt, nil
+}
+
+// parseDefault parses the text of a `default` tag as a value of the given type.  Defaults are only supported for
+// primitive types.
+func parseDefault(typ reflect.Type, text string) (resource.PropertyValue, error) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return resource.NewStringProperty(text), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewBoolProperty(b), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return resource.PropertyValue{}, err
+		}
+		return resource.NewNumberProperty(f), nil
+	default:
+		return resource.PropertyValue{}, errors.Errorf("defaults are not supported for values of type %v", typ)
+	}
+}
+
+// field returns the property with the given name, if any.
+func (rt *resourceType) field(name resource.PropertyKey) (resourceField, bool) {
+	for _, fld := range rt.fields {
+		if fld.name == name {
+			return fld, true
+		}
+	}
+	return resourceField{}, false
+}
+
+// inputs returns the subset of the given properties that are inputs to this resource type.
+func (rt *resourceType) inputs(props resource.PropertyMap) resource.PropertyMap {
+	inputs := resource.PropertyMap{}
+	for _, fld := range rt.fields {
+		if v, has := props[fld.name]; has && !fld.output {
+			inputs[fld.name] = v
+		}
+	}
+	return inputs
+}
+
+// check applies defaults to the given inputs and validates them against this resource type.
+func (rt *resourceType) check(news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure) {
+	inputs := news.Copy()
+
+	var failures []plugin.CheckFailure
+	for _, k := range news.StableKeys() {
+		if fld, has := rt.field(k); !has || fld.output {
+			failures = append(failures, plugin.CheckFailure{
+				Property: k,
+				Reason:   fmt.Sprintf("unknown property '%s' for resource type %s", k, rt.token),
+			})
+		}
+	}
+	for _, fld := range rt.fields {
+		if fld.output {
+			continue
+		}
+		if v, has := inputs[fld.name]; !has || v.IsNull() {
+			if fld.def != nil {
+				inputs[fld.name] = *fld.def
+			} else if !fld.optional {
+				failures = append(failures, plugin.CheckFailure{
+					Property: fld.name,
+					Reason:   fmt.Sprintf("missing required property '%s'", fld.name),
+				})
+			}
+		} else {
+			failures = append(failures, checkEnums(string(fld.name), fld.typ, v)...)
+		}
+	}
+
+	// If the inputs are fully known, ensure that they can be decoded into the resource's Go type.
+	if len(failures) == 0 && !inputs.ContainsUnknowns() {
+		if _, err := rt.decode(inputs); err != nil {
+			if merr, ok := err.(mapper.MappingError); ok {
+				for _, f := range merr.Failures() {
+					prop := resource.PropertyKey("")
+					if ferr, ok := f.(mapper.FieldError); ok {
+						prop = resource.PropertyKey(ferr.Field())
+					}
+					failures = append(failures, plugin.CheckFailure{Property: prop, Reason: f.Error()})
+				}
+			} else {
+				failures = append(failures, plugin.CheckFailure{Reason: err.Error()})
+			}
+		}
+	}
+
+	return inputs, failures
+}
+
+// new allocates a fresh, zero-valued instance of this resource type.
+func (rt *resourceType) new() Resource {
+	return reflect.New(rt.typ).Interface().(Resource)
+}
+
+// decode allocates a new instance of this resource type and populates it from the given properties.
+func (rt *resourceType) decode(props resource.PropertyMap) (Resource, error) {
+	res := rt.new()
+	if err := rt.decodeInto(res, props); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// decodeInto populates an existing instance of this resource type from the given properties.  Properties that are
+// not present are left untouched.
+func (rt *resourceType) decodeInto(res Resource, props resource.PropertyMap) error {
+	obj := unwrapSecrets(resource.NewObjectProperty(props)).ObjectValue().Mappable()
+	if err := mapper.MapI(obj, res); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resetInputs zeroes the input fields of the given instance of this resource type.
+func (rt *resourceType) resetInputs(res Resource) {
+	v := reflect.ValueOf(res).Elem()
+	for _, fld := range rt.fields {
+		if !fld.output {
+			f := v.FieldByName(fld.field)
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
+
+// providerInputsKey is the internal state property that lists the inputs whose values the provider filled in itself
+// (e.g. from its configuration) because the program did not set them.  Internal properties are not displayed.
+const providerInputsKey = resource.PropertyKey("__providerInputs")
+
+// providerInputs returns the names of the inputs that the provider filled in itself, as recorded in the given state.
+func providerInputs(state resource.PropertyMap) []resource.PropertyKey {
+	v, has := state[providerInputsKey]
+	if !has || !v.IsArray() {
+		return nil
+	}
+	var keys []resource.PropertyKey
+	for _, e := range v.ArrayValue() {
+		if e.IsString() {
+			keys = append(keys, resource.PropertyKey(e.StringValue()))
+		}
+	}
+	return keys
+}
+
+// state returns the state of the given instance of this resource type after it has been created or updated from the
+// given checked inputs.  Any inputs that the provider filled in itself are recorded in the state so that they are
+// preserved by later updates rather than treated as having been removed by the program.
+func (rt *resourceType) state(res Resource, inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	state, err := rt.encode(res, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var filled []resource.PropertyValue
+	for _, fld := range rt.fields {
+		if fld.output {
+			continue
+		}
+		if _, has := state[fld.name]; !has {
+			continue
+		}
+		if v, has := inputs[fld.name]; !has || v.IsNull() {
+			filled = append(filled, resource.NewStringProperty(string(fld.name)))
+		}
+	}
+	if len(filled) != 0 {
+		state[providerInputsKey] = resource.NewArrayProperty(filled)
+	}
+	return state, nil
+}
+
+// encode returns the state of the given instance of this resource type.  Any properties that are secrets in the given
+// inputs, as well as any fields that are always secret, are marked as such in the result.
+func (rt *resourceType) encode(res Resource, inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	m := mapper.New(&mapper.Opts{IgnoreMissing: true})
+
+	v := reflect.ValueOf(res).Elem()
+	state := resource.PropertyMap{}
+	for _, fld := range rt.fields {
+		f := v.FieldByName(fld.field)
+		if fld.optional && isZero(f) {
+			continue
+		}
+
+		e, err := m.EncodeValue(f.Interface())
+		if err != nil {
+			return nil, err
+		}
+		value := resource.NewPropertyValue(e)
+		if fld.secret || inputs[fld.name].ContainsSecrets() {
+			value = resource.MakeSecret(value)
+		}
+		state[fld.name] = value
+	}
+	return state, nil
+}
+
+// isZero returns true if the given value is the zero value for its type.
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	default:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+}
+
+// unwrapSecrets returns a copy of the given value with all secrets replaced by their underlying values.
+func unwrapSecrets(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsSecret():
+		return unwrapSecrets(v.SecretValue().Element)
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			arr[i] = unwrapSecrets(e)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		obj := resource.PropertyMap{}
+		for k, e := range v.ObjectValue() {
+			obj[k] = unwrapSecrets(e)
+		}
+		return resource.NewObjectProperty(obj)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// schemaTypes accumulates the object and enum types referenced by a package's resources.
+type schemaTypes struct {
+	pkg     tokens.Package
+	types   map[string]schema.ComplexTypeSpec
+	goTypes map[string]reflect.Type // the Go type that each of the types was derived from.
+}
+
+// typeToken returns the token of the schema type that corresponds to the given named Go type, and whether the type has
+// already been added to the package's types.  Tokens are derived from the names of Go types, so it is an error for two
+// different Go types with the same name to be used in the same module.
+func (st *schemaTypes) typeToken(mod tokens.ModuleName, typ reflect.Type) (string, bool, error) {
+	token := string(tokens.NewTypeToken(tokens.NewModuleToken(st.pkg, mod), tokens.TypeName(typ.Name())))
+	existing, has := st.goTypes[token]
+	if !has {
+		st.goTypes[token] = typ
+		return token, false, nil
+	}
+	if existing != typ {
+		return "", false, errors.Errorf("types %v and %v both map to the schema type %s", existing, typ, token)
+	}
+	return token, true, nil
+}
+
+// typeSpec returns the schema type that corresponds to the given Go type.  Struct types and types that implement Enum
+// are added to the package's types under the given module.
+func (st *schemaTypes) typeSpec(mod tokens.ModuleName, typ reflect.Type) (schema.TypeSpec, error) {
+	if typ.Kind() != reflect.Ptr {
+		declared, values, err := enumValues(typ)
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		if values != nil {
+			return st.enumSpec(mod, typ, declared, values)
+		}
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return st.typeSpec(mod, typ.Elem())
+	case reflect.Bool:
+		return schema.TypeSpec{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema.TypeSpec{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return schema.TypeSpec{Type: "number"}, nil
+	case reflect.String:
+		return schema.TypeSpec{Type: "string"}, nil
+	case reflect.Interface:
+		return schema.TypeSpec{Ref: "pulumi.json#/Any"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := st.typeSpec(mod, typ.Elem())
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		return schema.TypeSpec{Type: "array", Items: &items}, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return schema.TypeSpec{}, errors.Errorf("map keys must be strings; got %v", typ.Key())
+		}
+		elem, err := st.typeSpec(mod, typ.Elem())
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		return schema.TypeSpec{Type: "object", AdditionalProperties: &elem}, nil
+	case reflect.Struct:
+		token, has, err := st.typeToken(mod, typ)
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		if !has {
+			// The token is reserved before recurring in case the type refers to itself.
+			spec, err := st.objectSpec(mod, typ)
+			if err != nil {
+				return schema.TypeSpec{}, err
+			}
+			st.types[token] = schema.ComplexTypeSpec{ObjectTypeSpec: spec}
+		}
+		return schema.TypeSpec{Ref: "#/types/" + token}, nil
+	default:
+		return schema.TypeSpec{}, errors.Errorf("values of type %v cannot be described by a schema", typ)
+	}
+}
+
+// enumSpec adds the schema enum type that corresponds to the given Go type to the package's types and returns a
+// reference to it.
+func (st *schemaTypes) enumSpec(mod tokens.ModuleName, typ reflect.Type, declared []EnumValue,
+	values []resource.PropertyValue) (schema.TypeSpec, error) {
+
+	var elementType string
+	switch typ.Kind() {
+	case reflect.Bool:
+		elementType = "boolean"
+	case reflect.Float32, reflect.Float64:
+		elementType = "number"
+	case reflect.String:
+		elementType = "string"
+	default:
+		elementType = "integer"
+	}
+
+	token, has, err := st.typeToken(mod, typ)
+	if err != nil {
+		return schema.TypeSpec{}, err
+	}
+	if !has {
+		spec := schema.ComplexTypeSpec{ObjectTypeSpec: schema.ObjectTypeSpec{Type: elementType}}
+		for i, v := range values {
+			spec.Enum = append(spec.Enum, schema.EnumValueSpec{
+				Name:        declared[i].Name,
+				Description: declared[i].Description,
+				Value:       v.V,
+			})
+		}
+		st.types[token] = spec
+	}
+	return schema.TypeSpec{Type: elementType, Ref: "#/types/" + token}, nil
+}
+
+// objectSpec returns the schema object type that corresponds to the given Go struct type.
+func (st *schemaTypes) objectSpec(mod tokens.ModuleName, typ reflect.Type) (schema.ObjectTypeSpec, error) {
+	spec := schema.ObjectTypeSpec{Type: "object", Properties: map[string]schema.PropertySpec{}}
+	for i := 0; i < typ.NumField(); i++ {
+		info := typ.Field(i)
+		tag := info.Tag.Get("pulumi")
+		if tag == "" {
+			tag = info.Tag.Get("json")
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "" || parts[0] == "-" || info.PkgPath != "" {
+			continue
+		}
+
+		t, err := st.typeSpec(mod, info.Type)
+		if err != nil {
+			return schema.ObjectTypeSpec{}, errors.Wrapf(err, "%v.%v", typ.Name(), info.Name)
+		}
+		spec.Properties[parts[0]] = schema.PropertySpec{TypeSpec: t}
+
+		optional := false
+		for _, part := range parts[1:] {
+			optional = optional || part == "optional" || part == "omitempty"
+		}
+		if !optional {
+			spec.Required = append(spec.Required, parts[0])
+		}
+	}
+	return spec, nil
+}
+
+// resourceSpec returns the schema for the given resource type.
+func (st *schemaTypes) resourceSpec(rt *resourceType) (schema.ResourceSpec, error) {
+	spec := schema.ResourceSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:       "object",
+			Properties: map[string]schema.PropertySpec{},
+		},
+		InputProperties: map[string]schema.PropertySpec{},
+	}
+
+	mod := rt.token.Module().Name()
+	for _, fld := range rt.fields {
+		t, err := st.typeSpec(mod, fld.typ)
+		if err != nil {
+			return schema.ResourceSpec{}, errors.Wrapf(err, "%s.%s", rt.token, fld.field)
+		}
+		prop := schema.PropertySpec{TypeSpec: t}
+
+		name := string(fld.name)
+		spec.Properties[name] = prop
+		if !fld.optional || fld.def != nil {
+			spec.Required = append(spec.Required, name)
+		}
+
+		if !fld.output {
+			if fld.def != nil {
+				prop.Default = fld.def.V
+			}
+			spec.InputProperties[name] = prop
+			if !fld.optional && fld.def == nil {
+				spec.RequiredInputs = append(spec.RequiredInputs, name)
+			}
+		}
+	}
+	sort.Strings(spec.Required)
+	sort.Strings(spec.RequiredInputs)
+
+	return spec, nil
+}
@@ -1678,7 +1678,7 @@ func (pt *programTester) prepareGoProject(projinfo *engine.Projinfo) error {
 		return err
 	}
 
-	// skip building if the 'go run' invocation path is requested.
+	// skip building if the language host should build the program itself.
 	if !pt.opts.RunBuild {
 		return nil
 	}
@@ -31,6 +31,7 @@ import (
 // TracingEndpoint is the Zipkin-compatible tracing endpoint where tracing data will be sent.
 var TracingEndpoint string
 var TracingToFile bool
+var TracingToOTLP bool
 var TracingRootSpan opentracing.Span
 
 var traceCloser io.Closer
@@ -90,6 +91,12 @@ func InitTracing(name, rootSpanName, tracingEndpoint string) {
 
 		collector := appdash.NewLocalCollector(store.store)
 		tracer = appdash_opentracing.NewTracer(collector)
+	case isOTLPEndpoint(endpointURL):
+		// If the endpoint scheme is otlp+http or otlp+https, send spans to an OpenTelemetry collector.
+		TracingToOTLP = true
+
+		t, recorder := newOTLPTracer(name, endpointURL)
+		tracer, traceCloser = t, recorder
 	case endpointURL.Scheme == "tcp":
 		// If the endpoint scheme is tcp, use an Appdash endpoint.
 		collector := appdash.NewRemoteCollector(tracingEndpoint)
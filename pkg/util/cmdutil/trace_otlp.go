@@ -0,0 +1,309 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	basictracer "github.com/opentracing/basictracer-go"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+const (
+	// otlpSchemePrefix prefixes the scheme of tracing endpoints that receive spans using OTLP over HTTP, e.g.
+	// `otlp+http://localhost:4318`.
+	otlpSchemePrefix = "otlp+"
+	// otlpTracesPath is the path at which OTLP receivers accept spans by default.
+	otlpTracesPath = "/v1/traces"
+	// otlpBatchSize is the number of finished spans that are buffered before they are sent.
+	otlpBatchSize = 128
+)
+
+// OTLPTracingEndpointFromEnv returns the OTLP tracing endpoint configured by the standard OpenTelemetry environment
+// variables, if any, in the form accepted by InitTracing.
+func OTLPTracingEndpointFromEnv() string {
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		return otlpSchemePrefix + endpoint
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		return otlpSchemePrefix + strings.TrimSuffix(endpoint, "/") + otlpTracesPath
+	}
+	return ""
+}
+
+// isOTLPEndpoint returns true if the given tracing endpoint receives spans using OTLP.
+func isOTLPEndpoint(endpointURL *url.URL) bool {
+	return strings.HasPrefix(endpointURL.Scheme, otlpSchemePrefix)
+}
+
+// otlpRecorder is a span recorder that sends finished spans to an OTLP/HTTP receiver using the JSON encoding.
+type otlpRecorder struct {
+	endpoint string       // the URL to which spans are posted.
+	service  string       // the name of the service that emits the spans.
+	client   *http.Client // the HTTP client used to send spans.
+
+	m       sync.Mutex
+	spans   []basictracer.RawSpan // the finished spans that have not yet been sent.
+	senders sync.WaitGroup        // the batches that are being sent.
+}
+
+func newOTLPRecorder(service string, endpointURL *url.URL) *otlpRecorder {
+	u := *endpointURL
+	u.Scheme = strings.TrimPrefix(u.Scheme, otlpSchemePrefix)
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpTracesPath
+	}
+
+	return &otlpRecorder{
+		endpoint: u.String(),
+		service:  service,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// RecordSpan buffers a finished span, sending the buffered spans once a full batch has been collected.
+func (r *otlpRecorder) RecordSpan(span basictracer.RawSpan) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.spans = append(r.spans, span)
+	if len(r.spans) >= otlpBatchSize {
+		batch := r.spans
+		r.spans = nil
+
+		r.senders.Add(1)
+		go func() {
+			defer r.senders.Done()
+			r.send(batch)
+		}()
+	}
+}
+
+// Close sends any buffered spans and waits for all batches to be sent.
+func (r *otlpRecorder) Close() error {
+	r.m.Lock()
+	batch := r.spans
+	r.spans = nil
+	r.m.Unlock()
+
+	r.send(batch)
+	r.senders.Wait()
+	return nil
+}
+
+// send posts a batch of spans to the receiver. Failures are logged rather than reported, as tracing is best-effort.
+func (r *otlpRecorder) send(batch []basictracer.RawSpan) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := r.post(batch); err != nil {
+		logging.V(3).Infof("failed to send %d spans to %s: %v", len(batch), r.endpoint, err)
+	}
+}
+
+func (r *otlpRecorder) post(batch []basictracer.RawSpan) error {
+	body, err := json.Marshal(newOTLPTraces(r.service, batch))
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Post(r.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// The following types are the subset of the OTLP/JSON encoding of an ExportTraceServiceRequest that is necessary to
+// describe OpenTracing spans. See https://github.com/open-telemetry/opentelemetry-proto for the full definitions.
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Name         string          `json:"name"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code int `json:"code"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// OTLP span kinds and status codes.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+	otlpStatusCodeError  = 2
+)
+
+func newOTLPTraces(service string, batch []basictracer.RawSpan) otlpTraces {
+	spans := make([]otlpSpan, len(batch))
+	for i, s := range batch {
+		spans[i] = newOTLPSpan(s)
+	}
+
+	return otlpTraces{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpResource{Attributes: []otlpAttribute{newOTLPAttribute("service.name", service)}},
+			ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "pulumi"}, Spans: spans}},
+		}},
+	}
+}
+
+func newOTLPSpan(s basictracer.RawSpan) otlpSpan {
+	span := otlpSpan{
+		// OpenTracing trace IDs are 64 bits wide; OTLP trace IDs are 128 bits wide.
+		TraceID:           fmt.Sprintf("%032x", s.Context.TraceID),
+		SpanID:            fmt.Sprintf("%016x", s.Context.SpanID),
+		Name:              s.Operation,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.Start.Add(s.Duration).UnixNano(), 10),
+	}
+	if s.ParentSpanID != 0 {
+		span.ParentSpanID = fmt.Sprintf("%016x", s.ParentSpanID)
+	}
+
+	for k, v := range s.Tags {
+		switch k {
+		case string(ext.SpanKind):
+			switch fmt.Sprint(v) {
+			case string(ext.SpanKindRPCClientEnum):
+				span.Kind = otlpSpanKindClient
+			case string(ext.SpanKindRPCServerEnum):
+				span.Kind = otlpSpanKindServer
+			}
+		case string(ext.Error):
+			if b, ok := v.(bool); ok && b {
+				span.Status = &otlpStatus{Code: otlpStatusCodeError}
+			}
+		default:
+			span.Attributes = append(span.Attributes, newOTLPAttribute(k, v))
+		}
+	}
+	sort.Slice(span.Attributes, func(i, j int) bool { return span.Attributes[i].Key < span.Attributes[j].Key })
+
+	for _, l := range s.Logs {
+		event := otlpEvent{TimeUnixNano: strconv.FormatInt(l.Timestamp.UnixNano(), 10), Name: "log"}
+		for _, f := range l.Fields {
+			if f.Key() == "event" {
+				event.Name = fmt.Sprint(f.Value())
+			} else {
+				event.Attributes = append(event.Attributes, newOTLPAttribute(f.Key(), f.Value()))
+			}
+		}
+		span.Events = append(span.Events, event)
+	}
+
+	return span
+}
+
+func newOTLPAttribute(key string, value interface{}) otlpAttribute {
+	var v otlpAnyValue
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Bool:
+		b := rv.Bool()
+		v.BoolValue = &b
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := strconv.FormatInt(rv.Int(), 10)
+		v.IntValue = &i
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i := strconv.FormatUint(rv.Uint(), 10)
+		v.IntValue = &i
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		v.DoubleValue = &f
+	default:
+		s := fmt.Sprint(value)
+		v.StringValue = &s
+	}
+	return otlpAttribute{Key: key, Value: v}
+}
+
+// newOTLPTracer creates a tracer that samples every span and sends finished spans to the given OTLP receiver.
+func newOTLPTracer(service string, endpointURL *url.URL) (opentracing.Tracer, *otlpRecorder) {
+	recorder := newOTLPRecorder(service, endpointURL)
+
+	opts := basictracer.DefaultOptions()
+	opts.ShouldSample = func(traceID uint64) bool { return true }
+	opts.Recorder = recorder
+	return basictracer.NewWithOptions(opts), recorder
+}
@@ -0,0 +1,122 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOTLPTracer(t *testing.T) {
+	var paths []string
+	var requests []otlpTraces
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var traces otlpTraces
+		if assert.NoError(t, json.NewDecoder(r.Body).Decode(&traces)) {
+			paths, requests = append(paths, r.URL.Path), append(requests, traces)
+		}
+	}))
+	defer server.Close()
+
+	endpointURL, err := url.Parse(strings.Replace(server.URL, "http:", "otlp+http:", 1))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, isOTLPEndpoint(endpointURL))
+
+	tracer, recorder := newOTLPTracer("pulumi-cli", endpointURL)
+	root := tracer.StartSpan("pulumi")
+	child := tracer.StartSpan("pulumi-step", opentracing.ChildOf(root.Context()), opentracing.Tags{
+		"pulumi.urn":     "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b",
+		"pulumi.preview": false,
+		"attempts":       2,
+	})
+	ext.SpanKindRPCClient.Set(child)
+	ext.Error.Set(child, true)
+	child.LogKV("event", "error", "message", "failed")
+	child.Finish()
+	root.Finish()
+
+	// Spans are not sent until the recorder is closed or a batch is full.
+	assert.Empty(t, requests)
+	assert.NoError(t, recorder.Close())
+	if !assert.Len(t, requests, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, []string{otlpTracesPath}, paths)
+
+	resourceSpans := requests[0].ResourceSpans
+	assert.Equal(t, "service.name", resourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "pulumi-cli", *resourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := resourceSpans[0].ScopeSpans[0].Spans
+	if !assert.Len(t, spans, 2) {
+		t.FailNow()
+	}
+	step, pulumi := spans[0], spans[1]
+	assert.Equal(t, "pulumi", pulumi.Name)
+	assert.Equal(t, "", pulumi.ParentSpanID)
+	assert.Equal(t, otlpSpanKindInternal, pulumi.Kind)
+	assert.Len(t, pulumi.TraceID, 32)
+	assert.Len(t, pulumi.SpanID, 16)
+
+	assert.Equal(t, "pulumi-step", step.Name)
+	assert.Equal(t, pulumi.TraceID, step.TraceID)
+	assert.Equal(t, pulumi.SpanID, step.ParentSpanID)
+	assert.Equal(t, otlpSpanKindClient, step.Kind)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusCodeError}, step.Status)
+	assert.NotEqual(t, step.StartTimeUnixNano, "0")
+
+	if assert.Len(t, step.Attributes, 3) {
+		assert.Equal(t, "attempts", step.Attributes[0].Key)
+		assert.Equal(t, "2", *step.Attributes[0].Value.IntValue)
+		assert.Equal(t, "pulumi.preview", step.Attributes[1].Key)
+		assert.False(t, *step.Attributes[1].Value.BoolValue)
+		assert.Equal(t, "pulumi.urn", step.Attributes[2].Key)
+		assert.Equal(t, "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b", *step.Attributes[2].Value.StringValue)
+	}
+	if assert.Len(t, step.Events, 1) {
+		assert.Equal(t, "error", step.Events[0].Name)
+		assert.Equal(t, "message", step.Events[0].Attributes[0].Key)
+		assert.Equal(t, "failed", *step.Events[0].Attributes[0].Value.StringValue)
+	}
+}
+
+func TestOTLPTracingEndpointFromEnv(t *testing.T) {
+	for _, key := range []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"} {
+		if old, ok := os.LookupEnv(key); ok {
+			defer os.Setenv(key, old)
+		}
+		defer os.Unsetenv(key)
+		assert.NoError(t, os.Unsetenv(key))
+	}
+
+	assert.Equal(t, "", OTLPTracingEndpointFromEnv())
+
+	assert.NoError(t, os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318/"))
+	assert.Equal(t, "otlp+http://localhost:4318/v1/traces", OTLPTracingEndpointFromEnv())
+
+	assert.NoError(t, os.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "https://collector/traces"))
+	assert.Equal(t, "otlp+https://collector/traces", OTLPTracingEndpointFromEnv())
+}
@@ -94,5 +94,14 @@ func OpenTracingClientInterceptor() grpc.UnaryClientInterceptor {
 		// Do not trace calls to the empty method
 		otgrpc.IncludingSpans(func(_ opentracing.SpanContext, method string, _, _ interface{}) bool {
 			return method != ""
-		}))
+		}),
+		// Tag calls that operate on a resource with the resource's URN
+		otgrpc.SpanDecorator(decorateResourceSpan))
+}
+
+// decorateResourceSpan tags the span of a gRPC call whose request names a resource with that resource's URN.
+func decorateResourceSpan(span opentracing.Span, _ string, req, _ interface{}, _ error) {
+	if r, ok := req.(interface{ GetUrn() string }); ok && r.GetUrn() != "" {
+		span.SetTag("pulumi.urn", r.GetUrn())
+	}
 }
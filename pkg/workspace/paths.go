@@ -71,6 +71,9 @@ const (
 
 	// PolicyPackFile is the base name of a Pulumi policy pack file.
 	PolicyPackFile = "PulumiPolicy"
+
+	// PluginLockFile is the base name of a project's plugin lockfile.
+	PluginLockFile = "PulumiPlugins.lock"
 )
 
 // DetectProjectPath locates the closest project from the current working directory, or an error if not found.
@@ -0,0 +1,197 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// PluginLock records the exact plugins a project was last resolved against, so that every machine running the
+// project loads the same plugin versions.
+type PluginLock struct {
+	// Plugins is the list of locked plugins, sorted by kind and name.
+	Plugins []LockedPlugin `json:"plugins" yaml:"plugins"`
+}
+
+// LockedPlugin is a single plugin entry in a plugin lockfile.
+type LockedPlugin struct {
+	// Name is the simple name of the plugin.
+	Name string `json:"name" yaml:"name"`
+	// Kind is the kind of the plugin (language, resource, etc).
+	Kind PluginKind `json:"kind" yaml:"kind"`
+	// Version is the exact version of the plugin.
+	Version string `json:"version" yaml:"version"`
+	// Checksum is the hex-encoded SHA-256 checksum of the plugin's primary executable.
+	Checksum string `json:"checksum" yaml:"checksum"`
+	// ServerURL is an optional server to use when downloading this plugin.
+	ServerURL string `json:"server,omitempty" yaml:"server,omitempty"`
+}
+
+// Info returns the plugin info for this locked plugin.
+func (lp LockedPlugin) Info() (PluginInfo, error) {
+	version, err := semver.ParseTolerant(lp.Version)
+	if err != nil {
+		return PluginInfo{}, errors.Wrapf(err, "invalid version for locked %s plugin %s", lp.Kind, lp.Name)
+	}
+	return PluginInfo{
+		Name:      lp.Name,
+		Kind:      lp.Kind,
+		Version:   &version,
+		ServerURL: lp.ServerURL,
+	}, nil
+}
+
+// NewLockedPlugin creates a lockfile entry for the given plugin, whose primary executable is found at path.
+func NewLockedPlugin(info PluginInfo, path string) (LockedPlugin, error) {
+	contract.Require(info.Version != nil, "info.Version")
+
+	checksum, err := PluginChecksum(path)
+	if err != nil {
+		return LockedPlugin{}, err
+	}
+	return LockedPlugin{
+		Name:      info.Name,
+		Kind:      info.Kind,
+		Version:   info.Version.String(),
+		Checksum:  checksum,
+		ServerURL: info.ServerURL,
+	}, nil
+}
+
+// Find returns the locked entry for the plugin with the given kind and name, if there is one.
+func (lock *PluginLock) Find(kind PluginKind, name string) (LockedPlugin, bool) {
+	for _, lp := range lock.Plugins {
+		if lp.Kind == kind && lp.Name == name {
+			return lp, true
+		}
+	}
+	return LockedPlugin{}, false
+}
+
+// Save writes the lockfile to the given path.
+func (lock *PluginLock) Save(path string) error {
+	contract.Require(path != "", "path")
+
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		if lock.Plugins[i].Kind != lock.Plugins[j].Kind {
+			return lock.Plugins[i].Kind < lock.Plugins[j].Kind
+		}
+		return lock.Plugins[i].Name < lock.Plugins[j].Name
+	})
+
+	m, err := marshallerForPath(path)
+	if err != nil {
+		return err
+	}
+
+	b, err := m.Marshal(lock)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// LoadPluginLock reads a plugin lockfile. If the file doesn't exist, a nil lock is returned.
+func LoadPluginLock(path string) (*PluginLock, error) {
+	contract.Require(path != "", "path")
+
+	m, err := marshallerForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var lock PluginLock
+	if err = m.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "loading plugin lockfile %s", path)
+	}
+	return &lock, nil
+}
+
+// PluginLockPath returns the path of the plugin lockfile for the project file at projPath. The lockfile lives next to
+// the project file and uses the same format.
+func PluginLockPath(projPath string) string {
+	return filepath.Join(filepath.Dir(projPath), PluginLockFile+filepath.Ext(projPath))
+}
+
+// DetectPluginLockFrom locates the closest project from the given path and loads its plugin lockfile. If there is no
+// project or the project has no lockfile, a nil lock is returned.
+func DetectPluginLockFrom(path string) (*PluginLock, string, error) {
+	projPath, err := DetectProjectPathFrom(path)
+	if err != nil || projPath == "" {
+		return nil, "", err
+	}
+	lockPath := PluginLockPath(projPath)
+	lock, err := LoadPluginLock(lockPath)
+	return lock, lockPath, err
+}
+
+// PluginChecksum returns the hex-encoded SHA-256 checksum of the plugin executable at the given path.
+func PluginChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer contract.IgnoreClose(f)
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", errors.Wrapf(err, "computing checksum of %s", path)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetPluginInfo finds the installed plugin that would be loaded for the given kind, name, and optional version, using
+// the same rules as GetPluginPath. It returns the plugin's info, with an exact version, and the path to its primary
+// executable. If no such plugin is installed, a MissingError is returned.
+func GetPluginInfo(kind PluginKind, name string, version *semver.Version) (PluginInfo, string, error) {
+	dir, path, err := GetPluginPath(kind, name, version)
+	if err != nil {
+		return PluginInfo{}, "", err
+	}
+	if path == "" {
+		return PluginInfo{}, "", NewMissingError(PluginInfo{Kind: kind, Name: name, Version: version})
+	}
+
+	info := PluginInfo{Kind: kind, Name: name, Version: version, Path: path}
+	if dir != "" {
+		file, err := os.Stat(dir)
+		if err != nil {
+			return PluginInfo{}, "", err
+		}
+		if _, _, v, ok := tryPlugin(file); ok {
+			info.Version = &v
+		}
+	}
+	return info, path, nil
+}
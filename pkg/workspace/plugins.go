@@ -15,10 +15,14 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
@@ -26,6 +30,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/util/archive"
@@ -169,13 +174,47 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 	return nil
 }
 
+// DefaultPluginServerURL is the default location from which plugins are downloaded.
+const DefaultPluginServerURL = "https://api.pulumi.com/releases/plugins"
+
+const (
+	// PluginMirrorEnvVar names an environment variable holding the URL of a plugin mirror to use instead of the default
+	// server for plugins that do not specify their own server. Along with http(s) URLs, file:// URLs that name a local
+	// directory of plugin tarballs are supported, which is useful for air-gapped machines.
+	PluginMirrorEnvVar = "PULUMI_PLUGIN_MIRROR"
+	// PluginChecksumsEnvVar names an environment variable holding the path of a checksum manifest. When set, every
+	// downloaded plugin tarball must be listed in the manifest and match its SHA-256 checksum.
+	PluginChecksumsEnvVar = "PULUMI_PLUGIN_CHECKSUMS"
+	// PluginDownloadHeadersEnvVar names an environment variable holding extra HTTP headers to send when downloading
+	// plugins, such as credentials for an internal artifact server. Headers are separated by semicolons and take the
+	// form `Name: Value`.
+	PluginDownloadHeadersEnvVar = "PULUMI_PLUGIN_DOWNLOAD_HEADERS"
+)
+
+// TarballName returns the name of the tarball that holds this plugin for the given OS and architecture.
+func (info PluginInfo) TarballName(os, arch string) string {
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch)
+}
+
+// DownloadURL returns the server that this plugin will be downloaded from: its own server, if it has one, or else the
+// plugin mirror, if one is configured, or else the default location, which is hosted by Pulumi.
+func (info PluginInfo) DownloadURL() string {
+	if info.ServerURL != "" {
+		return info.ServerURL
+	}
+	if mirror := os.Getenv(PluginMirrorEnvVar); mirror != "" {
+		return mirror
+	}
+	return DefaultPluginServerURL
+}
+
 // Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 	// Figure out the OS/ARCH pair for the download URL.
-	var os string
+	var goos string
 	switch runtime.GOOS {
 	case "darwin", "linux", "windows":
-		os = runtime.GOOS
+		goos = runtime.GOOS
 	default:
 		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
 	}
@@ -187,14 +226,85 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
 	}
 
-	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
-	// is hosted by Pulumi.
-	serverURL := info.ServerURL
-	if serverURL == "" {
-		serverURL = "https://api.pulumi.com/releases/plugins"
+	serverURL := strings.TrimSuffix(info.DownloadURL(), "/")
+	tarballName := info.TarballName(goos, arch)
+
+	var tarball io.ReadCloser
+	var size int64
+	var err error
+	if strings.HasPrefix(serverURL, "file://") {
+		tarball, size, err = openPluginFile(serverURL, tarballName)
+	} else {
+		tarball, size, err = downloadPluginHTTP(serverURL, tarballName)
+	}
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if tarball, err = VerifyPluginTarball(tarballName, tarball); err != nil {
+		return nil, -1, err
+	}
+	return tarball, size, nil
+}
+
+// VerifyPluginTarball makes sure that the contents of the given plugin tarball match the checksum manifest, if one has
+// been configured. If the tarball cannot be verified, it is closed and an error is returned.
+func VerifyPluginTarball(tarballName string, tarball io.ReadCloser) (io.ReadCloser, error) {
+	manifest := os.Getenv(PluginChecksumsEnvVar)
+	if manifest == "" {
+		return tarball, nil
 	}
 
-	endpoint := fmt.Sprintf("%s/pulumi-%s-%s-v%s-%s-%s.tar.gz", serverURL, info.Kind, info.Name, info.Version, os, arch)
+	checksums, err := LoadPluginChecksums(manifest)
+	if err != nil {
+		contract.IgnoreClose(tarball)
+		return nil, err
+	}
+	verified, err := checksums.Verify(tarballName, tarball)
+	if err != nil {
+		contract.IgnoreClose(tarball)
+		return nil, err
+	}
+	return verified, nil
+}
+
+// openPluginFile opens a plugin tarball from a file:// mirror.
+func openPluginFile(serverURL, tarballName string) (io.ReadCloser, int64, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, -1, errors.Wrapf(err, "parsing plugin mirror URL %s", serverURL)
+	}
+	path := filepath.Join(fileURLPath(u), tarballName)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, -1, errors.Wrapf(err, "opening plugin from %s", path)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
+
+// fileURLPath returns the local path named by a file:// URL. Both file:///C:/plugins and file://C:/plugins name the
+// Windows path C:\plugins.
+func fileURLPath(u *url.URL) string {
+	path := u.Path
+	if u.Host != "" && u.Host != "localhost" {
+		path = u.Host + path
+	}
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' &&
+		('a' <= path[1] && path[1] <= 'z' || 'A' <= path[1] && path[1] <= 'Z') {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// downloadPluginHTTP downloads a plugin tarball from an http(s) server.
+func downloadPluginHTTP(serverURL, tarballName string) (io.ReadCloser, int64, error) {
+	endpoint := fmt.Sprintf("%s/%s", serverURL, tarballName)
 	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, -1, err
@@ -203,18 +313,132 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
 	req.Header.Set("User-Agent", userAgent)
 
+	headers, err := parsePluginDownloadHeaders(os.Getenv(PluginDownloadHeadersEnvVar))
+	if err != nil {
+		return nil, -1, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
 	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
 	if err != nil {
 		return nil, -1, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
 		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
 	}
 
 	return resp.Body, resp.ContentLength, nil
 }
 
+// parsePluginDownloadHeaders parses a semicolon-separated list of `Name: Value` headers.
+func parsePluginDownloadHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, header := range strings.Split(s, ";") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, errors.Errorf("malformed header in %s; expected 'Name: Value'", PluginDownloadHeadersEnvVar)
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers, nil
+}
+
+// PluginChecksums maps the names of plugin tarballs to their hex-encoded SHA-256 checksums.
+type PluginChecksums map[string]string
+
+// LoadPluginChecksums reads a checksum manifest in the format written by `sha256sum`: one `<checksum>  <file>` pair
+// per line.
+func LoadPluginChecksums(path string) (PluginChecksums, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plugin checksum manifest")
+	}
+
+	checksums := make(PluginChecksums)
+	for i, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 0:
+			continue
+		case 2:
+			checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		default:
+			return nil, errors.Errorf("%s:%d: malformed checksum line", path, i+1)
+		}
+	}
+	return checksums, nil
+}
+
+// Verify wraps the given tarball in a reader that fails if the tarball's contents do not match the checksum listed in
+// the manifest for the given file name. The check happens when the end of the tarball is reached.
+func (checksums PluginChecksums) Verify(tarballName string, tarball io.ReadCloser) (io.ReadCloser, error) {
+	expected, has := checksums[tarballName]
+	if !has {
+		return tarball, errors.Errorf("plugin %s is not listed in the checksum manifest", tarballName)
+	}
+	return &checksumReader{
+		name:       tarballName,
+		expected:   expected,
+		hash:       sha256.New(),
+		readCloser: tarball,
+	}, nil
+}
+
+// checksumReader computes the checksum of everything read through it, and fails at EOF if it doesn't match.
+type checksumReader struct {
+	name       string
+	expected   string
+	hash       hash.Hash
+	readCloser io.ReadCloser
+}
+
+func (cr *checksumReader) Read(dest []byte) (int, error) {
+	n, err := cr.readCloser.Read(dest)
+	cr.hash.Write(dest[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(cr.hash.Sum(nil)); actual != cr.expected {
+			return n, errors.Errorf("checksum mismatch for plugin %s: expected %s, got %s", cr.name, cr.expected, actual)
+		}
+	}
+	return n, err
+}
+
+func (cr *checksumReader) Close() error {
+	return cr.readCloser.Close()
+}
+
+// pluginTarballRegexp matches plugin tarball names: pulumi-KIND-NAME-vVERSION[-OS-ARCH].tar.gz.
+var pluginTarballRegexp = regexp.MustCompile(
+	"^pulumi-(?P<Kind>[a-z]+)-" + // KIND
+		"(?P<Name>[a-zA-Z0-9-]*[a-zA-Z0-9])-" + // NAME
+		"v(?P<Version>.+?)" + // VERSION
+		"(?:-(?:darwin|linux|windows)-[a-z0-9]+)?\\.tar\\.gz$") // OS-ARCH
+
+// ParsePluginTarballName extracts the kind, name and version of a plugin from the name of its tarball, if the name
+// follows the standard `pulumi-KIND-NAME-vVERSION-OS-ARCH.tar.gz` format.
+func ParsePluginTarballName(name string) (PluginInfo, bool) {
+	match := pluginTarballRegexp.FindStringSubmatch(filepath.Base(name))
+	if match == nil || !IsPluginKind(match[1]) {
+		return PluginInfo{}, false
+	}
+	version, err := semver.ParseTolerant(match[3])
+	if err != nil {
+		return PluginInfo{}, false
+	}
+	return PluginInfo{
+		Kind:    PluginKind(match[1]),
+		Name:    match[2],
+		Version: &version,
+	}, true
+}
+
 // Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
 func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	// Fetch the directory into which we will expand this tarball, and create it.
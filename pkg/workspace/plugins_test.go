@@ -15,6 +15,16 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/blang/semver"
@@ -234,3 +244,180 @@ func TestPluginSelection_EmptyVersionWithAlternatives(t *testing.T) {
 	assert.Equal(t, "myplugin", result.Name)
 	assert.Equal(t, "0.2.0", result.Version.String())
 }
+
+func setEnv(t *testing.T, key, value string) func() {
+	old, had := os.LookupEnv(key)
+	assert.NoError(t, os.Setenv(key, value))
+	return func() {
+		if had {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestPluginDownloadFromMirror(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugin downloads are only supported on amd64")
+	}
+
+	version := semver.MustParse("1.2.3")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version}
+	tarballName := info.TarballName(runtime.GOOS, runtime.GOARCH)
+	contents := []byte("not really a tarball")
+	sum := sha256.Sum256(contents)
+
+	dir, err := ioutil.TempDir("", "pulumi-plugin-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, tarballName), contents, 0600))
+
+	manifest := filepath.Join(dir, "checksums.txt")
+	assert.NoError(t, ioutil.WriteFile(manifest,
+		[]byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), tarballName)), 0600))
+
+	t.Run("FileMirror", func(t *testing.T) {
+		defer setEnv(t, PluginMirrorEnvVar, "file://"+filepath.ToSlash(dir))()
+		defer setEnv(t, PluginChecksumsEnvVar, manifest)()
+
+		tarball, size, err := info.Download()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(contents)), size)
+		b, err := ioutil.ReadAll(tarball)
+		assert.NoError(t, err)
+		assert.NoError(t, tarball.Close())
+		assert.Equal(t, contents, b)
+	})
+
+	t.Run("HTTPMirrorWithHeaders", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Team") != "infra" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path != "/plugins/"+tarballName {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, err := w.Write(contents)
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		defer setEnv(t, PluginMirrorEnvVar, server.URL+"/plugins/")()
+		defer setEnv(t, PluginDownloadHeadersEnvVar, "Authorization: Bearer secret; X-Team: infra")()
+
+		tarball, _, err := info.Download()
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(tarball)
+		assert.NoError(t, err)
+		assert.NoError(t, tarball.Close())
+		assert.Equal(t, contents, b)
+
+		// A plugin with its own server does not use the mirror.
+		withServer := info
+		withServer.ServerURL = "file://" + filepath.ToSlash(dir)
+		assert.Equal(t, withServer.ServerURL, withServer.DownloadURL())
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad-checksums.txt")
+		assert.NoError(t, ioutil.WriteFile(bad, []byte(fmt.Sprintf("%064d  %s\n", 0, tarballName)), 0600))
+
+		defer setEnv(t, PluginMirrorEnvVar, "file://"+filepath.ToSlash(dir))()
+		defer setEnv(t, PluginChecksumsEnvVar, bad)()
+
+		tarball, _, err := info.Download()
+		assert.NoError(t, err)
+		_, err = ioutil.ReadAll(tarball)
+		assert.Error(t, err)
+		assert.NoError(t, tarball.Close())
+
+		// Plugins missing from the manifest are rejected up front.
+		other := PluginInfo{Kind: ResourcePlugin, Name: "other", Version: &version}
+		assert.NoError(t, ioutil.WriteFile(
+			filepath.Join(dir, other.TarballName(runtime.GOOS, runtime.GOARCH)), contents, 0600))
+		_, _, err = other.Download()
+		assert.Error(t, err)
+	})
+}
+
+func TestFileURLPath(t *testing.T) {
+	tests := map[string]string{
+		"file:///var/plugins":          "/var/plugins",
+		"file://localhost/var/plugins": "/var/plugins",
+		"file://plugins/dir":           "plugins/dir",
+		"file:///C:/plugins":           "C:/plugins",
+		"file://C:/plugins":            "C:/plugins",
+	}
+	for rawURL, expected := range tests {
+		u, err := url.Parse(rawURL)
+		if assert.NoError(t, err) {
+			assert.Equal(t, filepath.FromSlash(expected), fileURLPath(u), rawURL)
+		}
+	}
+}
+
+func TestParsePluginTarballName(t *testing.T) {
+	tests := map[string]string{
+		"pulumi-resource-aws-v1.2.3-linux-amd64.tar.gz":              "resource aws 1.2.3",
+		"pulumi-resource-azure-ad-v0.1.0-beta.1-darwin-amd64.tar.gz": "resource azure-ad 0.1.0-beta.1",
+		"/tmp/pulumi-analyzer-policy-v2.0.0.tar.gz":                  "analyzer policy 2.0.0",
+	}
+	for name, expected := range tests {
+		info, ok := ParsePluginTarballName(name)
+		if assert.True(t, ok, name) {
+			assert.Equal(t, expected, fmt.Sprintf("%s %s %s", info.Kind, info.Name, info.Version))
+		}
+	}
+
+	for _, name := range []string{"aws.tar.gz", "pulumi-widget-aws-v1.0.0.tar.gz", "pulumi-resource-aws-v1.zip"} {
+		_, ok := ParsePluginTarballName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestPluginLockRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-plugin-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	exe := filepath.Join(dir, "pulumi-resource-aws")
+	assert.NoError(t, ioutil.WriteFile(exe, []byte("binary"), 0700))
+	sum := sha256.Sum256([]byte("binary"))
+
+	version := semver.MustParse("1.2.3")
+	aws, err := NewLockedPlugin(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &version}, exe)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), aws.Checksum)
+
+	lock := &PluginLock{Plugins: []LockedPlugin{
+		{Kind: ResourcePlugin, Name: "kubernetes", Version: "0.22.0", Checksum: "abc"},
+		aws,
+		{Kind: AnalyzerPlugin, Name: "policy", Version: "0.1.0", Checksum: "def"},
+	}}
+
+	projPath := filepath.Join(dir, "Pulumi.yaml")
+	lockPath := PluginLockPath(projPath)
+	assert.Equal(t, filepath.Join(dir, "PulumiPlugins.lock.yaml"), lockPath)
+	assert.NoError(t, lock.Save(lockPath))
+
+	loaded, err := LoadPluginLock(lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"policy", "aws", "kubernetes"},
+		[]string{loaded.Plugins[0].Name, loaded.Plugins[1].Name, loaded.Plugins[2].Name})
+
+	found, ok := loaded.Find(ResourcePlugin, "aws")
+	assert.True(t, ok)
+	info, err := found.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, "aws-1.2.3", info.String())
+
+	_, ok = loaded.Find(AnalyzerPlugin, "aws")
+	assert.False(t, ok)
+
+	missing, err := LoadPluginLock(filepath.Join(dir, "nope.yaml"))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
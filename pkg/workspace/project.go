@@ -60,6 +60,15 @@ type ProjectBackend struct {
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
+// ProjectWebhook is a webhook that self-managed backends notify of the updates to a project's stacks.
+type ProjectWebhook struct {
+	// URL is the required URL that notifications are POSTed to.
+	URL string `json:"url" yaml:"url"`
+	// Events optionally restricts the notifications sent to the webhook to the given kinds: "update_started",
+	// "update_succeeded", "update_failed" and "policy_violation". All kinds are sent by default.
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
 // Project is a Pulumi project manifest.
 //
 // We explicitly add yaml tags (instead of using the default behavior from https://github.com/ghodss/yaml which works
@@ -92,6 +101,9 @@ type Project struct {
 
 	// Backend is an optional backend configuration
 	Backend *ProjectBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// Webhooks are optional webhooks that self-managed backends notify of the updates to all of this project's stacks.
+	Webhooks []ProjectWebhook `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 }
 
 func (proj *Project) Validate() error {
@@ -101,6 +113,11 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	for _, hook := range proj.Webhooks {
+		if hook.URL == "" {
+			return errors.New("project webhook is missing a 'url' attribute")
+		}
+	}
 
 	return nil
 }
@@ -165,8 +182,33 @@ type ProjectStack struct {
 	// EncryptionSalt is this stack's base64 encoded encryption salt.  Only used for
 	// passphrase-based secrets providers.
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
+	// Base is an optional path, relative to this file, of another stack configuration file (for example,
+	// `Pulumi.common.yaml` or `Pulumi.dev.yaml`) whose config this stack inherits. Bases may themselves have bases.
+	Base string `json:"base,omitempty" yaml:"base,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// Webhooks are optional webhooks that self-managed backends notify of the updates to this stack, in addition to
+	// the project's webhooks.
+	Webhooks []ProjectWebhook `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+
+	// bases holds the configuration files this stack inherits from, loaded along with the stack. These are never
+	// saved as part of this stack.
+	bases []ProjectStackLayer
+}
+
+// ProjectStackLayer is a single file in a stack's chain of configuration inheritance.
+type ProjectStackLayer struct {
+	// Path is the path of the file this layer was loaded from.
+	Path string
+	// Stack is the contents of the file.
+	Stack *ProjectStack
+}
+
+// Bases returns the configuration files this stack inherits from, ordered from the furthest ancestor to the stack's
+// immediate base. Config in later layers takes precedence over config in earlier ones, and the stack's own config
+// takes precedence over all of them.
+func (ps *ProjectStack) Bases() []ProjectStackLayer {
+	return ps.bases
 }
 
 // Save writes a project definition to a file.
@@ -327,8 +369,12 @@ func LoadPolicyPack(path string) (*PolicyPackProject, error) {
 	return &proj, err
 }
 
-// LoadProjectStack reads a stack definition from a file.
+// LoadProjectStack reads a stack definition from a file, along with any base configuration files it inherits from.
 func LoadProjectStack(path string) (*ProjectStack, error) {
+	return loadProjectStack(path, map[string]bool{})
+}
+
+func loadProjectStack(path string, visited map[string]bool) (*ProjectStack, error) {
 	contract.Require(path != "", "path")
 
 	m, err := marshallerForPath(path)
@@ -355,6 +401,35 @@ func LoadProjectStack(path string) (*ProjectStack, error) {
 		ps.Config = make(config.Map)
 	}
 
+	if ps.Base != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		visited[abs] = true
+
+		basePath := ps.Base
+		if !filepath.IsAbs(basePath) {
+			basePath = filepath.Join(filepath.Dir(path), basePath)
+		}
+		absBase, err := filepath.Abs(basePath)
+		if err != nil {
+			return nil, err
+		}
+		if visited[absBase] {
+			return nil, errors.Errorf("configuration file '%s' inherits from itself through base '%s'", path, ps.Base)
+		}
+		if _, err = os.Stat(basePath); err != nil {
+			return nil, errors.Wrapf(err, "loading base configuration for '%s'", path)
+		}
+
+		base, err := loadProjectStack(basePath, visited)
+		if err != nil {
+			return nil, err
+		}
+		ps.bases = append(append(ps.bases, base.bases...), ProjectStackLayer{Path: basePath, Stack: base})
+	}
+
 	return &ps, err
 }
 
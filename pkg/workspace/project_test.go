@@ -2,10 +2,15 @@ package workspace
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
 )
 
 func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
@@ -36,3 +41,46 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestLoadProjectStackBases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-stack-bases")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+
+	write("Pulumi.common.yaml", "config:\n  proj:a: common\n  proj:b: common\n")
+	write("Pulumi.dev.yaml", "base: Pulumi.common.yaml\nconfig:\n  proj:b: dev\n")
+	prod := write("Pulumi.prod.yaml", "base: Pulumi.dev.yaml\nconfig:\n  proj:c: prod\n")
+
+	ps, err := LoadProjectStack(prod)
+	assert.NoError(t, err)
+	assert.Equal(t, config.Map{config.MustMakeKey("proj", "c"): config.NewValue("prod")}, ps.Config)
+
+	bases := ps.Bases()
+	if assert.Len(t, bases, 2) {
+		assert.Equal(t, filepath.Join(dir, "Pulumi.common.yaml"), bases[0].Path)
+		assert.Equal(t, config.NewValue("common"), bases[0].Stack.Config[config.MustMakeKey("proj", "a")])
+		assert.Equal(t, filepath.Join(dir, "Pulumi.dev.yaml"), bases[1].Path)
+		assert.Equal(t, config.NewValue("dev"), bases[1].Stack.Config[config.MustMakeKey("proj", "b")])
+	}
+
+	// Saving the stack must not write inherited config back into the stack's own file.
+	assert.NoError(t, ps.Save(prod))
+	b, err := ioutil.ReadFile(prod)
+	assert.NoError(t, err)
+	assert.Equal(t, "base: Pulumi.dev.yaml\nconfig:\n  proj:c: prod\n", string(b))
+
+	// Cycles and missing bases are errors.
+	cycle := write("Pulumi.cycle.yaml", "base: Pulumi.cycle.yaml\n")
+	_, err = LoadProjectStack(cycle)
+	assert.Error(t, err)
+
+	missing := write("Pulumi.missing.yaml", "base: Pulumi.nope.yaml\n")
+	_, err = LoadProjectStack(missing)
+	assert.Error(t, err)
+}
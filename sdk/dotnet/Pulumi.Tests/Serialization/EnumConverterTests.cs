@@ -0,0 +1,60 @@
+﻿// Copyright 2016-2020, Pulumi Corporation
+
+using System;
+using System.Threading.Tasks;
+using Google.Protobuf.WellKnownTypes;
+using Pulumi.Serialization;
+using Xunit;
+
+namespace Pulumi.Tests.Serialization
+{
+    public class EnumConverterTests : ConverterTests
+    {
+        [EnumType]
+        public readonly struct Protocol : IEquatable<Protocol>
+        {
+            private readonly string _value;
+
+            private Protocol(string value)
+            {
+                _value = value ?? throw new ArgumentNullException(nameof(value));
+            }
+
+            public static Protocol Tcp { get; } = new Protocol("tcp");
+            public static Protocol Udp { get; } = new Protocol("udp");
+
+            public static explicit operator string(Protocol value) => value._value;
+
+            public override bool Equals(object? obj) => obj is Protocol other && Equals(other);
+            public bool Equals(Protocol other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+            public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+            public override string ToString() => _value;
+        }
+
+        [Fact]
+        public void ConvertsFromUnderlyingValue()
+        {
+            var data = Converter.ConvertValue<Protocol>("", new Value { StringValue = "udp" });
+            Assert.Equal(Protocol.Udp, data.Value);
+            Assert.True(data.IsKnown);
+        }
+
+        [Fact]
+        public void WrongUnderlyingTypeThrows()
+        {
+            Assert.Throws<InvalidOperationException>(() =>
+            {
+                var data = Converter.ConvertValue<Protocol>("", new Value { NumberValue = 1 });
+            });
+        }
+
+        [Fact]
+        public async Task SerializesUnderlyingValue()
+        {
+            var value = await SerializeToValueAsync(Protocol.Tcp);
+            Assert.Equal("tcp", value.StringValue);
+        }
+    }
+}
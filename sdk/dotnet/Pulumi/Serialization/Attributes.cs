@@ -76,4 +76,16 @@ namespace Pulumi
     public sealed class OutputConstructorAttribute : Attribute
     {
     }
+
+    /// <summary>
+    /// Attribute used by a Pulumi Cloud Provider Package to mark enum types.
+    /// 
+    /// An enum type must be a struct with a single constructor that takes the underlying value
+    /// of the enum (a <see cref="string"/>, <see cref="double"/>, <see cref="int"/> or
+    /// <see cref="bool"/>) and an explicit conversion operator back to that underlying type.
+    /// </summary>
+    [AttributeUsage(AttributeTargets.Struct)]
+    public sealed class EnumTypeAttribute : Attribute
+    {
+    }
 }
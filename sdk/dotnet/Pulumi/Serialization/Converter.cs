@@ -113,6 +113,21 @@ namespace Pulumi.Serialization
                     $"Unexpected generic target type {targetType.FullName} when deserializing {context}");
             }
 
+            if (targetType.IsValueType && targetType.GetCustomAttribute<EnumTypeAttribute>() != null)
+            {
+                var enumConstructor = GetEnumConstructor(targetType);
+                if (enumConstructor == null)
+                    return (null, new InvalidOperationException(
+                        $"Expected enum type {targetType.FullName} to have a constructor with a single parameter when deserializing {context}"));
+
+                var (underlyingValue, enumException) = TryConvertObject(
+                    context, val, enumConstructor.GetParameters()[0].ParameterType);
+                if (enumException != null)
+                    return (null, enumException);
+
+                return (enumConstructor.Invoke(new[] { underlyingValue }), null);
+            }
+
             if (targetType.GetCustomAttribute<Pulumi.OutputTypeAttribute>() == null
 #pragma warning disable 618
                 && targetType.GetCustomAttribute<Pulumi.Serialization.OutputTypeAttribute>() == null)
@@ -321,6 +336,19 @@ namespace Pulumi.Serialization
                 return;
             }
 
+            if (targetType.IsValueType && targetType.GetCustomAttribute<EnumTypeAttribute>() != null)
+            {
+                var enumConstructor = GetEnumConstructor(targetType);
+                if (enumConstructor == null)
+                {
+                    throw new InvalidOperationException(
+$@"{targetType.FullName} had [{nameof(EnumTypeAttribute)}], but did not contain a constructor with a single parameter.");
+                }
+
+                CheckTargetType($@"{targetType.FullName}", enumConstructor.GetParameters()[0].ParameterType, seenTypes);
+                return;
+            }
+
             if (targetType == typeof(ImmutableDictionary<string, object>))
             {
                 // This type is what is generated for things like azure/aws tags.  It's an untyped
@@ -394,6 +422,10 @@ $@"{targetType.FullName} had [{nameof(Pulumi.OutputTypeAttribute)}], but did not
             }
         }
 
+        private static ConstructorInfo? GetEnumConstructor(System.Type enumType)
+            => enumType.GetConstructors(BindingFlags.NonPublic | BindingFlags.Public | BindingFlags.Instance)
+                       .SingleOrDefault(c => c.GetParameters().Length == 1);
+
         private static ConstructorInfo GetPropertyConstructor(System.Type outputTypeArg)
             => outputTypeArg.GetConstructors(BindingFlags.NonPublic | BindingFlags.Public | BindingFlags.Instance).FirstOrDefault(
                 c => c.GetCustomAttributes<Pulumi.OutputConstructorAttribute>() != null
@@ -5,6 +5,7 @@ using System.Collections;
 using System.Collections.Generic;
 using System.Collections.Immutable;
 using System.Linq;
+using System.Reflection;
 using System.Text.Json;
 using System.Threading.Tasks;
 using Google.Protobuf.WellKnownTypes;
@@ -39,6 +40,7 @@ namespace Pulumi.Serialization
         /// <item><see cref="Resource"/>s</item>
         /// <item><see cref="ResourceArgs"/>s</item>
         /// <item><see cref="JsonElement"/></item>
+        /// <item>Enum types marked with <see cref="EnumTypeAttribute"/></item>
         /// </list>
         /// Additionally, other more complex objects can be serialized as long as they are built
         /// out of serializable objects.  These complex objects include:
@@ -196,6 +198,19 @@ $"Tasks are not allowed inside ResourceArgs. Please wrap your Task in an Output:
                 return await SerializeAsync($"{ctx}.urn", componentResource.Urn).ConfigureAwait(false);
             }
 
+            var propType = prop.GetType();
+            if (propType.IsValueType && propType.GetCustomAttribute<EnumTypeAttribute>() != null)
+            {
+                if (_excessiveDebugOutput)
+                {
+                    Log.Debug($"Serialize property[{ctx}]: Recursing into enum");
+                }
+
+                var underlyingValue = propType.GetMethod("op_Explicit", BindingFlags.Public | BindingFlags.Static)!
+                                              .Invoke(null, new[] { prop });
+                return await SerializeAsync(ctx, underlyingValue).ConfigureAwait(false);
+            }
+
             if (prop is IDictionary dictionary)
                 return await SerializeDictionaryAsync(ctx, dictionary).ConfigureAwait(false);
 
@@ -0,0 +1,270 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+const (
+	// buildCacheDir is the name of the directory under the Pulumi home directory that holds compiled Go programs.
+	buildCacheDir = "go-build-cache"
+	// buildCacheKeep is the number of most recently used builds of each program that the build cache retains.
+	buildCacheKeep = 3
+	// buildCacheMaxAge is the time after which builds that have not been used are removed from the build cache.
+	buildCacheMaxAge = 7 * 24 * time.Hour
+)
+
+// buildError is returned when the Go compiler rejects a program. Its message contains the compiler's output.
+type buildError struct {
+	output string
+}
+
+func (e *buildError) Error() string {
+	return "failed to build the Go program:\n" + e.output
+}
+
+// sourceExtensions are the extensions of the files in a Go program's directory tree that may affect its build.
+var sourceExtensions = map[string]bool{
+	".go": true, ".s": true, ".c": true, ".cc": true, ".cpp": true, ".h": true, ".hh": true, ".hpp": true,
+	".syso": true,
+}
+
+// buildEnvironment are the environment variables that affect the output of `go build`.
+var buildEnvironment = []string{"CGO_ENABLED", "GOARCH", "GOFLAGS", "GOOS", "GOPATH", "GOPROXY"}
+
+// hashProgram computes a hash of the Go program rooted at the given directory. The hash covers the program's source
+// files, its go.mod, go.sum and vendored modules, the Go toolchain and the environment variables that affect its
+// build. Dependencies are covered by go.sum; dependencies that are replaced with local directories are not, so
+// changes to them require the program's own sources to change before the program is rebuilt.
+func hashProgram(dir, gobin string) (string, error) {
+	h := sha256.New()
+
+	// Hash the identity of the toolchain.
+	info, err := os.Stat(gobin)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "go %s %d %d\n", gobin, info.Size(), info.ModTime().UnixNano())
+	for _, k := range buildEnvironment {
+		fmt.Fprintf(h, "%s=%s\n", k, os.Getenv(k))
+	}
+
+	// Hash the program's sources in a deterministic order.
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			// Skip the directories that the Go tool ignores.
+			if path != dir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name == "go.mod" || name == "go.sum" || name == "modules.txt" || sourceExtensions[filepath.Ext(name)] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "enumerating program sources")
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		if err := hashFile(h, dir, path); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile adds the path and contents of the given file to the hash.
+func hashFile(h hash.Hash, dir, path string) error {
+	rel, err := filepath.Rel(dir, path)
+	contract.AssertNoError(err)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	fmt.Fprintf(h, "%s\n", filepath.ToSlash(rel))
+	_, err = io.Copy(h, f)
+	return err
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// buildHashLength is the number of hex digits of a program's hash that are used to name its binary.
+const buildHashLength = 32
+
+var exeSuffix = func() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}()
+
+// buildDirHashLength is the number of hex digits of the hash of a program's directory that are used to name its
+// binaries.
+const buildDirHashLength = 8
+
+var buildHashPattern = regexp.MustCompile(fmt.Sprintf("^[0-9a-f]{%d}$", buildHashLength))
+
+var buildNamePattern = regexp.MustCompile(fmt.Sprintf("-[0-9a-f]{%d}-[0-9a-f]{%d}%s$",
+	buildDirHashLength, buildHashLength, regexp.QuoteMeta(exeSuffix)))
+
+// buildPrefix returns the prefix of the names of the cached binaries of the given project's program in the given
+// directory. Programs in different directories, e.g. in different checkouts of the same project, have different
+// prefixes, so that their builds are cached independently.
+func buildPrefix(project, dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	dirHash := sha256.Sum256([]byte(dir))
+	return unsafeFileChars.ReplaceAllString(project, "_") + "-" +
+		hex.EncodeToString(dirHash[:])[:buildDirHashLength] + "-"
+}
+
+// isBuild returns true if the given file name is the name of a cached binary.
+func isBuild(name string) bool {
+	return buildNamePattern.MatchString(name)
+}
+
+// isBuildOf returns true if the given file name is the name of a cached binary with the given prefix.
+func isBuildOf(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, exeSuffix) {
+		return false
+	}
+	return buildHashPattern.MatchString(strings.TrimSuffix(strings.TrimPrefix(name, prefix), exeSuffix))
+}
+
+// evictBuilds removes the cached binaries that have not been used for buildCacheMaxAge, along with all but the
+// buildCacheKeep most recently used binaries with the given prefix. The given binary, which is about to be used, is
+// never removed. A binary's modification time records when it was last used.
+func evictBuilds(cacheDir, prefix, binary string, now time.Time) {
+	entries, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		logging.V(5).Infof("not evicting builds: %v", err)
+		return
+	}
+
+	var builds []os.FileInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !isBuild(name) || filepath.Join(cacheDir, name) == binary {
+			continue
+		}
+		if now.Sub(entry.ModTime()) > buildCacheMaxAge {
+			logging.V(5).Infof("evicting unused build %s", name)
+			contract.IgnoreError(os.Remove(filepath.Join(cacheDir, name)))
+			continue
+		}
+		if isBuildOf(name, prefix) {
+			builds = append(builds, entry)
+		}
+	}
+
+	sort.Slice(builds, func(i, j int) bool { return builds[i].ModTime().After(builds[j].ModTime()) })
+	for i, build := range builds {
+		// The binary that is about to be used is one of the builds that are kept.
+		if i >= buildCacheKeep-1 {
+			logging.V(5).Infof("evicting least recently used build %s", build.Name())
+			contract.IgnoreError(os.Remove(filepath.Join(cacheDir, build.Name())))
+		}
+	}
+}
+
+// buildProgram compiles the Go program in the given directory into the build cache, unless a binary built from the
+// same sources is already present, and returns the path to the binary.
+func buildProgram(gobin, dir, project string) (string, error) {
+	cacheDir, err := workspace.GetPulumiPath(buildCacheDir)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(cacheDir, 0700); err != nil {
+		return "", errors.Wrap(err, "creating build cache directory")
+	}
+
+	programHash, err := hashProgram(dir, gobin)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := buildPrefix(project, dir)
+	binary := filepath.Join(cacheDir, prefix+programHash[:buildHashLength]+exeSuffix)
+
+	// Record when each binary is used so that the least recently used binaries are evicted first.
+	now := time.Now()
+	if err = os.Chtimes(binary, now, now); err == nil {
+		logging.V(5).Infof("reusing cached build of program %s: %s", project, binary)
+		return binary, nil
+	}
+
+	// Build into a temporary file and move it into place once the build succeeds so that concurrent runs never
+	// observe a partially-written binary.
+	tmpDir, err := ioutil.TempDir(cacheDir, prefix+"build-")
+	if err != nil {
+		return "", errors.Wrap(err, "creating build output directory")
+	}
+	defer func() { contract.IgnoreError(os.RemoveAll(tmpDir)) }()
+	tmp := filepath.Join(tmpDir, filepath.Base(binary))
+
+	logging.V(5).Infof("building program %s: %s", project, binary)
+	var output bytes.Buffer
+	cmd := exec.Command(gobin, "build", "-o", tmp, ".")
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout, cmd.Stderr = &output, &output
+	if err = cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", &buildError{output: strings.TrimSpace(output.String())}
+		}
+		return "", errors.Wrap(err, "running 'go build'")
+	}
+
+	if err = os.Rename(tmp, binary); err != nil {
+		return "", errors.Wrap(err, "moving binary into the build cache")
+	}
+
+	evictBuilds(cacheDir, prefix, binary, now)
+	return binary, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+const testModule = "module example.com/program\n\ngo 1.13\n"
+
+const testProgram = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+}
+`
+
+func writeProgram(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if !assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700)) {
+			t.FailNow()
+		}
+		if !assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600)) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestHashProgram(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	writeProgram(t, dir, map[string]string{"go.mod": testModule, "main.go": testProgram})
+	h1, err := hashProgram(dir, gobin)
+	assert.NoError(t, err)
+
+	// Files that do not affect the build do not affect the hash.
+	writeProgram(t, dir, map[string]string{
+		"Pulumi.yaml":         "name: program\nruntime: go\n",
+		"Pulumi.dev.yaml":     "config: {}\n",
+		"README.md":           "# program\n",
+		".git/HEAD":           "ref: refs/heads/master\n",
+		"testdata/fixture.go": "package fixture\n",
+		"_scratch/notes.go":   "package notes\n",
+	})
+	h2, err := hashProgram(dir, gobin)
+	assert.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	// Changes to sources and to go.sum do.
+	writeProgram(t, dir, map[string]string{"pkg/util.go": "package pkg\n"})
+	h3, err := hashProgram(dir, gobin)
+	assert.NoError(t, err)
+	assert.NotEqual(t, h2, h3)
+
+	writeProgram(t, dir, map[string]string{"go.sum": "example.com/dep v1.0.0 h1:abc=\n"})
+	h4, err := hashProgram(dir, gobin)
+	assert.NoError(t, err)
+	assert.NotEqual(t, h3, h4)
+}
+
+func TestBuildProgram(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipped in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	home, err := ioutil.TempDir("", "pulumi-language-go-home")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv(workspace.PulumiHomeEnvVar)
+	defer func() { os.Setenv(workspace.PulumiHomeEnvVar, oldHome) }()
+	os.Setenv(workspace.PulumiHomeEnvVar, home)
+
+	dir, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	writeProgram(t, dir, map[string]string{"go.mod": testModule, "main.go": testProgram})
+
+	// The first build populates the cache, and the second reuses it.
+	binary, err := buildProgram(gobin, dir, "my/project")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, isBuildOf(filepath.Base(binary), buildPrefix("my/project", dir)))
+	info, err := os.Stat(binary)
+	assert.NoError(t, err)
+
+	out, err := exec.Command(binary).Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", string(out))
+
+	cached, err := buildProgram(gobin, dir, "my/project")
+	assert.NoError(t, err)
+	assert.Equal(t, binary, cached)
+	cachedInfo, err := os.Stat(cached)
+	assert.NoError(t, err)
+	assert.False(t, cachedInfo.ModTime().Before(info.ModTime()))
+
+	// Changing the program produces a new binary. The previous binary is kept, as other runs may still use it.
+	writeProgram(t, dir, map[string]string{"main.go": testProgram + "\nvar _ = 42\n"})
+	rebuilt, err := buildProgram(gobin, dir, "my/project")
+	assert.NoError(t, err)
+	assert.NotEqual(t, binary, rebuilt)
+	_, err = os.Stat(binary)
+	assert.NoError(t, err)
+
+	// The same project in another directory is built separately.
+	other, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(other)
+	writeProgram(t, other, map[string]string{"go.mod": testModule, "main.go": testProgram})
+	otherBinary, err := buildProgram(gobin, other, "my/project")
+	assert.NoError(t, err)
+	assert.NotEqual(t, binary, otherBinary)
+	_, err = os.Stat(rebuilt)
+	assert.NoError(t, err)
+
+	// Compilation errors are reported with the compiler's output.
+	writeProgram(t, dir, map[string]string{"main.go": "package main\n\nfunc main() { undefined() }\n"})
+	_, err = buildProgram(gobin, dir, "my/project")
+	if assert.Error(t, err) {
+		assert.IsType(t, &buildError{}, err)
+		assert.Contains(t, err.Error(), "undefined")
+	}
+}
+
+func TestEvictBuilds(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "pulumi-language-go-cache")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(cacheDir)
+
+	now := time.Now()
+	build := func(prefix string, hash byte, age time.Duration) string {
+		name := prefix + strings.Repeat(string(hash), buildHashLength) + exeSuffix
+		path := filepath.Join(cacheDir, name)
+		if !assert.NoError(t, ioutil.WriteFile(path, nil, 0700)) {
+			t.FailNow()
+		}
+		assert.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		return name
+	}
+
+	prefix, otherPrefix := buildPrefix("proj", "/src/proj"), buildPrefix("proj", "/src/proj-worktree")
+	current := build(prefix, 'a', 0)
+	recent := build(prefix, 'b', time.Hour)
+	older := build(prefix, 'c', 2*time.Hour)
+	oldest := build(prefix, 'd', 3*time.Hour)
+	other := build(otherPrefix, 'a', 4*time.Hour)
+	unused := build(otherPrefix, 'b', buildCacheMaxAge+time.Hour)
+	writeProgram(t, cacheDir, map[string]string{"notes.txt": "not a build\n"})
+	assert.NoError(t, os.Chtimes(filepath.Join(cacheDir, "notes.txt"), now.Add(-2*buildCacheMaxAge),
+		now.Add(-2*buildCacheMaxAge)))
+
+	evictBuilds(cacheDir, prefix, filepath.Join(cacheDir, current), now)
+
+	// The program's most recently used builds are kept, as are the recent builds of other programs. Builds that have
+	// not been used for a long time are removed.
+	var remaining []string
+	infos, err := ioutil.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	for _, info := range infos {
+		remaining = append(remaining, info.Name())
+	}
+	assert.ElementsMatch(t, []string{current, recent, older, other, "notes.txt"}, remaining)
+	assert.NotContains(t, remaining, oldest)
+	assert.NotContains(t, remaining, unused)
+}
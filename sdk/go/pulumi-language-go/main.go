@@ -41,6 +41,11 @@ func main() {
 	var tracing string
 	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
 
+	// The binary to run, if the program is built ahead of time, e.g. by a CI pipeline. This is set through the
+	// `runtime.options.binary` setting in Pulumi.yaml.
+	var binary string
+	flag.StringVar(&binary, "binary", "", "Run the given pre-built binary rather than building the program")
+
 	flag.Parse()
 	args := flag.Args()
 	logging.InitLogging(false, 0, false)
@@ -55,7 +60,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(engineAddress, tracing)
+			host := newLanguageHost(engineAddress, tracing, binary)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -77,19 +82,36 @@ func main() {
 type goLanguageHost struct {
 	engineAddress string
 	tracing       string
+	binary        string
 }
 
-func newLanguageHost(engineAddress, tracing string) pulumirpc.LanguageRuntimeServer {
+func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRuntimeServer {
 	return &goLanguageHost{
 		engineAddress: engineAddress,
 		tracing:       tracing,
+		binary:        binary,
 	}
 }
 
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
 func (host *goLanguageHost) GetRequiredPlugins(ctx context.Context,
 	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
-	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	// To get the plugins required by a program, list the modules in the program's module graph and look for those
+	// that correspond to resource provider SDKs. Errors are logged rather than returned, as the engine will still
+	// report any plugins that turn out to be missing once the program runs.
+	modules, err := listModules(ctx, req.GetPwd())
+	if err != nil {
+		logging.V(3).Infof("unable to list the modules of the program: %s", err)
+		return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	}
+
+	plugins, err := getPlugins(modules)
+	if err != nil {
+		logging.V(3).Infof("one or more errors while discovering plugins: %s", err)
+	}
+	return &pulumirpc.GetRequiredPluginsResponse{
+		Plugins: plugins,
+	}, nil
 }
 
 const unableToFindProgramTemplate = "unable to find program: %s"
@@ -128,6 +150,44 @@ func findProgram(program string) (string, error) {
 	return "", errors.Errorf(unableToFindProgramTemplate, program)
 }
 
+// getProgram returns the path of the binary to run for the given project. If a pre-built binary was configured, it is
+// used as-is. Otherwise, a binary named after the project is used if one exists, so that programs installed with
+// `go install .` are run directly. Failing that, the program in the current directory is built into the build cache,
+// reusing any previous build of the same sources.
+func (host *goLanguageHost) getProgram(project string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get current working directory")
+	}
+
+	if host.binary != "" {
+		program := host.binary
+		if !filepath.IsAbs(program) {
+			program = filepath.Join(cwd, program)
+		}
+		if info, err := os.Stat(program); err != nil || info.IsDir() {
+			return "", errors.Errorf("unable to find the binary %s given by runtime.options.binary", host.binary)
+		}
+		logging.V(5).Infof("using pre-built binary %s", program)
+		return program, nil
+	}
+
+	program, err := findProgram(project)
+	if err == nil {
+		return program, nil
+	}
+	if err.Error() != fmt.Sprintf(unableToFindProgramTemplate, project) {
+		return "", errors.Wrap(err, "problem executing program (could not run language executor)")
+	}
+	logging.V(5).Infof("unable to find program %s, building it from source", project)
+
+	gobin, err := findProgram("go")
+	if err != nil {
+		return "", errors.Wrap(err, "problem executing program (could not find the 'go' executable)")
+	}
+	return buildProgram(gobin, cwd, project)
+}
+
 // RPC endpoint for LanguageRuntimeServer::Run
 func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
 	// Create the environment we'll use to run the process.  This is how we pass the RunInfo to the actual
@@ -137,51 +197,21 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	// by default we try to run a named executable on the path, but we will fallback to 'go run' style execution
-	goRunInvoke := false
-
-	// The program to execute is simply the name of the project.  This ensures good Go toolability, whereby
-	// you can simply run `go install .` to build a Pulumi program prior to running it, among other benefits.
-	// For ease of use, if we don't find a pre-built program, we attempt to invoke via 'go run' on behalf of the user.
-	program, err := findProgram(req.GetProject())
+	// Build the program, or find the pre-built binary. Compilation errors are the user's to fix, so they are
+	// reported as the result of the run rather than as a failure of the language host.
+	program, err := host.getProgram(req.GetProject())
 	if err != nil {
-		const message = "problem executing program (could not run language executor)"
-		if err.Error() == fmt.Sprintf(unableToFindProgramTemplate, req.GetProject()) {
-			logging.V(5).Infof("Unable to find program %s in $PATH, attempting invocation via 'go run'", program)
-			program, err = findProgram("go")
-			if err != nil {
-				return nil, errors.Wrap(err, message)
-			}
-			goRunInvoke = true
-		} else {
-			return nil, errors.Wrap(err, message)
+		if _, ok := err.(*buildError); ok {
+			return &pulumirpc.RunResponse{Error: err.Error()}, nil
 		}
+		return nil, err
 	}
 
 	logging.V(5).Infof("language host launching process: %s", program)
 
 	// Now simply spawn a process to execute the requested program, wiring up stdout/stderr directly.
 	var errResult string
-	var cmd *exec.Cmd
-
-	if goRunInvoke {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to get current working directory")
-		}
-
-		goFileSearchPattern := filepath.Join(cwd, "*.go")
-		if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
-			return nil, errors.Errorf("Failed to find go files for 'go run' matching %s", goFileSearchPattern)
-		}
-
-		args := []string{"run", cwd}
-		// go run $cwd
-		cmd = exec.Command(program, args...)
-	} else {
-		cmd = exec.Command(program)
-	}
-
+	cmd := exec.Command(program)
 	cmd.Env = env
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgram(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("binaries in $PATH require an executable extension on Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	dir, err = filepath.EvalSymlinks(dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// The program directory contains a pre-built binary, and a binary named after the project is installed in $PATH.
+	programDir, binDir := filepath.Join(dir, "program"), filepath.Join(dir, "bin")
+	writeProgram(t, programDir, map[string]string{"out/program": "#!/bin/sh\n"})
+	writeProgram(t, binDir, map[string]string{"project": "#!/bin/sh\n"})
+	assert.NoError(t, os.Chmod(filepath.Join(binDir, "project"), 0700))
+
+	cwd, err := os.Getwd()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer func() { assert.NoError(t, os.Chdir(cwd)) }()
+	assert.NoError(t, os.Chdir(programDir))
+
+	oldPath, oldGoPath := os.Getenv("PATH"), os.Getenv("GOPATH")
+	defer func() {
+		os.Setenv("PATH", oldPath)
+		os.Setenv("GOPATH", oldGoPath)
+	}()
+	os.Setenv("PATH", binDir)
+	os.Setenv("GOPATH", dir)
+
+	// A configured binary takes precedence over the binary in $PATH.
+	program, err := (&goLanguageHost{binary: "out/program"}).getProgram("project")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(programDir, "out", "program"), program)
+
+	_, err = (&goLanguageHost{binary: "out/missing"}).getProgram("project")
+	assert.EqualError(t, err, "unable to find the binary out/missing given by runtime.options.binary")
+
+	// Otherwise, a binary installed with `go install .` is run rather than building the program.
+	program, err = (&goLanguageHost{}).getProgram("project")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(binDir, "project"), program)
+
+	// If there is no such binary, the program is built, which requires the go executable.
+	_, err = (&goLanguageHost{}).getProgram("other")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not find the 'go' executable")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// pluginManifestFile is the name of the file that a Go SDK module may place at its root to declare the resource
+// provider plugin it requires.
+const pluginManifestFile = "pulumiplugin.json"
+
+// goModule is the subset of the output of `go list -m -json` that we care about.
+type goModule struct {
+	Path    string
+	Version string
+	Dir     string
+	Main    bool
+	Replace *goModule
+}
+
+// pluginManifest is the contents of a pulumiplugin.json file. If the name or version are omitted, they are derived
+// from the module's path and version.
+type pluginManifest struct {
+	Resource bool   `json:"resource"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Server   string `json:"server"`
+}
+
+// pulumiSDKModulePattern matches the paths of the Go SDK modules for Pulumi's own resource providers, e.g.
+// github.com/pulumi/pulumi-aws/sdk or github.com/pulumi/pulumi-aws/sdk/v2. The first submatch is the plugin name.
+var pulumiSDKModulePattern = regexp.MustCompile(`^github\.com/pulumi/pulumi-([a-z0-9-]+)/sdk(/v[0-9]+)?$`)
+
+// listModules returns the module graph of the Go program in the given directory by running `go list -m -json all`.
+func listModules(ctx context.Context, dir string) ([]*goModule, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find 'go' executable")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, gobin, "list", "-m", "-json", "all")
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "'go list -m -json all' failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	// The output is a sequence of JSON objects, one per module.
+	var modules []*goModule
+	for dec := json.NewDecoder(&stdout); ; {
+		var m goModule
+		if err := dec.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "parsing 'go list' output")
+		}
+		modules = append(modules, &m)
+	}
+	return modules, nil
+}
+
+// getPlugins returns the resource provider plugins required by the given modules. A module requires a plugin if it
+// has a pulumiplugin.json file at its root that sets "resource" to true, or, absent such a file, if it is the Go SDK
+// module for one of Pulumi's own resource providers.
+func getPlugins(modules []*goModule) ([]*pulumirpc.PluginDependency, error) {
+	var plugins []*pulumirpc.PluginDependency
+	var allErrors *multierror.Error
+	seen := map[string]bool{}
+	for _, m := range modules {
+		if m.Main {
+			continue
+		}
+
+		plugin, err := getPlugin(m)
+		if err != nil {
+			allErrors = multierror.Append(allErrors, err)
+			continue
+		}
+		if plugin == nil || seen[plugin.Name] {
+			continue
+		}
+
+		seen[plugin.Name] = true
+		plugins = append(plugins, plugin)
+	}
+	return plugins, allErrors.ErrorOrNil()
+}
+
+// getPlugin returns the resource provider plugin required by the given module, if any.
+func getPlugin(m *goModule) (*pulumirpc.PluginDependency, error) {
+	// Replacements determine the source of a module but not its identity: the module's version still comes from the
+	// original requirement unless the replacement is itself versioned.
+	version, dir := m.Version, m.Dir
+	if m.Replace != nil {
+		if m.Replace.Version != "" {
+			version = m.Replace.Version
+		}
+		if m.Replace.Dir != "" {
+			dir = m.Replace.Dir
+		}
+	}
+
+	var manifest pluginManifest
+	hasManifest := false
+	if dir != "" {
+		path := filepath.Join(dir, pluginManifestFile)
+		b, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if err = json.Unmarshal(b, &manifest); err != nil {
+				return nil, errors.Wrapf(err, "unmarshaling %s", path)
+			}
+			hasManifest = true
+		case !os.IsNotExist(err):
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+	}
+
+	conventionalName := ""
+	if match := pulumiSDKModulePattern.FindStringSubmatch(m.Path); match != nil {
+		conventionalName = match[1]
+	}
+
+	switch {
+	case hasManifest && !manifest.Resource:
+		return nil, nil
+	case !hasManifest && conventionalName == "":
+		return nil, nil
+	}
+
+	name := manifest.Name
+	if name == "" {
+		if conventionalName == "" {
+			return nil, errors.Errorf("module %s: %s is missing the plugin name", m.Path, pluginManifestFile)
+		}
+		name = conventionalName
+	}
+
+	if manifest.Version != "" {
+		version = manifest.Version
+	}
+	if version == "" {
+		return nil, errors.Errorf("module %s: unable to determine the version of plugin %s", m.Path, name)
+	}
+	version = strings.TrimSuffix(version, "+incompatible")
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	return &pulumirpc.PluginDependency{
+		Name:    name,
+		Kind:    "resource",
+		Version: version,
+		Server:  manifest.Server,
+	}, nil
+}
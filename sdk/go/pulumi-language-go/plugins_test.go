@@ -0,0 +1,74 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeManifest(t *testing.T, root, name, contents string) string {
+	dir := filepath.Join(root, name)
+	if !assert.NoError(t, os.MkdirAll(dir, 0700)) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, pluginManifestFile), []byte(contents), 0600)) {
+		t.FailNow()
+	}
+	return dir
+}
+
+func TestGetPlugins(t *testing.T) {
+	root, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(root)
+
+	custom := writeManifest(t, root, "custom",
+		`{"resource": true, "name": "custom", "version": "1.2.3", "server": "https://example.com"}`)
+	notAProvider := writeManifest(t, root, "lib", `{"resource": false}`)
+	versionless := writeManifest(t, root, "random", `{"resource": true}`)
+	nameless := writeManifest(t, root, "nameless", `{"resource": true}`)
+
+	modules := []*goModule{
+		{Path: "example.com/program", Main: true, Dir: root},
+		{Path: "github.com/pulumi/pulumi", Version: "v1.11.0"},
+		{Path: "github.com/pulumi/pulumi-aws/sdk", Version: "v1.24.0"},
+		{Path: "github.com/pulumi/pulumi-azure/sdk/v2", Version: "v2.1.0"},
+		{Path: "github.com/pulumi/pulumi-terraform-bridge", Version: "v1.8.0"},
+		{Path: "example.com/custom", Version: "v0.1.0", Dir: custom},
+		{Path: "example.com/lib", Version: "v0.1.0", Dir: notAProvider},
+		{Path: "github.com/pulumi/pulumi-random/sdk", Version: "v1.5.0+incompatible", Dir: versionless},
+		{Path: "example.com/nameless", Version: "v0.1.0", Dir: nameless},
+		{Path: "github.com/pulumi/pulumi-kubernetes/sdk", Version: "v1.5.0",
+			Replace: &goModule{Path: "example.com/kubernetes", Version: "v1.5.1"}},
+	}
+
+	plugins, err := getPlugins(modules)
+	assert.Error(t, err)
+	assert.Equal(t, []*pulumirpc.PluginDependency{
+		{Name: "aws", Kind: "resource", Version: "v1.24.0"},
+		{Name: "azure", Kind: "resource", Version: "v2.1.0"},
+		{Name: "custom", Kind: "resource", Version: "v1.2.3", Server: "https://example.com"},
+		{Name: "random", Kind: "resource", Version: "v1.5.0"},
+		{Name: "kubernetes", Kind: "resource", Version: "v1.5.1"},
+	}, plugins)
+}
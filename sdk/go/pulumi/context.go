@@ -49,6 +49,10 @@ type Context struct {
 	rpcsDone    *sync.Cond  // an event signaling completion of RPCs.
 	rpcsLock    *sync.Mutex // a lock protecting the RPC count and event.
 	rpcError    error       // the first error (if any) encountered during an RPC.
+
+	stackTransformations []ResourceTransformation // transformations applied to all resources in the stack.
+
+	Log Log // the logging interface for the Pulumi log stream.
 }
 
 // NewContext creates a fresh run context out of the given metadata.
@@ -93,6 +97,7 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		rpcs:        0,
 		rpcsLock:    mutex,
 		rpcsDone:    sync.NewCond(mutex),
+		Log:         &logState{engine: engine, ctx: ctx},
 	}, nil
 }
 
@@ -156,11 +161,11 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 		providerRef = pr
 	}
 
-	// Serialize arguments. Outputs will not be awaited: instead, an error will be returned if any Outputs are present.
+	// Serialize arguments, awaiting any Outputs so that secret arguments are passed to the provider as secrets.
 	if args == nil {
 		args = struct{}{}
 	}
-	resolvedArgs, _, err := marshalInput(args, anyType, false)
+	resolvedArgs, _, err := marshalInput(args, anyType, true)
 	if err != nil {
 		return errors.Wrap(err, "marshaling arguments")
 	}
@@ -172,7 +177,7 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 
 	rpcArgs, err := plugin.MarshalProperties(
 		resolvedArgsMap,
-		plugin.MarshalOptions{KeepUnknowns: false})
+		plugin.MarshalOptions{KeepUnknowns: false, KeepSecrets: true})
 	if err != nil {
 		return errors.Wrap(err, "marshaling arguments")
 	}
@@ -212,7 +217,7 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 		return err
 	}
 
-	if err = unmarshalOutput(resource.NewObjectProperty(outProps), resultV.Elem()); err != nil {
+	if _, err = unmarshalOutput(resource.NewObjectProperty(outProps), resultV.Elem()); err != nil {
 		return err
 	}
 	logging.V(9).Infof("Invoke(%s, ...): success: w/ %d outs (err=%v)", tok, len(outProps), err)
@@ -253,22 +258,14 @@ func (ctx *Context) ReadResource(
 		return errors.New("resource ID is required for lookup and cannot be empty")
 	}
 
-	if props != nil {
-		propsType := reflect.TypeOf(props)
-		if propsType.Kind() == reflect.Ptr {
-			propsType = propsType.Elem()
-		}
-		if propsType.Kind() != reflect.Struct {
-			return errors.New("props must be a struct or a pointer to a struct")
-		}
+	// Apply any transformations.
+	props, options, transformations, err := ctx.applyTransformations(t, name, props, resource, opts)
+	if err != nil {
+		return err
 	}
 
-	options := &resourceOptions{}
-	for _, o := range opts {
-		o.applyResourceOption(options)
-	}
-	if options.Parent == nil {
-		options.Parent = ctx.stack
+	if err := checkProps(props); err != nil {
+		return err
 	}
 
 	// Collapse aliases to URNs.
@@ -286,7 +283,7 @@ func (ctx *Context) ReadResource(
 	providers := mergeProviders(t, options.Parent, options.Provider, options.Providers)
 
 	// Create resolvers for the resource's outputs.
-	res := makeResourceState(t, name, resource, providers, aliasURNs)
+	res := makeResourceState(t, name, resource, providers, aliasURNs, transformations)
 
 	// Kick off the resource read operation.  This will happen asynchronously and resolve the above properties.
 	go func() {
@@ -313,13 +310,15 @@ func (ctx *Context) ReadResource(
 
 		logging.V(9).Infof("ReadResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
-			Type:       t,
-			Name:       name,
-			Parent:     inputs.parent,
-			Properties: inputs.rpcProps,
-			Provider:   inputs.provider,
-			Id:         string(idToRead),
-			Aliases:    inputs.aliases,
+			Type:                    t,
+			Name:                    name,
+			Parent:                  inputs.parent,
+			Properties:              inputs.rpcProps,
+			Provider:                inputs.provider,
+			Id:                      string(idToRead),
+			Aliases:                 inputs.aliases,
+			AcceptSecrets:           true,
+			AdditionalSecretOutputs: inputs.additionalSecretOutputs,
 		})
 		if err != nil {
 			logging.V(9).Infof("ReadResource(%s, %s): error: %v", t, name, err)
@@ -373,22 +372,14 @@ func (ctx *Context) RegisterResource(
 		return errors.New("provider resource type must begin with \"pulumi:providers:\"")
 	}
 
-	if props != nil {
-		propsType := reflect.TypeOf(props)
-		if propsType.Kind() == reflect.Ptr {
-			propsType = propsType.Elem()
-		}
-		if propsType.Kind() != reflect.Struct {
-			return errors.New("props must be a struct or a pointer to a struct")
-		}
+	// Apply any transformations.
+	props, options, transformations, err := ctx.applyTransformations(t, name, props, resource, opts)
+	if err != nil {
+		return err
 	}
 
-	options := &resourceOptions{}
-	for _, o := range opts {
-		o.applyResourceOption(options)
-	}
-	if options.Parent == nil {
-		options.Parent = ctx.stack
+	if err := checkProps(props); err != nil {
+		return err
 	}
 
 	// Collapse aliases to URNs.
@@ -406,7 +397,7 @@ func (ctx *Context) RegisterResource(
 	providers := mergeProviders(t, options.Parent, options.Provider, options.Providers)
 
 	// Create resolvers for the resource's outputs.
-	res := makeResourceState(t, name, resource, providers, aliasURNs)
+	res := makeResourceState(t, name, resource, providers, aliasURNs, transformations)
 
 	// Kick off the resource registration.  If we are actually performing a deployment, the resulting properties
 	// will be resolved asynchronously as the RPC operation completes.  If we're just planning, values won't resolve.
@@ -429,20 +420,22 @@ func (ctx *Context) RegisterResource(
 
 		logging.V(9).Infof("RegisterResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.RegisterResource(ctx.ctx, &pulumirpc.RegisterResourceRequest{
-			Type:                 t,
-			Name:                 name,
-			Parent:               inputs.parent,
-			Object:               inputs.rpcProps,
-			Custom:               custom,
-			Protect:              inputs.protect,
-			Dependencies:         inputs.deps,
-			Provider:             inputs.provider,
-			PropertyDependencies: inputs.rpcPropertyDeps,
-			DeleteBeforeReplace:  inputs.deleteBeforeReplace,
-			ImportId:             inputs.importID,
-			CustomTimeouts:       inputs.customTimeouts,
-			IgnoreChanges:        inputs.ignoreChanges,
-			Aliases:              inputs.aliases,
+			Type:                    t,
+			Name:                    name,
+			Parent:                  inputs.parent,
+			Object:                  inputs.rpcProps,
+			Custom:                  custom,
+			Protect:                 inputs.protect,
+			Dependencies:            inputs.deps,
+			Provider:                inputs.provider,
+			PropertyDependencies:    inputs.rpcPropertyDeps,
+			DeleteBeforeReplace:     inputs.deleteBeforeReplace,
+			ImportId:                inputs.importID,
+			CustomTimeouts:          inputs.customTimeouts,
+			IgnoreChanges:           inputs.ignoreChanges,
+			Aliases:                 inputs.aliases,
+			AcceptSecrets:           true,
+			AdditionalSecretOutputs: inputs.additionalSecretOutputs,
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -458,12 +451,147 @@ func (ctx *Context) RegisterResource(
 	return nil
 }
 
+// RegisterStackTransformation adds a transformation to all future resources constructed in this Pulumi stack.
+func (ctx *Context) RegisterStackTransformation(t ResourceTransformation) error {
+	ctx.stackTransformations = append(ctx.stackTransformations, t)
+	return nil
+}
+
+// newResourceOptions applies the given options to a fresh set of resource options.  Resources without a parent are
+// parented to the stack.
+func (ctx *Context) newResourceOptions(opts []ResourceOption) *resourceOptions {
+	options := &resourceOptions{}
+	for _, o := range opts {
+		o.applyResourceOption(options)
+	}
+	if options.Parent == nil {
+		options.Parent = ctx.stack
+	}
+	return options
+}
+
+// applyTransformations applies the transformations of a resource, those of its parents, and those of the stack to
+// the resource's props and options.  It returns the transformed props and options along with the transformations
+// that are inherited by the resource's children.
+func (ctx *Context) applyTransformations(t, name string, props Input, resource Resource,
+	opts []ResourceOption) (Input, *resourceOptions, []ResourceTransformation, error) {
+
+	options := ctx.newResourceOptions(opts)
+
+	// The resource's own transformations are applied before those of its parents.
+	transformations := append([]ResourceTransformation{}, options.Transformations...)
+	if options.Parent != nil {
+		transformations = append(transformations, options.Parent.getTransformations()...)
+	}
+
+	for _, transformation := range append(transformations, ctx.stackTransformations...) {
+		res := transformation(&ResourceTransformationArgs{
+			Resource: resource,
+			Type:     t,
+			Name:     name,
+			Props:    props,
+			Opts:     opts,
+		})
+		if res == nil {
+			continue
+		}
+
+		newOptions := ctx.newResourceOptions(res.Opts)
+		if newOptions.Parent != options.Parent {
+			return nil, nil, nil, errors.New("transformations cannot currently be used to change the parent of a resource")
+		}
+		props, opts, options = res.Props, res.Opts, newOptions
+	}
+
+	return props, options, transformations, nil
+}
+
+// checkProps returns an error if the given props are neither nil nor a struct or pointer to a struct.
+func checkProps(props Input) error {
+	if props != nil {
+		propsType := reflect.TypeOf(props)
+		if propsType.Kind() == reflect.Ptr {
+			propsType = propsType.Elem()
+		}
+		if propsType.Kind() != reflect.Struct {
+			return errors.New("props must be a struct or a pointer to a struct")
+		}
+	}
+	return nil
+}
+
+// RegisterComponentResource registers a new component resource. t is the fully qualified type token and name is the
+// "name" part to use in creating a stable and globally unique URN for the object.
+//
+// The value passed to resource must be a pointer to a struct that embeds the ResourceState type. Fields of the struct
+// that have types that are assignable from Output and a `pulumi` tag are the component's outputs. Once the component
+// has created its children and assigned its outputs, it should call RegisterComponentOutputs to complete its
+// registration.
+//
+// For example, given a component with a string-typed output "url", one would define the following type:
+//
+//     type MyComponent struct {
+//         pulumi.ResourceState
+//
+//         URL pulumi.StringOutput `pulumi:"url"`
+//     }
+//
+// And construct the component like so:
+//
+//     component := &MyComponent{}
+//     err := ctx.RegisterComponentResource("my:module:MyComponent", name, component, opts...)
+//     ...
+//     var bucket Bucket
+//     err = ctx.RegisterResource("my:module:Bucket", name, nil, &bucket, pulumi.Parent(component))
+//     ...
+//     component.URL = bucket.URL
+//     err = ctx.RegisterComponentOutputs(component)
+//
 func (ctx *Context) RegisterComponentResource(
 	t, name string, resource ComponentResource, opts ...ResourceOption) error {
 
 	return ctx.RegisterResource(t, name, nil, resource, opts...)
 }
 
+// RegisterComponentOutputs completes the registration of a component resource by registering the values of the
+// component's output fields as its outputs. The output fields of a component are the fields of its struct type that
+// have types that are assignable from Output and a `pulumi` tag. Fields with nil values are ignored.
+func (ctx *Context) RegisterComponentOutputs(resource ComponentResource) error {
+	outs, err := componentOutputs(resource)
+	if err != nil {
+		return err
+	}
+	return ctx.RegisterResourceOutputs(resource, outs)
+}
+
+// componentOutputs gathers the values of the given component's output fields into a map keyed by the fields' tags.
+func componentOutputs(resource ComponentResource) (Map, error) {
+	resourceV := reflect.ValueOf(resource)
+	if resourceV.Kind() != reflect.Ptr || resourceV.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("component resource must be a pointer to a struct, not a %T", resource)
+	}
+	resourceV = resourceV.Elem()
+
+	outs := Map{}
+	typ := resourceV.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("pulumi")
+		if tag == "" || field.Anonymous || !field.Type.Implements(outputType) {
+			continue
+		}
+
+		fieldV := resourceV.Field(i)
+		if !fieldV.CanInterface() {
+			return nil, errors.Errorf("output field %v of %v must be exported", field.Name, typ)
+		}
+		if output, ok := fieldV.Interface().(Output); ok && output.getState() != nil {
+			outs[tag] = output
+		}
+	}
+	return outs, nil
+}
+
 // resourceState contains the results of a resource registration operation.
 type resourceState struct {
 	outputs   map[string]Output
@@ -533,7 +661,7 @@ func (ctx *Context) collapseAliases(aliases []Alias, t, name string, parent Reso
 // makeResourceState creates a set of resolvers that we'll use to finalize state, for URNs, IDs, and output
 // properties.
 func makeResourceState(t, name string, resourceV Resource, providers map[string]ProviderResource,
-	aliases []URNOutput) *resourceState {
+	aliases []URNOutput, transformations []ResourceTransformation) *resourceState {
 
 	resource := reflect.ValueOf(resourceV)
 
@@ -603,6 +731,7 @@ func makeResourceState(t, name string, resourceV Resource, providers map[string]
 	rs.name = name
 	state.aliases = aliases
 	rs.aliases = aliases
+	rs.transformations = transformations
 
 	return state
 }
@@ -642,6 +771,11 @@ func (state *resourceState) resolve(dryrun bool, err error, inputs *resourceInpu
 			v = inprops[resource.PropertyKey(k)]
 		}
 
+		secret := v.IsSecret()
+		if secret {
+			v = v.SecretValue().Element
+		}
+
 		known := true
 		if v.IsNull() || v.IsComputed() || v.IsOutput() {
 			known = !dryrun
@@ -649,28 +783,30 @@ func (state *resourceState) resolve(dryrun bool, err error, inputs *resourceInpu
 
 		// Allocate storage for the unmarshalled output.
 		dest := reflect.New(output.ElementType()).Elem()
-		if err = unmarshalOutput(v, dest); err != nil {
+		nestedSecret, err := unmarshalOutput(v, dest)
+		if err != nil {
 			output.reject(err)
 		} else {
-			output.resolve(dest.Interface(), known)
+			output.resolve(dest.Interface(), known, secret || nestedSecret)
 		}
 	}
 }
 
 // resourceInputs reflects all of the inputs necessary to perform core resource RPC operations.
 type resourceInputs struct {
-	parent              string
-	deps                []string
-	protect             bool
-	provider            string
-	resolvedProps       resource.PropertyMap
-	rpcProps            *structpb.Struct
-	rpcPropertyDeps     map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies
-	deleteBeforeReplace bool
-	importID            string
-	customTimeouts      *pulumirpc.RegisterResourceRequest_CustomTimeouts
-	ignoreChanges       []string
-	aliases             []string
+	parent                  string
+	deps                    []string
+	protect                 bool
+	provider                string
+	resolvedProps           resource.PropertyMap
+	rpcProps                *structpb.Struct
+	rpcPropertyDeps         map[string]*pulumirpc.RegisterResourceRequest_PropertyDependencies
+	deleteBeforeReplace     bool
+	importID                string
+	customTimeouts          *pulumirpc.RegisterResourceRequest_CustomTimeouts
+	ignoreChanges           []string
+	aliases                 []string
+	additionalSecretOutputs []string
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
@@ -697,7 +833,7 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 	keepUnknowns := ctx.DryRun()
 	rpcProps, err := plugin.MarshalProperties(
 		resolvedProps,
-		plugin.MarshalOptions{KeepUnknowns: keepUnknowns})
+		plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: true})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshaling properties")
 	}
@@ -742,18 +878,19 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 	}
 
 	return &resourceInputs{
-		parent:              string(parent),
-		deps:                deps,
-		protect:             protect,
-		provider:            provider,
-		resolvedProps:       resolvedProps,
-		rpcProps:            rpcProps,
-		rpcPropertyDeps:     rpcPropertyDeps,
-		deleteBeforeReplace: deleteBeforeReplace,
-		importID:            string(importID),
-		customTimeouts:      getTimeouts(opts.CustomTimeouts),
-		ignoreChanges:       ignoreChanges,
-		aliases:             aliases,
+		parent:                  string(parent),
+		deps:                    deps,
+		protect:                 protect,
+		provider:                provider,
+		resolvedProps:           resolvedProps,
+		rpcProps:                rpcProps,
+		rpcPropertyDeps:         rpcPropertyDeps,
+		deleteBeforeReplace:     deleteBeforeReplace,
+		importID:                string(importID),
+		customTimeouts:          getTimeouts(opts.CustomTimeouts),
+		ignoreChanges:           ignoreChanges,
+		aliases:                 aliases,
+		additionalSecretOutputs: opts.AdditionalSecretOutputs,
 	}, nil
 }
 
@@ -912,7 +1049,7 @@ func (ctx *Context) RegisterResourceOutputs(resource Resource, outs Map) error {
 		keepUnknowns := ctx.DryRun()
 		outsMarshalled, err := plugin.MarshalProperties(
 			outsResolved.ObjectValue(),
-			plugin.MarshalOptions{KeepUnknowns: keepUnknowns})
+			plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: true})
 		if err != nil {
 			return
 		}
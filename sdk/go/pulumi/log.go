@@ -0,0 +1,94 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"golang.org/x/net/context"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Log is a group of logging functions that send messages to the Pulumi engine, which displays them alongside the
+// progress of the current update.
+type Log interface {
+	// Debug logs a debug-level message that is generally hidden from end-users.
+	Debug(msg string, args *LogArgs) error
+	// Info logs an informational message that is generally printed to stdout during resource operations.
+	Info(msg string, args *LogArgs) error
+	// Warn logs a warning to indicate that something went wrong, but not catastrophically so.
+	Warn(msg string, args *LogArgs) error
+	// Error logs a fatal condition. Consider returning a non-nil error object after calling Error to stop the Pulumi
+	// program.
+	Error(msg string, args *LogArgs) error
+}
+
+// LogArgs may be used to associate a log message with a resource or a stream of messages.
+type LogArgs struct {
+	// Resource is an optional resource to associate the log message with.
+	Resource Resource
+	// StreamID is an optional stream ID that a stream of log messages can be associated with. This allows messages
+	// that are conceptually connected to be sent in chunks and stitched together by the engine. Zero means that the
+	// message is not associated with any stream.
+	StreamID int32
+}
+
+type logState struct {
+	engine pulumirpc.EngineClient
+	ctx    context.Context
+}
+
+func (log *logState) Debug(msg string, args *LogArgs) error {
+	return log.log(pulumirpc.LogSeverity_DEBUG, msg, args)
+}
+
+func (log *logState) Info(msg string, args *LogArgs) error {
+	return log.log(pulumirpc.LogSeverity_INFO, msg, args)
+}
+
+func (log *logState) Warn(msg string, args *LogArgs) error {
+	return log.log(pulumirpc.LogSeverity_WARNING, msg, args)
+}
+
+func (log *logState) Error(msg string, args *LogArgs) error {
+	return log.log(pulumirpc.LogSeverity_ERROR, msg, args)
+}
+
+func (log *logState) log(severity pulumirpc.LogSeverity, msg string, args *LogArgs) error {
+	// If we are not connected to an engine, there is nowhere to send the message.
+	if log.engine == nil {
+		return nil
+	}
+
+	var urn URN
+	var streamID int32
+	if args != nil {
+		if args.Resource != nil {
+			resURN, _, err := args.Resource.URN().awaitURN(log.ctx)
+			if err != nil {
+				return err
+			}
+			urn = resURN
+		}
+		streamID = args.StreamID
+	}
+
+	_, err := log.engine.Log(log.ctx, &pulumirpc.LogRequest{
+		Severity: severity,
+		Message:  msg,
+		Urn:      string(urn),
+		StreamId: streamID,
+	})
+	return err
+}
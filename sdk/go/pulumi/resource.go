@@ -36,6 +36,8 @@ type ResourceState struct {
 	aliases []URNOutput
 
 	name string
+
+	transformations []ResourceTransformation
 }
 
 func (s ResourceState) URN() URNOutput {
@@ -58,6 +60,10 @@ func (s ResourceState) getName() string {
 	return s.name
 }
 
+func (s ResourceState) getTransformations() []ResourceTransformation {
+	return s.transformations
+}
+
 func (ResourceState) isResource() {}
 
 type CustomResourceState struct {
@@ -96,6 +102,9 @@ type Resource interface {
 	// getName returns the name of the resource
 	getName() string
 
+	// getTransformations returns the transformations for the resource, which are inherited by its children.
+	getTransformations() []ResourceTransformation
+
 	// isResource() is a marker method used to ensure that all Resource types embed a ResourceState.
 	isResource()
 }
@@ -157,6 +166,13 @@ type resourceOptions struct {
 	IgnoreChanges []string
 	// Aliases is an optional list of identifiers used to find and use existing resources.
 	Aliases []Alias
+	// Transformations is an optional list of transformations to apply to this resource during construction. The
+	// transformations are applied in order, and are applied prior to transformation applied to parents walking from
+	// the resource up to the stack.
+	Transformations []ResourceTransformation
+	// AdditionalSecretOutputs is an optional list of output properties that should be treated as secrets in addition
+	// to those that the resource's provider marks as secret.
+	AdditionalSecretOutputs []string
 }
 
 type invokeOptions struct {
@@ -293,3 +309,48 @@ func Aliases(o []Alias) ResourceOption {
 		ro.Aliases = o
 	})
 }
+
+// Transformations is an optional list of transformations to be applied to the resource and all of its children.
+func Transformations(o []ResourceTransformation) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.Transformations = append(ro.Transformations, o...)
+	})
+}
+
+// AdditionalSecretOutputs specifies a list of output properties that should be treated as secrets in addition to those
+// that the resource's provider marks as secret.
+func AdditionalSecretOutputs(o []string) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.AdditionalSecretOutputs = o
+	})
+}
+
+// ResourceTransformationArgs is the argument bag passed to a resource transformation.
+type ResourceTransformationArgs struct {
+	// Resource is the resource instance that is being transformed.
+	Resource Resource
+	// Type is the type token of the resource.
+	Type string
+	// Name is the name of the resource.
+	Name string
+	// Props is the original properties of the resource.
+	Props Input
+	// Opts is the original resource options of the resource.
+	Opts []ResourceOption
+}
+
+// ResourceTransformationResult is the result that must be returned by a resource transformation callback.  It
+// includes new values to use for the `props` and `opts` of the `Resource` in place of the originally provided values.
+type ResourceTransformationResult struct {
+	// Props is the new properties to use in place of the original `props`.
+	Props Input
+	// Opts is the new resource options to use in place of the original `opts`.
+	Opts []ResourceOption
+}
+
+// ResourceTransformation is the callback signature for the `Transformations` resource option.  A transformation is
+// passed the same set of inputs provided to the `Resource` constructor, and can optionally return back alternate
+// values for the `props` and/or `opts` prior to the resource actually being created.  The effect will be as though
+// those props and opts were passed in place of the original call to the `Resource` constructor.  If the
+// transformation returns nil, this indicates that the resource will not be transformed.
+type ResourceTransformation func(*ResourceTransformationArgs) *ResourceTransformationResult
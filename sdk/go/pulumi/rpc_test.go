@@ -16,6 +16,7 @@
 package pulumi
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -70,7 +71,7 @@ func TestMarshalRoundtrip(t *testing.T) {
 	out, resolve, _ := NewOutput()
 	resolve("outputty")
 	out2 := newOutputState(reflect.TypeOf(""))
-	out2.fulfill(nil, false, nil)
+	out2.fulfill(nil, false, false, nil)
 	inputs := testInputs{
 		S:           String("a string"),
 		A:           Bool(true),
@@ -105,7 +106,8 @@ func TestMarshalRoundtrip(t *testing.T) {
 		assert.Equal(t, 0, len(deps))
 
 		// Now just unmarshal and ensure the resulting map matches.
-		resV, err := unmarshalPropertyValue(resource.NewObjectProperty(resolved))
+		resV, secret, err := unmarshalPropertyValue(resource.NewObjectProperty(resolved))
+		assert.False(t, secret)
 		if !assert.Nil(t, err) {
 			if !assert.NotNil(t, resV) {
 				res := resV.(map[string]interface{})
@@ -261,7 +263,7 @@ type testResource struct {
 
 func TestResourceState(t *testing.T) {
 	var theResource testResource
-	state := makeResourceState("", "", &theResource, nil, nil)
+	state := makeResourceState("", "", &theResource, nil, nil, nil)
 
 	resolved, _, _, _ := marshalInputs(&testResourceInputs{
 		Any:     String("foo"),
@@ -347,8 +349,9 @@ func TestResourceState(t *testing.T) {
 	}, pdeps)
 	assert.Equal(t, []URN{"foo"}, deps)
 
-	res, err := unmarshalPropertyValue(resource.NewObjectProperty(resolved))
+	res, secret, err := unmarshalPropertyValue(resource.NewObjectProperty(resolved))
 	assert.Nil(t, err)
+	assert.False(t, secret)
 	assert.Equal(t, map[string]interface{}{
 		"urn":     "foo",
 		"id":      "bar",
@@ -378,13 +381,71 @@ func TestResourceState(t *testing.T) {
 	}, res)
 }
 
-func TestUnmarshalUnsupportedSecret(t *testing.T) {
+func TestUnmarshalSecret(t *testing.T) {
 	secret := resource.MakeSecret(resource.NewPropertyValue("foo"))
 
-	_, err := unmarshalPropertyValue(secret)
-	assert.Error(t, err)
+	v, isSecret, err := unmarshalPropertyValue(secret)
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+	assert.Equal(t, "foo", v)
 
 	var sv string
-	err = unmarshalOutput(secret, reflect.ValueOf(&sv).Elem())
-	assert.Error(t, err)
+	isSecret, err = unmarshalOutput(secret, reflect.ValueOf(&sv).Elem())
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+	assert.Equal(t, "foo", sv)
+
+	// Secrets nested within other values make the entire value secret.
+	obj := resource.NewObjectProperty(resource.PropertyMap{
+		"foo": secret,
+		"bar": resource.NewStringProperty("baz"),
+	})
+	var mv map[string]string
+	isSecret, err = unmarshalOutput(obj, reflect.ValueOf(&mv).Elem())
+	assert.NoError(t, err)
+	assert.True(t, isSecret)
+	assert.Equal(t, map[string]string{"foo": "foo", "bar": "baz"}, mv)
+}
+
+// TestMarshalSecretRoundtrip ensures that secret outputs are marshaled as secret property values and that secret
+// property values unmarshal into secret outputs.
+func TestMarshalSecretRoundtrip(t *testing.T) {
+	resolved, _, _, err := marshalInputs(&testResourceInputs{
+		Bool:   Bool(true),
+		Int:    Unsecret(ToSecret(Int(42))).(IntOutput),
+		Map:    Map{"foo": ToSecret(String("bar"))},
+		String: ToSecret(String("qux")).(StringOutput),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewBoolProperty(true), resolved["bool"])
+	assert.Equal(t, resource.NewNumberProperty(42), resolved["int"])
+	assert.Equal(t, resource.NewObjectProperty(resource.PropertyMap{
+		"foo": resource.MakeSecret(resource.NewStringProperty("bar")),
+	}), resolved["map"])
+	assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("qux")), resolved["string"])
+
+	// Send the properties over the wire and use them to resolve a resource's outputs.
+	s, err := plugin.MarshalProperties(resolved, plugin.MarshalOptions{KeepSecrets: true})
+	assert.NoError(t, err)
+
+	var theResource testResource
+	state := makeResourceState("", "", &theResource, nil, nil, nil)
+	state.resolve(false, nil, nil, "foo", "bar", s)
+
+	for _, c := range []struct {
+		output Output
+		value  interface{}
+		secret bool
+	}{
+		{theResource.Bool, true, false},
+		{theResource.Int, 42, false},
+		{theResource.Map, map[string]interface{}{"foo": "bar"}, true},
+		{theResource.String, "qux", true},
+	} {
+		v, known, secret, err := c.output.await(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, c.secret, secret)
+		assert.Equal(t, c.value, v)
+	}
 }
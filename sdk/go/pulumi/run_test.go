@@ -2,9 +2,16 @@ package pulumi
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
 	"github.com/pulumi/pulumi/pkg/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -188,3 +195,307 @@ func TestInvoke(t *testing.T) {
 	}, WithMocks("project", "stack", mocks))
 	assert.NoError(t, err)
 }
+
+func TestInvokeSecretArgs(t *testing.T) {
+	mocks := &testMonitor{
+		CallF: func(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+			assert.True(t, args["bang"].IsSecret())
+			assert.Equal(t, "gnab", args["bang"].SecretValue().Element.StringValue())
+			assert.False(t, args["bar"].IsSecret())
+			return resource.NewPropertyMapFromMap(map[string]interface{}{
+				"foo": "oof",
+				"baz": "zab",
+			}), nil
+		},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		var result invokeResult
+		err := ctx.Invoke("test:index:func", Map{
+			"bang": ToSecret(String("gnab")),
+			"bar":  String("rab"),
+		}, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "oof", result.Foo)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+}
+
+// testOutputsMonitor records the outputs that are registered for each resource.
+type testOutputsMonitor struct {
+	pulumirpc.ResourceMonitorClient
+
+	lock    sync.Mutex
+	outputs map[string]*structpb.Struct
+}
+
+func (m *testOutputsMonitor) RegisterResourceOutputs(ctx context.Context,
+	in *pulumirpc.RegisterResourceOutputsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.outputs[in.GetUrn()] = in.GetOutputs()
+	return m.ResourceMonitorClient.RegisterResourceOutputs(ctx, in, opts...)
+}
+
+// recordOutputs replaces the context's resource monitor with one that records the outputs registered for each
+// resource.
+func recordOutputs(ctx *Context) *testOutputsMonitor {
+	monitor := &testOutputsMonitor{ResourceMonitorClient: ctx.monitor, outputs: map[string]*structpb.Struct{}}
+	ctx.monitor = monitor
+	return monitor
+}
+
+// assertSecretOutput asserts that the given registered output is marshaled as a secret.
+func assertSecretOutput(t *testing.T, outputs *structpb.Struct, key string) {
+	if !assert.NotNil(t, outputs) || !assert.Contains(t, outputs.GetFields(), key) {
+		return
+	}
+	secret := outputs.GetFields()[key].GetStructValue()
+	if assert.NotNil(t, secret, key) {
+		assert.Equal(t, resource.SecretSig, secret.GetFields()[resource.SigKey].GetStringValue(), key)
+	}
+}
+
+func TestExportSecret(t *testing.T) {
+	var monitor *testOutputsMonitor
+	var stackURN URN
+	err := RunErr(func(ctx *Context) error {
+		monitor = recordOutputs(ctx)
+
+		urn, _, err := ctx.stack.URN().awaitURN(ctx.ctx)
+		assert.NoError(t, err)
+		stackURN = urn
+
+		ctx.Export("secret", ToSecret(String("shh")))
+		ctx.Export("plain", String("hello"))
+		return nil
+	}, WithMocks("project", "stack", &testMonitor{}))
+	assert.NoError(t, err)
+
+	// Secret exports are registered as secrets, so that they are encrypted in the checkpoint.
+	outputs := monitor.outputs[string(stackURN)]
+	assertSecretOutput(t, outputs, "secret")
+	if assert.NotNil(t, outputs) {
+		assert.Equal(t, "hello", outputs.GetFields()["plain"].GetStringValue())
+	}
+}
+
+type testComponent struct {
+	ResourceState
+}
+
+// appendFoo returns a transformation that appends the given suffix to the foo input of test resources.
+func appendFoo(suffix string) ResourceTransformation {
+	return func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		props, ok := args.Props.(*testResource2Inputs)
+		if !ok {
+			return nil
+		}
+		transformed := *props
+		transformed.Foo = String(string(props.Foo.(String)) + suffix)
+		return &ResourceTransformationResult{Props: &transformed, Opts: args.Opts}
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	var lock sync.Mutex
+	foos := map[string]interface{}{}
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			if typeToken == "test:resource:type" {
+				lock.Lock()
+				defer lock.Unlock()
+				foos[name] = inputs["foo"].StringValue()
+			}
+			return name, resource.PropertyMap{}, nil
+		},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		assert.NoError(t, ctx.RegisterStackTransformation(appendFoo("-stack")))
+
+		var parent testComponent
+		err := ctx.RegisterComponentResource("test:component:type", "parent", &parent,
+			Transformations([]ResourceTransformation{appendFoo("-parent")}))
+		assert.NoError(t, err)
+
+		// The child's own transformations run first, followed by its parent's and then the stack's.
+		var child testResource2
+		err = ctx.RegisterResource("test:resource:type", "child", &testResource2Inputs{Foo: String("foo")}, &child,
+			Parent(&parent), Transformations([]ResourceTransformation{appendFoo("-child")}))
+		assert.NoError(t, err)
+
+		// Resources outside of the parent only see the stack's transformations.
+		var other testResource2
+		err = ctx.RegisterResource("test:resource:type", "other", &testResource2Inputs{Foo: String("foo")}, &other)
+		assert.NoError(t, err)
+
+		// Transformations may not reparent resources.
+		var orphan testResource2
+		err = ctx.RegisterResource("test:resource:type", "orphan", &testResource2Inputs{Foo: String("foo")}, &orphan,
+			Parent(&parent), Transformations([]ResourceTransformation{
+				func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+					return &ResourceTransformationResult{Props: args.Props, Opts: []ResourceOption{Parent(&other)}}
+				},
+			}))
+		assert.Error(t, err)
+
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"child": "foo-child-parent-stack",
+		"other": "foo-stack",
+	}, foos)
+}
+
+type testTypedComponent struct {
+	ResourceState
+
+	Foo   StringOutput `pulumi:"foo"`
+	Child *testResource2
+}
+
+func newTestTypedComponent(ctx *Context, name, foo string, opts ...ResourceOption) (*testTypedComponent, error) {
+	component := &testTypedComponent{}
+	if err := ctx.RegisterComponentResource("test:component:typed", name, component, opts...); err != nil {
+		return nil, err
+	}
+
+	var child testResource2
+	err := ctx.RegisterResource("test:resource:type", name+"-child", &testResource2Inputs{Foo: String(foo)}, &child,
+		Parent(component))
+	if err != nil {
+		return nil, err
+	}
+
+	component.Foo, component.Child = child.Foo, &child
+	if err = ctx.RegisterComponentOutputs(component); err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+func TestComponentOutputs(t *testing.T) {
+	var lock sync.Mutex
+	inputs := map[string]resource.PropertyMap{}
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, state resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+
+			lock.Lock()
+			defer lock.Unlock()
+			inputs[name] = state
+
+			// Echo the inputs of custom resources back as their outputs.
+			if typeToken == "test:component:typed" {
+				return "", resource.PropertyMap{}, nil
+			}
+			return name, state, nil
+		},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		component, err := newTestTypedComponent(ctx, "comp", "bar")
+		assert.NoError(t, err)
+
+		outs, err := componentOutputs(component)
+		assert.NoError(t, err)
+		assert.Equal(t, Map{"foo": component.Child.Foo}, outs)
+
+		foo, known, err := await(component.Foo)
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "bar", foo)
+
+		// The component's typed outputs may be consumed like those of any other resource.
+		var consumer testResource2
+		err = ctx.RegisterResource("test:resource:type", "consumer", &testResource2Inputs{Foo: component.Foo},
+			&consumer, DependsOn([]Resource{component}))
+		assert.NoError(t, err)
+
+		foo, known, err = await(consumer.Foo)
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "bar", foo)
+
+		_, err = componentOutputs(testTypedComponent{})
+		assert.Error(t, err)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "bar", inputs["consumer"]["foo"].StringValue())
+}
+
+func TestSecretComponentOutputs(t *testing.T) {
+	var monitor *testOutputsMonitor
+	var componentURN URN
+	err := RunErr(func(ctx *Context) error {
+		monitor = recordOutputs(ctx)
+
+		component := &testTypedComponent{}
+		err := ctx.RegisterComponentResource("test:component:typed", "comp", component)
+		assert.NoError(t, err)
+		componentURN, _, err = component.URN().awaitURN(ctx.ctx)
+		assert.NoError(t, err)
+
+		component.Foo = ToSecret(String("shh")).(StringOutput)
+		return ctx.RegisterComponentOutputs(component)
+	}, WithMocks("project", "stack", &testMonitor{}))
+	assert.NoError(t, err)
+
+	// Secret component outputs are registered as secrets, so that they are encrypted in the checkpoint.
+	assertSecretOutput(t, monitor.outputs[string(componentURN)], "foo")
+}
+
+type testLogEngine struct {
+	mockEngine
+
+	lock sync.Mutex
+	logs []*pulumirpc.LogRequest
+}
+
+func (e *testLogEngine) Log(ctx context.Context, in *pulumirpc.LogRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.logs = append(e.logs, in)
+	return &empty.Empty{}, nil
+}
+
+func TestLog(t *testing.T) {
+	engine := &testLogEngine{}
+
+	var resURN URN
+	err := RunErr(func(ctx *Context) error {
+		ctx.Log = &logState{engine: engine, ctx: ctx.ctx}
+
+		var res testResource2
+		err := ctx.RegisterResource("test:resource:type", "resA", &testResource2Inputs{}, &res)
+		assert.NoError(t, err)
+		resURN, _, err = res.URN().awaitURN(ctx.ctx)
+		assert.NoError(t, err)
+
+		assert.NoError(t, ctx.Log.Debug("debug", nil))
+		assert.NoError(t, ctx.Log.Info("info", &LogArgs{Resource: &res}))
+		assert.NoError(t, ctx.Log.Warn("warn", &LogArgs{StreamID: 42}))
+		assert.NoError(t, ctx.Log.Error("error", &LogArgs{Resource: &res, StreamID: 43}))
+		return nil
+	}, WithMocks("project", "stack", &testMonitor{}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*pulumirpc.LogRequest{
+		{Severity: pulumirpc.LogSeverity_DEBUG, Message: "debug"},
+		{Severity: pulumirpc.LogSeverity_INFO, Message: "info", Urn: string(resURN)},
+		{Severity: pulumirpc.LogSeverity_WARNING, Message: "warn", StreamId: 42},
+		{Severity: pulumirpc.LogSeverity_ERROR, Message: "error", Urn: string(resURN), StreamId: 43},
+	}, engine.logs)
+}
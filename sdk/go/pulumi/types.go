@@ -38,12 +38,12 @@ type Output interface {
 
 	getState() *OutputState
 	dependencies() []Resource
-	fulfillValue(value reflect.Value, known bool, err error)
-	resolveValue(value reflect.Value, known bool)
-	fulfill(value interface{}, known bool, err error)
-	resolve(value interface{}, known bool)
+	fulfillValue(value reflect.Value, known, secret bool, err error)
+	resolveValue(value reflect.Value, known, secret bool)
+	fulfill(value interface{}, known, secret bool, err error)
+	resolve(value interface{}, known, secret bool)
 	reject(err error)
-	await(ctx context.Context) (interface{}, bool, error)
+	await(ctx context.Context) (interface{}, bool, bool, error)
 }
 
 var outputType = reflect.TypeOf((*Output)(nil)).Elem()
@@ -74,9 +74,10 @@ type OutputState struct {
 
 	state uint32 // one of output{Pending,Resolved,Rejected}
 
-	value interface{} // the value of this output if it is resolved.
-	err   error       // the error associated with this output if it is rejected.
-	known bool        // true if this output's value is known.
+	value  interface{} // the value of this output if it is resolved.
+	err    error       // the error associated with this output if it is rejected.
+	known  bool        // true if this output's value is known.
+	secret bool        // true if this output's value is secret.
 
 	element reflect.Type // the element type of this output.
 	deps    []Resource   // the dependencies associated with this output property.
@@ -96,11 +97,11 @@ func (o *OutputState) dependencies() []Resource {
 	return o.deps
 }
 
-func (o *OutputState) fulfill(value interface{}, known bool, err error) {
-	o.fulfillValue(reflect.ValueOf(value), known, err)
+func (o *OutputState) fulfill(value interface{}, known, secret bool, err error) {
+	o.fulfillValue(reflect.ValueOf(value), known, secret, err)
 }
 
-func (o *OutputState) fulfillValue(value reflect.Value, known bool, err error) {
+func (o *OutputState) fulfillValue(value reflect.Value, known, secret bool, err error) {
 	if o == nil {
 		return
 	}
@@ -121,40 +122,45 @@ func (o *OutputState) fulfillValue(value reflect.Value, known bool, err error) {
 		if value.IsValid() {
 			reflect.ValueOf(&o.value).Elem().Set(value)
 		}
-		o.state, o.known = outputResolved, known
+		o.state, o.known, o.secret = outputResolved, known, secret
 	}
 }
 
-func (o *OutputState) resolve(value interface{}, known bool) {
-	o.fulfill(value, known, nil)
+func (o *OutputState) resolve(value interface{}, known, secret bool) {
+	o.fulfill(value, known, secret, nil)
 }
 
-func (o *OutputState) resolveValue(value reflect.Value, known bool) {
-	o.fulfillValue(value, known, nil)
+func (o *OutputState) resolveValue(value reflect.Value, known, secret bool) {
+	o.fulfillValue(value, known, secret, nil)
 }
 
 func (o *OutputState) reject(err error) {
-	o.fulfill(nil, true, err)
+	o.fulfill(nil, true, false, err)
 }
 
-func (o *OutputState) await(ctx context.Context) (interface{}, bool, error) {
+// await waits for the output to resolve and returns its value, whether or not the value is known, and whether or not
+// the value is secret. If the output resolves to another output, await waits for that output in turn; the result is
+// secret if any output in the chain is secret.
+func (o *OutputState) await(ctx context.Context) (interface{}, bool, bool, error) {
+	secret := false
 	for {
 		if o == nil {
 			// If the state is nil, treat its value as resolved and unknown.
-			return nil, false, nil
+			return nil, false, secret, nil
 		}
 
 		o.mutex.Lock()
 		for o.state == outputPending {
 			if ctx.Err() != nil {
-				return nil, true, ctx.Err()
+				return nil, true, secret, ctx.Err()
 			}
 			o.cond.Wait()
 		}
 		o.mutex.Unlock()
 
+		secret = secret || o.secret
 		if !o.known || o.err != nil {
-			return nil, o.known, o.err
+			return nil, o.known, secret, o.err
 		}
 
 		// If the result is an Output, await it in turn.
@@ -163,7 +169,7 @@ func (o *OutputState) await(ctx context.Context) (interface{}, bool, error) {
 		// the element type of the outer output. We should reconsider this.
 		ov, ok := o.value.(Output)
 		if !ok {
-			return o.value, true, nil
+			return o.value, true, secret, nil
 		}
 		o = ov.getState()
 	}
@@ -220,7 +226,7 @@ func NewOutput() (Output, func(interface{}), func(error)) {
 	out := newOutputState(anyType)
 
 	resolve := func(v interface{}) {
-		out.resolve(v, true)
+		out.resolve(v, true, false)
 	}
 	reject := func(err error) {
 		out.reject(err)
@@ -375,9 +381,9 @@ func (o *OutputState) ApplyTWithContext(ctx context.Context, applier interface{}
 
 	result := newOutput(resultType, o.dependencies()...)
 	go func() {
-		v, known, err := o.await(ctx)
+		v, known, secret, err := o.await(ctx)
 		if err != nil || !known {
-			result.fulfill(nil, known, err)
+			result.fulfill(nil, known, secret, err)
 			return
 		}
 
@@ -388,8 +394,8 @@ func (o *OutputState) ApplyTWithContext(ctx context.Context, applier interface{}
 			return
 		}
 
-		// Fulfill the result.
-		result.fulfillValue(results[0], true, nil)
+		// Fulfill the result. The result of applying a function to a secret value is also secret.
+		result.fulfillValue(results[0], true, secret, nil)
 	}()
 	return result
 }
@@ -489,11 +495,11 @@ func callToOutputMethod(ctx context.Context, input reflect.Value, resolvedType r
 	return toOutputMethod.Call([]reflect.Value{reflect.ValueOf(ctx)})[0].Interface().(Output), true
 }
 
-func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
+func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, bool, error) {
 	contract.Assert(v.IsValid())
 
 	if !resolved.CanSet() {
-		return true, nil
+		return true, false, nil
 	}
 
 	// If the value is an Input with of a different element type, turn it into an Output of the appropriate type and
@@ -503,7 +509,7 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		input, isNonNil := v.Interface().(Input)
 		if !isNonNil {
 			// A nil input is already fully-resolved.
-			return true, nil
+			return true, false, nil
 		}
 
 		valueType = input.ElementType()
@@ -529,22 +535,22 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 
 		// If the input is an Output, await its value. The returned value is fully resolved.
 		if output, ok := input.(Output); ok {
-			e, known, err := output.await(ctx)
+			e, known, secret, err := output.await(ctx)
 			if err != nil || !known {
-				return known, err
+				return known, secret, err
 			}
 			if !assignInput {
 				resolved.Set(reflect.ValueOf(e))
 			} else {
 				resolved.Set(reflect.ValueOf(input))
 			}
-			return true, nil
+			return true, secret, nil
 		}
 
 		// Check for types that are already fully-resolved.
 		if v, ok := getResolvedValue(input); ok {
 			resolved.Set(v)
-			return true, nil
+			return true, false, nil
 		}
 
 		v, isInput = reflect.ValueOf(input), true
@@ -572,7 +578,7 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		resolved = reflect.New(valueType).Elem()
 	}
 
-	known, err := true, error(nil)
+	known, secret, err := true, false, error(nil)
 	switch v.Kind() {
 	case reflect.Interface:
 		if !v.IsNil() {
@@ -589,8 +595,8 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		numFields := typ.NumField()
 		for i := 0; i < numFields; i++ {
 			_, field := getMappedField(resolved, i)
-			fknown, ferr := awaitInputs(ctx, v.Field(i), field)
-			known = known && fknown
+			fknown, fsecret, ferr := awaitInputs(ctx, v.Field(i), field)
+			known, secret = known && fknown, secret || fsecret
 			if err == nil {
 				err = ferr
 			}
@@ -598,8 +604,8 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 	case reflect.Array:
 		l := v.Len()
 		for i := 0; i < l; i++ {
-			eknown, eerr := awaitInputs(ctx, v.Index(i), resolved.Index(i))
-			known = known && eknown
+			eknown, esecret, eerr := awaitInputs(ctx, v.Index(i), resolved.Index(i))
+			known, secret = known && eknown, secret || esecret
 			if err == nil {
 				err = eerr
 			}
@@ -608,8 +614,8 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		l := v.Len()
 		resolved.Set(reflect.MakeSlice(resolved.Type(), l, l))
 		for i := 0; i < l; i++ {
-			eknown, eerr := awaitInputs(ctx, v.Index(i), resolved.Index(i))
-			known = known && eknown
+			eknown, esecret, eerr := awaitInputs(ctx, v.Index(i), resolved.Index(i))
+			known, secret = known && eknown, secret || esecret
 			if err == nil {
 				err = eerr
 			}
@@ -620,13 +626,13 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		iter := v.MapRange()
 		for iter.Next() {
 			kv := reflect.New(resolvedKeyType).Elem()
-			kknown, kerr := awaitInputs(ctx, iter.Key(), kv)
+			kknown, ksecret, kerr := awaitInputs(ctx, iter.Key(), kv)
 			if err == nil {
 				err = kerr
 			}
 
 			vv := reflect.New(resolvedValueType).Elem()
-			vknown, verr := awaitInputs(ctx, iter.Value(), vv)
+			vknown, vsecret, verr := awaitInputs(ctx, iter.Value(), vv)
 			if err == nil {
 				err = verr
 			}
@@ -635,7 +641,7 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 				resolved.SetMapIndex(kv, vv)
 			}
 
-			known = known && kknown && vknown
+			known, secret = known && kknown && vknown, secret || ksecret || vsecret
 		}
 	default:
 		if isInput {
@@ -643,7 +649,7 @@ func awaitInputs(ctx context.Context, v, resolved reflect.Value) (bool, error) {
 		}
 		resolved.Set(v)
 	}
-	return known, err
+	return known, secret, err
 }
 
 // ToOutput returns an Output that will resolve when all Inputs contained in the given value have resolved.
@@ -668,13 +674,48 @@ func ToOutputWithContext(ctx context.Context, v interface{}) Output {
 	go func() {
 		element := reflect.New(resolvedType).Elem()
 
-		known, err := awaitInputs(ctx, reflect.ValueOf(v), element)
+		known, secret, err := awaitInputs(ctx, reflect.ValueOf(v), element)
 		if err != nil || !known {
-			result.fulfill(nil, known, err)
+			result.fulfill(nil, known, secret, err)
 			return
 		}
 
-		result.resolveValue(element, true)
+		result.resolveValue(element, true, secret)
+	}()
+	return result
+}
+
+// ToSecret returns an Output that resolves to the same value as the given input, but is marked as secret. Secret
+// values are encrypted in the stack's checkpoint and masked in the CLI's output. The result has the same Output type
+// as the result of calling ToOutput on the input.
+func ToSecret(input interface{}) Output {
+	return ToSecretWithContext(context.Background(), input)
+}
+
+// ToSecretWithContext returns an Output that resolves to the same value as the given input, but is marked as secret.
+// The provided context can be used to reject the output as canceled.
+func ToSecretWithContext(ctx context.Context, input interface{}) Output {
+	return withSecretness(ctx, ToOutputWithContext(ctx, input), true)
+}
+
+// Unsecret returns an Output that resolves to the same value as the given output, but is not marked as secret.
+func Unsecret(input Output) Output {
+	return UnsecretWithContext(context.Background(), input)
+}
+
+// UnsecretWithContext returns an Output that resolves to the same value as the given output, but is not marked as
+// secret. The provided context can be used to reject the output as canceled.
+func UnsecretWithContext(ctx context.Context, input Output) Output {
+	return withSecretness(ctx, input, false)
+}
+
+// withSecretness returns an Output of the same type as the given output that resolves to the same value with the given
+// secretness.
+func withSecretness(ctx context.Context, output Output, secret bool) Output {
+	result := newOutput(reflect.TypeOf(output), output.dependencies()...)
+	go func() {
+		v, known, _, err := output.await(ctx)
+		result.fulfill(v, known, secret, err)
 	}()
 	return result
 }
@@ -754,8 +795,8 @@ func AnyWithContext(ctx context.Context, v interface{}) AnyOutput {
 	out := newOutput(anyOutputType, gatherDependencies(v)...)
 	go func() {
 		var result interface{}
-		known, err := awaitInputs(ctx, reflect.ValueOf(v), reflect.ValueOf(&result).Elem())
-		out.fulfill(result, known, err)
+		known, secret, err := awaitInputs(ctx, reflect.ValueOf(v), reflect.ValueOf(&result).Elem())
+		out.fulfill(result, known, secret, err)
 	}()
 	return out.(AnyOutput)
 }
@@ -783,7 +824,7 @@ func (o IDOutput) ToStringPtrOutputWithContext(ctx context.Context) StringPtrOut
 }
 
 func (o IDOutput) awaitID(ctx context.Context) (ID, bool, error) {
-	id, known, err := o.await(ctx)
+	id, known, _, err := o.await(ctx)
 	if !known || err != nil {
 		return "", known, err
 	}
@@ -807,7 +848,7 @@ func (o URNOutput) ToStringPtrOutputWithContext(ctx context.Context) StringPtrOu
 }
 
 func (o URNOutput) awaitURN(ctx context.Context) (URN, bool, error) {
-	id, known, err := o.await(ctx)
+	id, known, _, err := o.await(ctx)
 	if !known || err != nil {
 		return "", known, err
 	}
@@ -30,7 +30,7 @@ func TestOutputApply(t *testing.T) {
 	// Test that resolved outputs lead to applies being run.
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 		var ranApp bool
 		app := out.ApplyT(func(v int) (interface{}, error) {
 			ranApp = true
@@ -45,7 +45,7 @@ func TestOutputApply(t *testing.T) {
 	// Test that resolved, but unknown outputs, skip the running of applies.
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, false) }()
+		go func() { out.resolve(42, false, false) }()
 		var ranApp bool
 		app := out.ApplyT(func(v int) (interface{}, error) {
 			ranApp = true
@@ -73,7 +73,7 @@ func TestOutputApply(t *testing.T) {
 	// Test that an an apply that returns an output returns the resolution of that output, not the output itself.
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 		var ranApp bool
 		app := out.ApplyT(func(v int) (interface{}, error) {
 			other, resolveOther, _ := NewOutput()
@@ -102,7 +102,7 @@ func TestOutputApply(t *testing.T) {
 	// Test that an an apply that reject an output returns the rejection of that output, not the output itself.
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 		var ranApp bool
 		app := out.ApplyT(func(v int) (interface{}, error) {
 			other, _, rejectOther := NewOutput()
@@ -129,7 +129,7 @@ func TestOutputApply(t *testing.T) {
 	// Test builtin applies.
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 
 		t.Run("ApplyArchive", func(t *testing.T) {
 			o2 := out.ApplyArchive(func(v int) Archive { return *new(Archive) })
@@ -1035,7 +1035,7 @@ func TestOutputApply(t *testing.T) {
 	// Test that applies return appropriate concrete implementations of Output based on the callback type
 	{
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 
 		t.Run("ApplyT::ArchiveOutput", func(t *testing.T) {
 			_, ok := out.ApplyT(func(v int) Archive { return *new(Archive) }).(ArchiveOutput)
@@ -1421,10 +1421,10 @@ func TestOutputApply(t *testing.T) {
 		}
 
 		out := newIntOutput()
-		go func() { out.resolve(42, true) }()
+		go func() { out.resolve(42, true, false) }()
 
 		out2 := StringOutput{newOutputState(reflect.TypeOf(""))}
-		go func() { out2.resolve("hello", true) }()
+		go func() { out2.resolve("hello", true, false) }()
 
 		res := out.
 			ApplyT(func(v int) myStructType {
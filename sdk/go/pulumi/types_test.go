@@ -25,7 +25,8 @@ import (
 )
 
 func await(out Output) (interface{}, bool, error) {
-	return out.await(context.Background())
+	v, known, _, err := out.await(context.Background())
+	return v, known, err
 }
 
 func assertApplied(t *testing.T, out Output) {
@@ -65,7 +66,7 @@ func TestBasicOutputs(t *testing.T) {
 func TestArrayOutputs(t *testing.T) {
 	out := ArrayOutput{newOutputState(reflect.TypeOf([]interface{}{}))}
 	go func() {
-		out.resolve([]interface{}{nil, 0, "x"}, true)
+		out.resolve([]interface{}{nil, 0, "x"}, true, false)
 	}()
 	{
 		assertApplied(t, out.ApplyT(func(arr []interface{}) (interface{}, error) {
@@ -83,7 +84,7 @@ func TestArrayOutputs(t *testing.T) {
 func TestBoolOutputs(t *testing.T) {
 	out := BoolOutput{newOutputState(reflect.TypeOf(false))}
 	go func() {
-		out.resolve(true, true)
+		out.resolve(true, true, false)
 	}()
 	{
 		assertApplied(t, out.ApplyT(func(v bool) (interface{}, error) {
@@ -100,7 +101,7 @@ func TestMapOutputs(t *testing.T) {
 			"x": 1,
 			"y": false,
 			"z": "abc",
-		}, true)
+		}, true, false)
 	}()
 	{
 		assertApplied(t, out.ApplyT(func(v map[string]interface{}) (interface{}, error) {
@@ -116,7 +117,7 @@ func TestMapOutputs(t *testing.T) {
 func TestNumberOutputs(t *testing.T) {
 	out := Float64Output{newOutputState(reflect.TypeOf(float64(0)))}
 	go func() {
-		out.resolve(42.345, true)
+		out.resolve(42.345, true, false)
 	}()
 	{
 		assertApplied(t, out.ApplyT(func(v float64) (interface{}, error) {
@@ -129,7 +130,7 @@ func TestNumberOutputs(t *testing.T) {
 func TestStringOutputs(t *testing.T) {
 	out := StringOutput{newOutputState(reflect.TypeOf(""))}
 	go func() {
-		out.resolve("a stringy output", true)
+		out.resolve("a stringy output", true, false)
 	}()
 	{
 		assertApplied(t, out.ApplyT(func(v string) (interface{}, error) {
@@ -301,3 +302,40 @@ func TestToOutputInputAny(t *testing.T) {
 		A: map[string]interface{}{"world": true},
 	}, v)
 }
+
+// Test that secretness is propagated by ToOutput and Apply, and removed by Unsecret.
+func TestSecrets(t *testing.T) {
+	s := ToSecret(String("foo"))
+	_, ok := s.(StringOutput)
+	assert.True(t, ok)
+
+	v, known, secret, err := s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "foo", v)
+
+	applied := s.ApplyT(func(v string) int { return len(v) })
+	v, known, secret, err = applied.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, 3, v)
+
+	all := All(String("bar"), s)
+	v, known, secret, err = all.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, []interface{}{"bar", "foo"}, v)
+
+	v, known, secret, err = Unsecret(all).await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.Equal(t, []interface{}{"bar", "foo"}, v)
+
+	_, _, secret, err = ToOutput(String("baz")).await(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, secret)
+}
@@ -1207,6 +1207,82 @@ func (m *ErrorResourceInitFailed) GetInputs() *_struct.Struct {
 	return nil
 }
 
+type GetSchemaRequest struct {
+	Version              int32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()    {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_857e379df15f5bcf, []int{19}
+}
+func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaRequest.Unmarshal(m, b)
+}
+func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaRequest.Merge(dst, src)
+}
+func (m *GetSchemaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaRequest.Size(m)
+}
+func (m *GetSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
+
+func (m *GetSchemaRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetSchemaResponse struct {
+	Schema               string   `protobuf:"bytes,1,opt,name=schema" json:"schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchemaResponse) Reset()         { *m = GetSchemaResponse{} }
+func (m *GetSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaResponse) ProtoMessage()    {}
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_857e379df15f5bcf, []int{20}
+}
+func (m *GetSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaResponse.Unmarshal(m, b)
+}
+func (m *GetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaResponse.Merge(dst, src)
+}
+func (m *GetSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaResponse.Size(m)
+}
+func (m *GetSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaResponse proto.InternalMessageInfo
+
+func (m *GetSchemaResponse) GetSchema() string {
+	if m != nil {
+		return m.Schema
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ConfigureRequest)(nil), "pulumirpc.ConfigureRequest")
 	proto.RegisterMapType((map[string]string)(nil), "pulumirpc.ConfigureRequest.VariablesEntry")
@@ -1230,6 +1306,8 @@ func init() {
 	proto.RegisterType((*UpdateResponse)(nil), "pulumirpc.UpdateResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "pulumirpc.DeleteRequest")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
+	proto.RegisterType((*GetSchemaRequest)(nil), "pulumirpc.GetSchemaRequest")
+	proto.RegisterType((*GetSchemaResponse)(nil), "pulumirpc.GetSchemaResponse")
 	proto.RegisterEnum("pulumirpc.PropertyDiff_Kind", PropertyDiff_Kind_name, PropertyDiff_Kind_value)
 	proto.RegisterEnum("pulumirpc.DiffResponse_DiffChanges", DiffResponse_DiffChanges_name, DiffResponse_DiffChanges_value)
 }
@@ -1278,6 +1356,8 @@ type ResourceProviderClient interface {
 	Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	// GetSchema fetches the schema for this resource provider.
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
 }
 
 type resourceProviderClient struct {
@@ -1428,6 +1508,15 @@ func (c *resourceProviderClient) GetPluginInfo(ctx context.Context, in *empty.Em
 	return out, nil
 }
 
+func (c *resourceProviderClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
+	out := new(GetSchemaResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceProvider/GetSchema", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ResourceProvider service
 
 type ResourceProviderServer interface {
@@ -1464,6 +1553,8 @@ type ResourceProviderServer interface {
 	Cancel(context.Context, *empty.Empty) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
+	// GetSchema fetches the schema for this resource provider.
+	GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
 }
 
 func RegisterResourceProviderServer(s *grpc.Server, srv ResourceProviderServer) {
@@ -1707,6 +1798,24 @@ func _ResourceProvider_GetPluginInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceProvider_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.ResourceProvider",
 	HandlerType: (*ResourceProviderServer)(nil),
@@ -1759,6 +1868,10 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPluginInfo",
 			Handler:    _ResourceProvider_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _ResourceProvider_GetSchema_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1773,82 +1886,85 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_857e379df15f5bcf) }
 
 var fileDescriptor_provider_857e379df15f5bcf = []byte{
-	// 1221 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x57, 0xcd, 0x72, 0x1b, 0xc5,
-	0x13, 0xd7, 0x6a, 0x65, 0xd9, 0x6a, 0x7d, 0x44, 0x99, 0xff, 0x9f, 0x58, 0x51, 0x7c, 0x50, 0x2d,
-	0x1c, 0x04, 0x14, 0xb2, 0xcb, 0x39, 0x40, 0x52, 0x4e, 0x05, 0xdb, 0x92, 0x83, 0xcb, 0xf1, 0x07,
-	0xeb, 0x98, 0x8f, 0x53, 0x58, 0x6b, 0x47, 0xf2, 0x96, 0x56, 0xbb, 0xcb, 0xec, 0xac, 0x28, 0x73,
-	0xe6, 0xc0, 0x2b, 0xf0, 0x10, 0x14, 0x55, 0x3c, 0x01, 0x77, 0x9e, 0x21, 0x8f, 0xc0, 0x3b, 0x50,
-	0xf3, 0xb1, 0xab, 0x19, 0x4b, 0x76, 0x64, 0x93, 0x82, 0xdb, 0xf4, 0x74, 0xcf, 0x74, 0xf7, 0x6f,
-	0xba, 0x7f, 0x33, 0x03, 0xb5, 0x88, 0x84, 0x13, 0xcf, 0xc5, 0xa4, 0x13, 0x91, 0x90, 0x86, 0xa8,
-	0x14, 0x25, 0x7e, 0x32, 0xf6, 0x48, 0xd4, 0x6f, 0x56, 0x22, 0x3f, 0x19, 0x7a, 0x81, 0x50, 0x34,
-	0x1f, 0x0d, 0xc3, 0x70, 0xe8, 0xe3, 0x75, 0x2e, 0x9d, 0x27, 0x83, 0x75, 0x3c, 0x8e, 0xe8, 0xa5,
-	0x54, 0xae, 0x5d, 0x55, 0xc6, 0x94, 0x24, 0x7d, 0x2a, 0xb4, 0xd6, 0x5f, 0x06, 0xd4, 0x77, 0xc3,
-	0x60, 0xe0, 0x0d, 0x13, 0x82, 0x6d, 0xfc, 0x7d, 0x82, 0x63, 0x8a, 0xbe, 0x80, 0xd2, 0xc4, 0x21,
-	0x9e, 0x73, 0xee, 0xe3, 0xb8, 0x61, 0xb4, 0xcc, 0x76, 0x79, 0xf3, 0xa3, 0x4e, 0xe6, 0xbc, 0x73,
-	0xd5, 0xbe, 0xf3, 0x55, 0x6a, 0xdc, 0x0b, 0x28, 0xb9, 0xb4, 0xa7, 0x8b, 0xd1, 0xc7, 0x50, 0x70,
-	0xc8, 0x30, 0x6e, 0xe4, 0x5b, 0x46, 0xbb, 0xbc, 0xb9, 0xda, 0x11, 0xb1, 0x74, 0xd2, 0x58, 0x3a,
-	0xa7, 0x3c, 0x16, 0x9b, 0x1b, 0xa1, 0x0f, 0xa0, 0xea, 0xf4, 0xfb, 0x38, 0xa2, 0xa7, 0xb8, 0x4f,
-	0x30, 0x8d, 0x1b, 0x66, 0xcb, 0x68, 0xaf, 0xd8, 0xfa, 0x64, 0x73, 0x0b, 0x6a, 0xba, 0x3f, 0x54,
-	0x07, 0x73, 0x84, 0x2f, 0x1b, 0x46, 0xcb, 0x68, 0x97, 0x6c, 0x36, 0x44, 0xff, 0x87, 0xa5, 0x89,
-	0xe3, 0x27, 0x98, 0xfb, 0x2d, 0xd9, 0x42, 0x78, 0x9a, 0xff, 0xcc, 0xb0, 0x9e, 0xc0, 0x7d, 0x25,
-	0xfc, 0x38, 0x0a, 0x83, 0x18, 0xcf, 0x3a, 0x36, 0xe6, 0x38, 0xb6, 0x7e, 0x37, 0xe0, 0x61, 0xb6,
-	0xb6, 0x47, 0x48, 0x48, 0x0e, 0xbd, 0x38, 0xf6, 0x82, 0xe1, 0x01, 0xbe, 0x8c, 0xd1, 0x97, 0x50,
-	0x1e, 0x4f, 0x45, 0x89, 0xda, 0xfa, 0x3c, 0xd4, 0xae, 0x2e, 0xed, 0x4c, 0xc7, 0xb6, 0xba, 0x47,
-	0x73, 0x07, 0x60, 0xaa, 0x42, 0x08, 0x0a, 0x81, 0x33, 0xc6, 0x32, 0x4d, 0x3e, 0x46, 0x2d, 0x28,
-	0xbb, 0x38, 0xee, 0x13, 0x2f, 0xa2, 0x5e, 0x18, 0xc8, 0x6c, 0xd5, 0x29, 0xeb, 0x27, 0x03, 0xaa,
-	0xfb, 0xc1, 0x24, 0x1c, 0x65, 0x87, 0x5b, 0x07, 0x93, 0x86, 0xa3, 0x14, 0x2d, 0x1a, 0x8e, 0x6e,
-	0x77, 0x48, 0x4d, 0x58, 0x49, 0xcb, 0x92, 0x9f, 0x4f, 0xc9, 0xce, 0x64, 0xd4, 0x80, 0xe5, 0x09,
-	0x26, 0x31, 0x0b, 0xa5, 0xc0, 0x55, 0xa9, 0x68, 0x4d, 0xa0, 0x96, 0x46, 0x21, 0x31, 0x5f, 0x87,
-	0x22, 0xc1, 0x34, 0x21, 0x01, 0x8f, 0xe4, 0x06, 0xb7, 0xd2, 0x0c, 0x3d, 0x86, 0x95, 0x81, 0xe3,
-	0xf9, 0x09, 0xc1, 0x2c, 0x52, 0x93, 0x2f, 0x51, 0xd0, 0xbd, 0xc0, 0xfd, 0xd1, 0x9e, 0xd0, 0xdb,
-	0x99, 0xa1, 0xf5, 0x23, 0x54, 0xb8, 0x46, 0x49, 0x3e, 0x75, 0x59, 0xb2, 0xd9, 0x90, 0x25, 0x1f,
-	0xfa, 0xee, 0xdb, 0x93, 0x67, 0x46, 0xcc, 0x38, 0xc0, 0x3f, 0x88, 0xc2, 0xbc, 0xc9, 0x98, 0x19,
-	0x59, 0x09, 0x54, 0xa5, 0xef, 0x69, 0xca, 0x5e, 0x10, 0x25, 0xb2, 0xbe, 0x6e, 0x4a, 0x59, 0x98,
-	0xdd, 0x2d, 0xe5, 0x1d, 0x99, 0xb2, 0xd4, 0xc8, 0x03, 0x8b, 0x30, 0xa1, 0x69, 0x8b, 0x64, 0x32,
-	0x7a, 0xc0, 0x0e, 0xc1, 0x89, 0xb3, 0xd2, 0x91, 0x92, 0xf5, 0x9b, 0x01, 0xe5, 0xae, 0x37, 0x18,
-	0xa4, 0xb0, 0xd5, 0x20, 0xef, 0xb9, 0x72, 0x75, 0xde, 0x73, 0x53, 0x18, 0xf3, 0xb3, 0x30, 0x9a,
-	0xb7, 0x81, 0xb1, 0xb0, 0x00, 0x8c, 0xac, 0x39, 0xbd, 0x61, 0x10, 0x12, 0xbc, 0x7b, 0xe1, 0x04,
-	0x43, 0x1c, 0x37, 0x96, 0x5a, 0x66, 0xbb, 0x64, 0xeb, 0x93, 0xd6, 0x1f, 0x06, 0x54, 0x4e, 0x64,
-	0x5a, 0x2c, 0x72, 0xb4, 0x01, 0x85, 0x91, 0x17, 0x88, 0xa0, 0x6b, 0x9b, 0x6b, 0x0a, 0x6e, 0xaa,
-	0x59, 0xe7, 0xc0, 0x0b, 0x5c, 0x9b, 0x5b, 0xa2, 0x35, 0x28, 0x71, 0xdc, 0xd9, 0x3c, 0x4f, 0x6d,
-	0xc5, 0x9e, 0x4e, 0x58, 0xdf, 0x41, 0x81, 0xd9, 0xa2, 0x65, 0x30, 0xb7, 0xbb, 0xdd, 0x7a, 0x0e,
-	0xdd, 0x83, 0xf2, 0x76, 0xb7, 0xfb, 0xda, 0xee, 0x9d, 0xbc, 0xdc, 0xde, 0xed, 0xd5, 0x0d, 0x04,
-	0x50, 0xec, 0xf6, 0x5e, 0xf6, 0x5e, 0xf5, 0xea, 0x79, 0x84, 0xa0, 0x26, 0xc6, 0x99, 0xde, 0x64,
-	0xfa, 0xb3, 0x93, 0xee, 0xf6, 0xab, 0x5e, 0xbd, 0xc0, 0xf4, 0x62, 0x9c, 0xe9, 0x97, 0xac, 0x37,
-	0x26, 0x54, 0x04, 0xe8, 0xb2, 0x5e, 0x9a, 0xb0, 0x42, 0x70, 0xe4, 0x3b, 0x7d, 0xc9, 0xc2, 0x25,
-	0x3b, 0x93, 0x59, 0xab, 0xc5, 0x54, 0x10, 0x74, 0x9e, 0xab, 0x52, 0x11, 0x6d, 0xc0, 0xff, 0x5c,
-	0xec, 0x63, 0x8a, 0x77, 0xf0, 0x20, 0x64, 0x24, 0xc7, 0x57, 0x48, 0x2e, 0x9d, 0xa7, 0x42, 0xcf,
-	0x60, 0xb9, 0x2f, 0xb1, 0x2d, 0x70, 0xb4, 0xde, 0x57, 0xd0, 0x52, 0x23, 0xe2, 0x82, 0x44, 0xdc,
-	0x4e, 0xd7, 0x30, 0xb2, 0x75, 0xbd, 0xc1, 0x20, 0x3d, 0x18, 0x21, 0xa0, 0x43, 0xa8, 0xb8, 0x98,
-	0x3a, 0x9e, 0x8f, 0x5d, 0x0e, 0x68, 0x91, 0xd7, 0xef, 0x87, 0xd7, 0xee, 0xac, 0xd8, 0x8a, 0x5b,
-	0x44, 0x5b, 0x8e, 0xda, 0x70, 0xef, 0xc2, 0x89, 0x55, 0xab, 0xc6, 0x32, 0xcf, 0xe8, 0xea, 0x74,
-	0xf3, 0x1b, 0xb8, 0x3f, 0xb3, 0xd9, 0x9c, 0x2b, 0xe2, 0x13, 0xf5, 0x8a, 0xd0, 0x1b, 0x4b, 0x2d,
-	0x10, 0xf5, 0xee, 0x78, 0x26, 0x9a, 0x42, 0x02, 0x80, 0xea, 0x50, 0xe9, 0xee, 0xef, 0xed, 0xbd,
-	0x3e, 0x3b, 0x3a, 0x38, 0x3a, 0xfe, 0xfa, 0xa8, 0x9e, 0x43, 0x55, 0x28, 0xf1, 0x99, 0xa3, 0xe3,
-	0x23, 0x56, 0x10, 0xa9, 0x78, 0x7a, 0x7c, 0xd8, 0xab, 0xe7, 0x2d, 0x0a, 0xd5, 0x5d, 0x82, 0x1d,
-	0x8a, 0xaf, 0x27, 0xa3, 0x4f, 0x01, 0x64, 0x6f, 0x7a, 0xf8, 0xad, 0x94, 0xa4, 0x98, 0xb2, 0x72,
-	0xa0, 0xde, 0x18, 0x87, 0x09, 0xe5, 0x07, 0x6d, 0xd8, 0xa9, 0x68, 0x7d, 0x0b, 0xb5, 0xd4, 0xab,
-	0x2c, 0xab, 0xab, 0xcd, 0x7c, 0x57, 0xa7, 0xd6, 0x2f, 0x06, 0x94, 0x6d, 0xec, 0xb8, 0x8b, 0xb3,
-	0x84, 0xee, 0xca, 0x5c, 0x3c, 0xbf, 0x29, 0x75, 0x16, 0x16, 0xa2, 0x4e, 0xeb, 0x67, 0x03, 0x2a,
-	0x22, 0xb6, 0x77, 0x9c, 0xb5, 0x12, 0x8a, 0xb9, 0x58, 0x28, 0x7f, 0x1a, 0x50, 0x3d, 0x8b, 0x5c,
-	0xe5, 0xe0, 0xff, 0x4b, 0x3a, 0x55, 0x2a, 0x65, 0x49, 0xab, 0x94, 0x59, 0xa2, 0x2d, 0xce, 0x23,
-	0xda, 0x7d, 0xa8, 0xa5, 0xc9, 0x48, 0x64, 0x75, 0x24, 0x8d, 0xc5, 0xeb, 0x87, 0xbd, 0x4d, 0xba,
-	0x9c, 0x8f, 0xfe, 0x85, 0x0a, 0x52, 0xf2, 0x2e, 0xe8, 0x1d, 0xf2, 0xab, 0x01, 0xab, 0xfc, 0x4d,
-	0x66, 0xe3, 0x38, 0x4c, 0x48, 0x1f, 0xef, 0x07, 0x1e, 0xdd, 0xe3, 0x04, 0xf2, 0xee, 0xaa, 0xa6,
-	0x01, 0xcb, 0xe2, 0x6e, 0x65, 0x41, 0x73, 0xbe, 0x96, 0xe2, 0xad, 0x4b, 0x7b, 0xf3, 0x4d, 0x11,
-	0xea, 0x69, 0xa8, 0x27, 0xe9, 0xd3, 0x6b, 0x07, 0xca, 0xfc, 0xd6, 0x17, 0xaf, 0x4c, 0x34, 0xf3,
-	0x4e, 0x90, 0x08, 0x37, 0x1b, 0xb3, 0x0a, 0x71, 0x8c, 0x56, 0x0e, 0x3d, 0x07, 0xe0, 0xfc, 0x26,
-	0xb6, 0x78, 0x30, 0x43, 0xd5, 0x62, 0x87, 0xd5, 0x6b, 0x28, 0xdc, 0xca, 0xb1, 0x7f, 0x43, 0xf6,
-	0xca, 0x45, 0x8f, 0x6e, 0xf8, 0x31, 0x34, 0xd7, 0xe6, 0x2b, 0x95, 0x50, 0x8a, 0xe2, 0xbd, 0x88,
-	0xd4, 0x80, 0xb5, 0x87, 0x6c, 0xf3, 0xe1, 0x1c, 0x4d, 0xb6, 0xc1, 0x0b, 0xa8, 0x9c, 0x52, 0x82,
-	0x9d, 0xf1, 0x3f, 0xda, 0x66, 0xc3, 0x40, 0x5b, 0xb0, 0xc4, 0x71, 0xba, 0x1b, 0xa4, 0x4f, 0xa0,
-	0xc0, 0xaf, 0xaf, 0x3b, 0x80, 0xf9, 0x1c, 0x8a, 0x82, 0xb8, 0xb5, 0xd8, 0xb5, 0x1b, 0x44, 0x8b,
-	0x5d, 0x67, 0x79, 0xe1, 0x9b, 0x31, 0xa0, 0xe6, 0x5b, 0xa1, 0x6b, 0xcd, 0xb7, 0x4a, 0x95, 0xc2,
-	0xb7, 0x68, 0x72, 0xcd, 0xb7, 0x46, 0x62, 0x9a, 0x6f, 0x9d, 0x11, 0xac, 0x1c, 0xda, 0x82, 0xa2,
-	0xe8, 0x6c, 0x6d, 0x03, 0xad, 0xd9, 0x9b, 0x0f, 0x66, 0x0a, 0xbd, 0xc7, 0xbe, 0xad, 0x56, 0x0e,
-	0x3d, 0x85, 0xe2, 0xae, 0x13, 0xf4, 0xb1, 0x8f, 0xae, 0xb1, 0xb9, 0x61, 0xed, 0xe7, 0x50, 0x7d,
-	0x81, 0xe9, 0x09, 0xff, 0x1e, 0xef, 0x07, 0x83, 0xf0, 0xda, 0x2d, 0xde, 0x53, 0x6f, 0xfc, 0xcc,
-	0xdc, 0xca, 0x9d, 0x17, 0xb9, 0xe1, 0xe3, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x7b, 0xd1, 0xe0,
-	0x18, 0x7f, 0x0f, 0x00, 0x00,
+	// 1265 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x4b, 0x72, 0xdb, 0x46,
+	0x13, 0x16, 0x08, 0x3e, 0xc4, 0xe6, 0xc3, 0xf0, 0xfc, 0x7f, 0x24, 0x0a, 0xd6, 0x82, 0x85, 0x64,
+	0xc1, 0xc4, 0x09, 0xe5, 0x92, 0x17, 0x89, 0x5d, 0x76, 0x39, 0x92, 0x48, 0x39, 0x2a, 0xdb, 0xb2,
+	0x02, 0xd9, 0x79, 0xac, 0x1c, 0x18, 0x18, 0x52, 0x28, 0x92, 0x00, 0x32, 0x18, 0x30, 0xa5, 0xac,
+	0xb3, 0xc8, 0x15, 0x72, 0x82, 0xac, 0x52, 0xa9, 0xca, 0x09, 0xb2, 0xcf, 0x19, 0x72, 0x84, 0xdc,
+	0x21, 0x35, 0x0f, 0x80, 0x03, 0x92, 0x92, 0x29, 0xc5, 0x95, 0xec, 0xd0, 0xd3, 0x3d, 0xd3, 0xdd,
+	0xdf, 0xf4, 0x7c, 0xdd, 0x80, 0x66, 0x44, 0xc2, 0xa9, 0xef, 0x61, 0xd2, 0x8d, 0x48, 0x48, 0x43,
+	0x54, 0x8d, 0x92, 0x71, 0x32, 0xf1, 0x49, 0xe4, 0x9a, 0xf5, 0x68, 0x9c, 0x0c, 0xfd, 0x40, 0x28,
+	0xcc, 0x5b, 0xc3, 0x30, 0x1c, 0x8e, 0xf1, 0x0e, 0x97, 0x5e, 0x27, 0x83, 0x1d, 0x3c, 0x89, 0xe8,
+	0xb9, 0x54, 0x6e, 0xcf, 0x2b, 0x63, 0x4a, 0x12, 0x97, 0x0a, 0xad, 0xf5, 0x97, 0x06, 0xc6, 0x41,
+	0x18, 0x0c, 0xfc, 0x61, 0x42, 0xb0, 0x8d, 0xbf, 0x4d, 0x70, 0x4c, 0xd1, 0x67, 0x50, 0x9d, 0x3a,
+	0xc4, 0x77, 0x5e, 0x8f, 0x71, 0xdc, 0xd2, 0xda, 0x7a, 0xa7, 0xb6, 0xfb, 0x41, 0x37, 0x73, 0xde,
+	0x9d, 0xb7, 0xef, 0x7e, 0x91, 0x1a, 0xf7, 0x03, 0x4a, 0xce, 0xed, 0xd9, 0x66, 0x74, 0x1b, 0x8a,
+	0x0e, 0x19, 0xc6, 0xad, 0x42, 0x5b, 0xeb, 0xd4, 0x76, 0x37, 0xbb, 0x22, 0x96, 0x6e, 0x1a, 0x4b,
+	0xf7, 0x94, 0xc7, 0x62, 0x73, 0x23, 0xf4, 0x1e, 0x34, 0x1c, 0xd7, 0xc5, 0x11, 0x3d, 0xc5, 0x2e,
+	0xc1, 0x34, 0x6e, 0xe9, 0x6d, 0xad, 0xb3, 0x6e, 0xe7, 0x17, 0xcd, 0x07, 0xd0, 0xcc, 0xfb, 0x43,
+	0x06, 0xe8, 0x23, 0x7c, 0xde, 0xd2, 0xda, 0x5a, 0xa7, 0x6a, 0xb3, 0x4f, 0xf4, 0x7f, 0x28, 0x4d,
+	0x9d, 0x71, 0x82, 0xb9, 0xdf, 0xaa, 0x2d, 0x84, 0xfb, 0x85, 0x4f, 0x34, 0xeb, 0x1e, 0xdc, 0x54,
+	0xc2, 0x8f, 0xa3, 0x30, 0x88, 0xf1, 0xa2, 0x63, 0x6d, 0x89, 0x63, 0xeb, 0x37, 0x0d, 0xb6, 0xb2,
+	0xbd, 0x7d, 0x42, 0x42, 0xf2, 0xcc, 0x8f, 0x63, 0x3f, 0x18, 0x3e, 0xc1, 0xe7, 0x31, 0xfa, 0x1c,
+	0x6a, 0x93, 0x99, 0x28, 0x51, 0xdb, 0x59, 0x86, 0xda, 0xfc, 0xd6, 0xee, 0xec, 0xdb, 0x56, 0xcf,
+	0x30, 0xf7, 0x01, 0x66, 0x2a, 0x84, 0xa0, 0x18, 0x38, 0x13, 0x2c, 0xd3, 0xe4, 0xdf, 0xa8, 0x0d,
+	0x35, 0x0f, 0xc7, 0x2e, 0xf1, 0x23, 0xea, 0x87, 0x81, 0xcc, 0x56, 0x5d, 0xb2, 0x7e, 0xd0, 0xa0,
+	0x71, 0x14, 0x4c, 0xc3, 0x51, 0x76, 0xb9, 0x06, 0xe8, 0x34, 0x1c, 0xa5, 0x68, 0xd1, 0x70, 0x74,
+	0xb5, 0x4b, 0x32, 0x61, 0x3d, 0x2d, 0x4b, 0x7e, 0x3f, 0x55, 0x3b, 0x93, 0x51, 0x0b, 0x2a, 0x53,
+	0x4c, 0x62, 0x16, 0x4a, 0x91, 0xab, 0x52, 0xd1, 0x9a, 0x42, 0x33, 0x8d, 0x42, 0x62, 0xbe, 0x03,
+	0x65, 0x82, 0x69, 0x42, 0x82, 0x96, 0x76, 0xb9, 0x5b, 0x69, 0x86, 0xee, 0xc2, 0xfa, 0xc0, 0xf1,
+	0xc7, 0x09, 0xc1, 0x2c, 0x52, 0x9d, 0x6f, 0x51, 0xd0, 0x3d, 0xc3, 0xee, 0xe8, 0x50, 0xe8, 0xed,
+	0xcc, 0xd0, 0xfa, 0x1e, 0xea, 0x5c, 0xa3, 0x24, 0x9f, 0xba, 0xac, 0xda, 0xec, 0x93, 0x25, 0x1f,
+	0x8e, 0xbd, 0x37, 0x27, 0xcf, 0x8c, 0x98, 0x71, 0x80, 0xbf, 0x13, 0x85, 0x79, 0x99, 0x31, 0x33,
+	0xb2, 0x12, 0x68, 0x48, 0xdf, 0xb3, 0x94, 0xfd, 0x20, 0x4a, 0x64, 0x7d, 0x5d, 0x96, 0xb2, 0x30,
+	0xbb, 0x5e, 0xca, 0xfb, 0x50, 0x57, 0x35, 0xf2, 0xc2, 0x22, 0x4c, 0x68, 0xfa, 0x44, 0x32, 0x19,
+	0x6d, 0xb0, 0x4b, 0x70, 0xe2, 0xac, 0x74, 0xa4, 0x64, 0xfd, 0xaa, 0x41, 0xad, 0xe7, 0x0f, 0x06,
+	0x29, 0x6c, 0x4d, 0x28, 0xf8, 0x9e, 0xdc, 0x5d, 0xf0, 0xbd, 0x14, 0xc6, 0xc2, 0x22, 0x8c, 0xfa,
+	0x55, 0x60, 0x2c, 0xae, 0x00, 0x23, 0x7b, 0x9c, 0xfe, 0x30, 0x08, 0x09, 0x3e, 0x38, 0x73, 0x82,
+	0x21, 0x8e, 0x5b, 0xa5, 0xb6, 0xde, 0xa9, 0xda, 0xf9, 0x45, 0xeb, 0x77, 0x0d, 0xea, 0x27, 0x32,
+	0x2d, 0x16, 0x39, 0xba, 0x03, 0xc5, 0x91, 0x1f, 0x88, 0xa0, 0x9b, 0xbb, 0xdb, 0x0a, 0x6e, 0xaa,
+	0x59, 0xf7, 0x89, 0x1f, 0x78, 0x36, 0xb7, 0x44, 0xdb, 0x50, 0xe5, 0xb8, 0xb3, 0x75, 0x9e, 0xda,
+	0xba, 0x3d, 0x5b, 0xb0, 0xbe, 0x81, 0x22, 0xb3, 0x45, 0x15, 0xd0, 0xf7, 0x7a, 0x3d, 0x63, 0x0d,
+	0xdd, 0x80, 0xda, 0x5e, 0xaf, 0xf7, 0xca, 0xee, 0x9f, 0x3c, 0xdd, 0x3b, 0xe8, 0x1b, 0x1a, 0x02,
+	0x28, 0xf7, 0xfa, 0x4f, 0xfb, 0x2f, 0xfa, 0x46, 0x01, 0x21, 0x68, 0x8a, 0xef, 0x4c, 0xaf, 0x33,
+	0xfd, 0xcb, 0x93, 0xde, 0xde, 0x8b, 0xbe, 0x51, 0x64, 0x7a, 0xf1, 0x9d, 0xe9, 0x4b, 0xd6, 0x9f,
+	0x3a, 0xd4, 0x05, 0xe8, 0xb2, 0x5e, 0x4c, 0x58, 0x27, 0x38, 0x1a, 0x3b, 0xae, 0x64, 0xe1, 0xaa,
+	0x9d, 0xc9, 0xec, 0xa9, 0xc5, 0x54, 0x10, 0x74, 0x81, 0xab, 0x52, 0x11, 0xdd, 0x81, 0xff, 0x79,
+	0x78, 0x8c, 0x29, 0xde, 0xc7, 0x83, 0x90, 0x91, 0x1c, 0xdf, 0x21, 0xb9, 0x74, 0x99, 0x0a, 0x3d,
+	0x84, 0x8a, 0x2b, 0xb1, 0x2d, 0x72, 0xb4, 0xde, 0x55, 0xd0, 0x52, 0x23, 0xe2, 0x82, 0x44, 0xdc,
+	0x4e, 0xf7, 0x30, 0xb2, 0xf5, 0xfc, 0xc1, 0x20, 0xbd, 0x18, 0x21, 0xa0, 0x67, 0x50, 0xf7, 0x30,
+	0x75, 0xfc, 0x31, 0xf6, 0x38, 0xa0, 0x65, 0x5e, 0xbf, 0xef, 0x5f, 0x78, 0xb2, 0x62, 0x2b, 0xba,
+	0x48, 0x6e, 0x3b, 0xea, 0xc0, 0x8d, 0x33, 0x27, 0x56, 0xad, 0x5a, 0x15, 0x9e, 0xd1, 0xfc, 0xb2,
+	0xf9, 0x15, 0xdc, 0x5c, 0x38, 0x6c, 0x49, 0x8b, 0xf8, 0x48, 0x6d, 0x11, 0xf9, 0x87, 0xa5, 0x16,
+	0x88, 0xda, 0x3b, 0x1e, 0x42, 0x4d, 0x01, 0x00, 0x19, 0x50, 0xef, 0x1d, 0x1d, 0x1e, 0xbe, 0x7a,
+	0x79, 0xfc, 0xe4, 0xf8, 0xf9, 0x97, 0xc7, 0xc6, 0x1a, 0x6a, 0x40, 0x95, 0xaf, 0x1c, 0x3f, 0x3f,
+	0x66, 0x05, 0x91, 0x8a, 0xa7, 0xcf, 0x9f, 0xf5, 0x8d, 0x82, 0x45, 0xa1, 0x71, 0x40, 0xb0, 0x43,
+	0xf1, 0xc5, 0x64, 0xf4, 0x31, 0x80, 0x7c, 0x9b, 0x3e, 0x7e, 0x23, 0x25, 0x29, 0xa6, 0xac, 0x1c,
+	0xa8, 0x3f, 0xc1, 0x61, 0x42, 0xf9, 0x45, 0x6b, 0x76, 0x2a, 0x5a, 0x5f, 0x43, 0x33, 0xf5, 0x2a,
+	0xcb, 0x6a, 0xfe, 0x31, 0x5f, 0xd7, 0xa9, 0xf5, 0x93, 0x06, 0x35, 0x1b, 0x3b, 0xde, 0xea, 0x2c,
+	0x91, 0x77, 0xa5, 0xaf, 0x9e, 0xdf, 0x8c, 0x3a, 0x8b, 0x2b, 0x51, 0xa7, 0xf5, 0xa3, 0x06, 0x75,
+	0x11, 0xdb, 0x5b, 0xce, 0x5a, 0x09, 0x45, 0x5f, 0x2d, 0x94, 0x3f, 0x34, 0x68, 0xbc, 0x8c, 0x3c,
+	0xe5, 0xe2, 0xff, 0x4b, 0x3a, 0x55, 0x2a, 0xa5, 0x94, 0xab, 0x94, 0x45, 0xa2, 0x2d, 0x2f, 0x23,
+	0xda, 0x23, 0x68, 0xa6, 0xc9, 0x48, 0x64, 0xf3, 0x48, 0x6a, 0xab, 0xd7, 0x0f, 0x9b, 0x4d, 0x7a,
+	0x9c, 0x8f, 0xfe, 0x85, 0x0a, 0x52, 0xf2, 0x2e, 0xe6, 0x5f, 0xc8, 0x2f, 0x1a, 0x6c, 0xf2, 0x99,
+	0xcc, 0xc6, 0x71, 0x98, 0x10, 0x17, 0x1f, 0x05, 0x3e, 0x3d, 0xe4, 0x04, 0xf2, 0xf6, 0xaa, 0xa6,
+	0x05, 0x15, 0xd1, 0x5b, 0x59, 0xd0, 0x9c, 0xaf, 0xa5, 0x78, 0xf5, 0xd2, 0xfe, 0x10, 0x8c, 0xc7,
+	0x98, 0x9e, 0xba, 0x67, 0x78, 0xe2, 0xa4, 0xc0, 0x29, 0x93, 0x17, 0x0b, 0xb6, 0x34, 0x9b, 0xbc,
+	0x6e, 0xc3, 0x4d, 0xc5, 0x5a, 0x5e, 0xd9, 0x06, 0x94, 0x63, 0xbe, 0x22, 0x53, 0x93, 0xd2, 0xee,
+	0xcf, 0x15, 0x30, 0x52, 0x14, 0x4e, 0xd2, 0xa9, 0x6e, 0x1f, 0x6a, 0x7c, 0xa0, 0x10, 0x03, 0x2c,
+	0x5a, 0x18, 0x41, 0x64, 0x0c, 0x66, 0x6b, 0x51, 0x21, 0xdc, 0x59, 0x6b, 0xe8, 0x11, 0x00, 0xa7,
+	0x4e, 0x71, 0xc4, 0xc6, 0x42, 0x17, 0x10, 0x27, 0x6c, 0x5e, 0xd0, 0x1d, 0xac, 0x35, 0xf6, 0x4b,
+	0x92, 0x0d, 0xd0, 0xe8, 0xd6, 0x25, 0x3f, 0x23, 0xe6, 0xf6, 0x72, 0xa5, 0x12, 0x4a, 0x59, 0x8c,
+	0xa2, 0x48, 0x0d, 0x38, 0x37, 0x23, 0x9b, 0x5b, 0x4b, 0x34, 0xd9, 0x01, 0x8f, 0xa1, 0x7e, 0x4a,
+	0x09, 0x76, 0x26, 0xff, 0xe8, 0x98, 0x3b, 0x1a, 0x7a, 0x00, 0x25, 0x8e, 0xd3, 0xf5, 0x20, 0xbd,
+	0x07, 0x45, 0xde, 0x19, 0xaf, 0x01, 0xe6, 0x23, 0x28, 0x8b, 0x9e, 0x90, 0x8b, 0x3d, 0xd7, 0x9c,
+	0xcc, 0xad, 0x25, 0x1a, 0xd5, 0x37, 0x23, 0xd7, 0x9c, 0x6f, 0xa5, 0x13, 0x98, 0x9b, 0x0b, 0xeb,
+	0xaa, 0x6f, 0xc1, 0x1f, 0x39, 0xdf, 0x39, 0x7e, 0x34, 0xb7, 0x96, 0x68, 0xb2, 0x03, 0x1e, 0x40,
+	0x59, 0x90, 0x46, 0xee, 0x80, 0x1c, 0x8f, 0x98, 0x1b, 0x0b, 0x6f, 0xa8, 0xcf, 0xfe, 0x88, 0xad,
+	0x35, 0x74, 0x1f, 0xca, 0x07, 0x4e, 0xe0, 0xe2, 0x31, 0xba, 0xc0, 0xe6, 0x92, 0xbd, 0x9f, 0x42,
+	0xe3, 0x31, 0xa6, 0x27, 0xfc, 0xcf, 0xfb, 0x28, 0x18, 0x84, 0x17, 0x1e, 0xf1, 0x8e, 0x3a, 0x4c,
+	0x64, 0xe6, 0xa2, 0x8a, 0xb3, 0xc7, 0x98, 0xab, 0xe2, 0xf9, 0x07, 0x6d, 0x6e, 0x2f, 0x57, 0xa6,
+	0x28, 0xbc, 0x2e, 0x73, 0x97, 0x77, 0xff, 0x1e, 0x00, 0x34, 0xb0, 0x0c, 0xd3, 0x24, 0x10, 0x00,
+	0x00,
 }